@@ -0,0 +1,224 @@
+package breez_sdk
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+const (
+	msatPerSat = 1_000
+	msatPerBtc = 100_000_000_000
+)
+
+var (
+	// ErrAmountOverflow is returned by Amount arithmetic and conversions whose
+	// result does not fit in a uint64 of msat.
+	ErrAmountOverflow = fmt.Errorf("amount overflows")
+	// ErrAmountNegative is returned when a result would be below zero.
+	ErrAmountNegative = fmt.Errorf("amount is negative")
+)
+
+// Amount is a bitcoin amount in msat. The request fields of the bindings stay
+// raw uint64 msat; Amount converts from and to them without rounding.
+type Amount uint64
+
+// Msat returns an Amount of msat.
+func Msat(msat uint64) Amount {
+	return Amount(msat)
+}
+
+// Sat returns an Amount of sat, or ErrAmountOverflow.
+func Sat(sat uint64) (Amount, error) {
+	if sat > math.MaxUint64/msatPerSat {
+		return 0, ErrAmountOverflow
+	}
+	return Amount(sat * msatPerSat), nil
+}
+
+// ParseBtc parses a decimal BTC amount such as "0.00012345" exactly, with up to
+// 11 fraction digits.
+func ParseBtc(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	integer, fraction, _ := strings.Cut(s, ".")
+	if integer == "" && fraction == "" || strings.HasPrefix(integer, "+") || strings.HasPrefix(integer, "-") {
+		return 0, fmt.Errorf("invalid BTC amount %q", s)
+	}
+	if len(fraction) > 11 {
+		return 0, fmt.Errorf("invalid BTC amount %q: more precise than a msat", s)
+	}
+	var btc uint64
+	if integer != "" {
+		var err error
+		if btc, err = strconv.ParseUint(integer, 10, 64); err != nil {
+			return 0, fmt.Errorf("invalid BTC amount %q", s)
+		}
+	}
+	var fractionMsat uint64
+	if fraction != "" {
+		var err error
+		if fractionMsat, err = strconv.ParseUint(fraction+strings.Repeat("0", 11-len(fraction)), 10, 64); err != nil {
+			return 0, fmt.Errorf("invalid BTC amount %q", s)
+		}
+	}
+	if btc > (math.MaxUint64-fractionMsat)/msatPerBtc {
+		return 0, ErrAmountOverflow
+	}
+	return Amount(btc*msatPerBtc + fractionMsat), nil
+}
+
+// FiatToAmount converts value of a fiat currency to the nearest msat at rate,
+// the price of a bitcoin in that currency as returned by FetchFiatRates.
+func FiatToAmount(value float64, rate float64) (Amount, error) {
+	if rate <= 0 || math.IsNaN(rate) || math.IsInf(rate, 0) || math.IsNaN(value) {
+		return 0, fmt.Errorf("invalid fiat rate %v", rate)
+	}
+	if value < 0 {
+		return 0, ErrAmountNegative
+	}
+	msat := math.Round(value / rate * msatPerBtc)
+	if msat >= math.MaxUint64 {
+		return 0, ErrAmountOverflow
+	}
+	return Amount(msat), nil
+}
+
+// Msat returns the amount in msat.
+func (a Amount) Msat() uint64 {
+	return uint64(a)
+}
+
+// Sat returns the whole sats of the amount, dropping a fraction of a sat.
+func (a Amount) Sat() uint64 {
+	return uint64(a) / msatPerSat
+}
+
+// SatCeil returns the amount in sats, rounding a fraction of a sat up, as is
+// needed for amounts to be paid.
+func (a Amount) SatCeil() uint64 {
+	sat := uint64(a) / msatPerSat
+	if uint64(a)%msatPerSat != 0 {
+		sat++
+	}
+	return sat
+}
+
+// HasSubSat reports whether the amount has a fraction of a sat, which onchain
+// transactions cannot carry.
+func (a Amount) HasSubSat() bool {
+	return uint64(a)%msatPerSat != 0
+}
+
+// Btc returns the amount in BTC, for display only: large amounts lose msat
+// precision as a float64. Use String or Format to show exact amounts.
+func (a Amount) Btc() float64 {
+	return float64(a/msatPerBtc) + float64(a%msatPerBtc)/msatPerBtc
+}
+
+// Fiat returns the value of the amount at rate, the price of a bitcoin in a
+// fiat currency.
+func (a Amount) Fiat(rate float64) float64 {
+	return a.Btc() * rate
+}
+
+// Add returns a+b, or ErrAmountOverflow.
+func (a Amount) Add(b Amount) (Amount, error) {
+	if a > math.MaxUint64-b {
+		return 0, ErrAmountOverflow
+	}
+	return a + b, nil
+}
+
+// Sub returns a-b, or ErrAmountNegative if b is larger.
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if b > a {
+		return 0, ErrAmountNegative
+	}
+	return a - b, nil
+}
+
+// Mul returns a*n, or ErrAmountOverflow.
+func (a Amount) Mul(n uint64) (Amount, error) {
+	if n != 0 && uint64(a) > math.MaxUint64/n {
+		return 0, ErrAmountOverflow
+	}
+	return a * Amount(n), nil
+}
+
+// MulPpm returns the amount times ppm millionths, rounded up like the
+// proportional fees of OpeningFeeParams, or ErrAmountOverflow.
+func (a Amount) MulPpm(ppm uint32) (Amount, error) {
+	hi, lo := bits.Mul64(uint64(a), uint64(ppm))
+	lo, carry := bits.Add64(lo, 999_999, 0)
+	hi += carry
+	if hi >= 1_000_000 {
+		return 0, ErrAmountOverflow
+	}
+	quotient, _ := bits.Div64(hi, lo, 1_000_000)
+	return Amount(quotient), nil
+}
+
+// Format formats the amount in unit like FormatMsat.
+func (a Amount) Format(unit Unit) string {
+	return FormatMsat(uint64(a), unit)
+}
+
+// FormatFiat formats the value of the amount at rate like FormatFiat.
+func (a Amount) FormatFiat(rate float64, info CurrencyInfo, locale string) string {
+	return FormatFiat(a.Fiat(rate), info, locale)
+}
+
+// String formats the amount in sats, such as "1,234.5 sat".
+func (a Amount) String() string {
+	return a.Format(UnitSat)
+}
+
+// NewReceivePaymentRequest returns a ReceivePaymentRequest of amount, whose
+// optional fields can be set on the result.
+func NewReceivePaymentRequest(amount Amount, description string) ReceivePaymentRequest {
+	return ReceivePaymentRequest{AmountMsat: amount.Msat(), Description: description}
+}
+
+// NewSendPaymentRequest returns a SendPaymentRequest paying bolt11. amount is
+// only set for invoices without one and may be nil otherwise.
+func NewSendPaymentRequest(bolt11 string, amount *Amount) SendPaymentRequest {
+	req := SendPaymentRequest{Bolt11: bolt11}
+	if amount != nil {
+		msat := amount.Msat()
+		req.AmountMsat = &msat
+	}
+	return req
+}
+
+// NewSendSpontaneousPaymentRequest returns a keysend request of amount to
+// nodeId.
+func NewSendSpontaneousPaymentRequest(nodeId string, amount Amount) SendSpontaneousPaymentRequest {
+	return SendSpontaneousPaymentRequest{NodeId: nodeId, AmountMsat: amount.Msat()}
+}
+
+// NewLnUrlPayRequest returns an LnUrlPayRequest of amount, which must be
+// within the MinSendable and MaxSendable of data.
+func NewLnUrlPayRequest(data LnUrlPayRequestData, amount Amount) (LnUrlPayRequest, error) {
+	if amount.Msat() < data.MinSendable || amount.Msat() > data.MaxSendable {
+		return LnUrlPayRequest{}, fmt.Errorf("amount %s outside of %s to %s", amount, Msat(data.MinSendable), Msat(data.MaxSendable))
+	}
+	return LnUrlPayRequest{Data: data, AmountMsat: amount.Msat()}, nil
+}
+
+// NewLnUrlWithdrawRequest returns an LnUrlWithdrawRequest of amount, which
+// must be within the MinWithdrawable and MaxWithdrawable of data.
+func NewLnUrlWithdrawRequest(data LnUrlWithdrawRequestData, amount Amount) (LnUrlWithdrawRequest, error) {
+	if amount.Msat() < data.MinWithdrawable || amount.Msat() > data.MaxWithdrawable {
+		return LnUrlWithdrawRequest{}, fmt.Errorf("amount %s outside of %s to %s", amount, Msat(data.MinWithdrawable), Msat(data.MaxWithdrawable))
+	}
+	return LnUrlWithdrawRequest{Data: data, AmountMsat: amount.Msat()}, nil
+}
+
+// NewOpenChannelFeeRequest returns an OpenChannelFeeRequest for a channel
+// opened by a payment of amount.
+func NewOpenChannelFeeRequest(amount Amount) OpenChannelFeeRequest {
+	msat := amount.Msat()
+	return OpenChannelFeeRequest{AmountMsat: &msat}
+}
@@ -0,0 +1,68 @@
+package breez_sdk
+
+import "context"
+
+// BackupStage is a step of a backup reported to OnBackupProgress.
+type BackupStage int
+
+const (
+	BackupStageStarted BackupStage = iota
+	BackupStageSucceeded
+	BackupStageFailed
+)
+
+func (s BackupStage) String() string {
+	switch s {
+	case BackupStageStarted:
+		return "started"
+	case BackupStageSucceeded:
+		return "succeeded"
+	case BackupStageFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// BackupProgress reports the stage of a running backup. Error is set for
+// BackupStageFailed.
+type BackupProgress struct {
+	Stage BackupStage
+	Error string
+}
+
+// OnBackupProgress calls fn whenever a backup, whether triggered by Backup or
+// by the node itself, changes stage. The returned function stops the
+// callbacks, as does Close.
+func (_self *BlockingBreezServices) OnBackupProgress(fn func(BackupProgress)) (stop func()) {
+	unsubscribe := _self.subscribeEvents(func(e BreezEvent) {
+		switch e := e.(type) {
+		case BreezEventBackupStarted:
+			fn(BackupProgress{Stage: BackupStageStarted})
+		case BreezEventBackupSucceeded:
+			fn(BackupProgress{Stage: BackupStageSucceeded})
+		case BreezEventBackupFailed:
+			fn(BackupProgress{Stage: BackupStageFailed, Error: e.Details.Error})
+		}
+	})
+	return _self.stopOnClose(unsubscribe)
+}
+
+// BackupWithContext is Backup returning ctx.Err() as soon as ctx is done. The
+// library cannot abort a backup that has started, so it keeps running in the
+// background and its outcome is only reported to OnBackupProgress.
+func (_self *BlockingBreezServices) BackupWithContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- _self.Guarded().Backup()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
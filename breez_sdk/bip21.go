@@ -0,0 +1,183 @@
+package breez_sdk
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Bip21Uri is a BIP-21 URI with both an onchain address and a lightning
+// invoice. ParseInput only returns the invoice of such URIs.
+type Bip21Uri struct {
+	Address BitcoinAddressData
+	Invoice LnInvoice
+}
+
+func (u Bip21Uri) Destroy() {
+	FfiDestroyerTypeBitcoinAddressData{}.destroy(u.Address)
+	FfiDestroyerTypeLnInvoice{}.destroy(u.Invoice)
+}
+
+// UnifiedInput is the result of ParseUnifiedInput: Bip21 for a unified BIP-21
+// URI, Input for anything else. Exactly one of them is set.
+type UnifiedInput struct {
+	Input InputType
+	Bip21 *Bip21Uri
+}
+
+func (u UnifiedInput) Destroy() {
+	if u.Input != nil {
+		u.Input.Destroy()
+	}
+	if u.Bip21 != nil {
+		u.Bip21.Destroy()
+	}
+}
+
+// ParseUnifiedInput is ParseInput keeping both parts of unified BIP-21 URIs,
+// "bitcoin:<address>?lightning=<bolt11>", as a Bip21Uri. Other input,
+// including a URI without an address, is parsed by ParseInput.
+func ParseUnifiedInput(s string) (UnifiedInput, error) {
+	s = strings.TrimSpace(s)
+	scheme, rest, ok := strings.Cut(s, ":")
+	if !ok || !strings.EqualFold(scheme, "bitcoin") {
+		return parseInput(s)
+	}
+	address, rawQuery, _ := strings.Cut(rest, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return parseInput(s)
+	}
+	var bolt11 string
+	for key, values := range query {
+		if strings.EqualFold(key, "lightning") && len(values) > 0 {
+			bolt11 = values[0]
+			query.Del(key)
+		}
+	}
+	if bolt11 == "" || address == "" {
+		return parseInput(s)
+	}
+
+	onchain := "bitcoin:" + address
+	if len(query) > 0 {
+		onchain += "?" + query.Encode()
+	}
+	parsed, err := ParseInput(onchain)
+	if err != nil {
+		return UnifiedInput{}, err
+	}
+	addressInput, ok := parsed.(InputTypeBitcoinAddress)
+	if !ok {
+		return UnifiedInput{}, fmt.Errorf("BIP-21 URI with an invalid address %q", address)
+	}
+	invoice, err := ParseInvoice(bolt11)
+	if err != nil {
+		return UnifiedInput{}, err
+	}
+	return UnifiedInput{Bip21: &Bip21Uri{Address: addressInput.Address, Invoice: invoice}}, nil
+}
+
+func parseInput(s string) (UnifiedInput, error) {
+	input, err := ParseInput(s)
+	if err != nil {
+		return UnifiedInput{}, err
+	}
+	return UnifiedInput{Input: input}, nil
+}
+
+// UnifiedPaymentResult is the result of PayUnified: Lightning is set if the
+// invoice was paid, Onchain if a reverse swap to the address was started.
+type UnifiedPaymentResult struct {
+	Lightning *SendPaymentResponse
+	Onchain   *PayOnchainResponse
+}
+
+// ErrNoPaymentMethod is returned by PayUnified when neither the invoice nor the
+// address of the URI can be paid.
+var ErrNoPaymentMethod = fmt.Errorf("no usable payment method in the URI")
+
+// PayUnified pays a unified BIP-21 URI, a bolt11 invoice or an onchain address
+// with an amount. The invoice is usable while it has not expired and its
+// amount, or the URI's for an invoice without one, is within MaxPayableMsat.
+// The address is usable when the URI has an amount within the reverse swap
+// limits, and it is paid at the recommended half-hour fee. The preferred
+// method is used if usable, the other one otherwise. A failed lightning
+// payment does not fall back to onchain, as it may still be pending.
+func (_self *BlockingBreezServices) PayUnified(uri string, preferLightning bool) (UnifiedPaymentResult, error) {
+	parsed, err := ParseUnifiedInput(uri)
+	if err != nil {
+		return UnifiedPaymentResult{}, err
+	}
+	var invoice *LnInvoice
+	var address *BitcoinAddressData
+	switch input := parsed.Input.(type) {
+	case InputTypeBolt11:
+		invoice = &input.Invoice
+	case InputTypeBitcoinAddress:
+		address = &input.Address
+	default:
+		if parsed.Bip21 == nil {
+			return UnifiedPaymentResult{}, fmt.Errorf("%w: unsupported input %T", ErrNoPaymentMethod, input)
+		}
+		invoice, address = &parsed.Bip21.Invoice, &parsed.Bip21.Address
+	}
+
+	// An invoice without an amount is paid the amount of the URI.
+	lightningReq := SendPaymentRequest{}
+	var amountMsat uint64
+	lightningUsable := false
+	if invoice != nil {
+		lightningReq.Bolt11 = invoice.Bolt11
+		switch {
+		case invoice.AmountMsat != nil:
+			amountMsat = *invoice.AmountMsat
+		case address != nil && address.AmountSat != nil:
+			amountMsat = *address.AmountSat * msatPerSat
+			lightningReq.AmountMsat = &amountMsat
+		}
+	}
+	if amountMsat > 0 && !invoice.IsExpired(_self.Clock().Now()) {
+		node, err := _self.Guarded().NodeInfo()
+		if err != nil {
+			return UnifiedPaymentResult{}, err
+		}
+		lightningUsable = amountMsat <= node.MaxPayableMsat
+	}
+	onchainUsable := false
+	if address != nil && address.AmountSat != nil {
+		limits, err := _self.Guarded().OnchainPaymentLimits()
+		if err != nil {
+			return UnifiedPaymentResult{}, err
+		}
+		onchainUsable = *address.AmountSat >= limits.MinSat && *address.AmountSat <= limits.MaxSat
+	}
+
+	switch {
+	case lightningUsable && (preferLightning || !onchainUsable):
+		res, err := _self.Guarded().SendPayment(lightningReq)
+		if err != nil {
+			return UnifiedPaymentResult{}, err
+		}
+		return UnifiedPaymentResult{Lightning: &res}, nil
+	case onchainUsable:
+		fees, err := _self.Guarded().RecommendedFees()
+		if err != nil {
+			return UnifiedPaymentResult{}, err
+		}
+		prepared, err := _self.Guarded().PrepareOnchainPayment(PrepareOnchainPaymentRequest{
+			AmountSat:      *address.AmountSat,
+			AmountType:     SwapAmountTypeReceive,
+			ClaimTxFeerate: uint32(fees.HalfHourFee),
+		})
+		if err != nil {
+			return UnifiedPaymentResult{}, err
+		}
+		res, err := _self.Guarded().PayOnchain(PayOnchainRequest{RecipientAddress: address.Address, PrepareRes: prepared})
+		if err != nil {
+			return UnifiedPaymentResult{}, err
+		}
+		return UnifiedPaymentResult{Onchain: &res}, nil
+	}
+	return UnifiedPaymentResult{}, ErrNoPaymentMethod
+}
@@ -0,0 +1,174 @@
+package breez_sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultDohUrl is the DNS-over-HTTPS resolver of Bip353Resolver when DohUrl
+// is not set.
+const DefaultDohUrl = "https://cloudflare-dns.com/dns-query"
+
+var (
+	// ErrNotBip353Address is returned by ResolveBip353 for input that is not
+	// a human-readable bitcoin address.
+	ErrNotBip353Address = fmt.Errorf("not a BIP-353 address")
+	// ErrBip353NotFound is returned when the domain publishes no payment
+	// instructions for the user.
+	ErrBip353NotFound = fmt.Errorf("no BIP-353 payment instructions found")
+	// ErrDnssecUnvalidated is returned when RequireDnssec is set and the
+	// resolver did not validate the answer.
+	ErrDnssecUnvalidated = fmt.Errorf("BIP-353 record not validated by DNSSEC")
+)
+
+// Bip353Resolver resolves BIP-353 addresses through a DNS-over-HTTPS resolver
+// speaking the JSON API of Cloudflare and Google.
+type Bip353Resolver struct {
+	// DohUrl defaults to DefaultDohUrl.
+	DohUrl string
+	// RequireDnssec rejects answers the resolver did not validate, which
+	// BIP-353 requires. Only disable it for testing.
+	RequireDnssec bool
+	// Client defaults to HttpClient.
+	Client *http.Client
+}
+
+// Bip353Instructions are the payment instructions published for an address.
+type Bip353Instructions struct {
+	Address string
+	// Uri is the BIP-21 URI of the TXT record.
+	Uri string
+	// OnchainAddress is the address part of Uri, if any.
+	OnchainAddress string
+	// Offer is the BOLT12 offer of the lno parameter, Bolt11 the invoice of
+	// the lightning parameter.
+	Offer  *string
+	Bolt11 *string
+	// Params holds every query parameter of Uri, keys lower-cased.
+	Params url.Values
+	// DnssecValidated tells whether the resolver validated the answer.
+	DnssecValidated bool
+}
+
+// ResolveBip353 resolves address, "user@domain" with an optional "₿" prefix,
+// with a Bip353Resolver requiring DNSSEC.
+func ResolveBip353(ctx context.Context, address string) (Bip353Instructions, error) {
+	return Bip353Resolver{RequireDnssec: true}.Resolve(ctx, address)
+}
+
+// Resolve looks up the TXT record user.user._bitcoin-payment.domain of address
+// and parses its BIP-21 URI, without paying it.
+func (r Bip353Resolver) Resolve(ctx context.Context, address string) (Bip353Instructions, error) {
+	address = strings.TrimPrefix(strings.TrimSpace(address), "₿")
+	user, domain, ok := strings.Cut(address, "@")
+	if !ok || user == "" || domain == "" || strings.ContainsAny(user+domain, "@/ ") {
+		return Bip353Instructions{}, ErrNotBip353Address
+	}
+	name := user + ".user._bitcoin-payment." + strings.TrimSuffix(domain, ".")
+
+	dohUrl := r.DohUrl
+	if dohUrl == "" {
+		dohUrl = DefaultDohUrl
+	}
+	client := r.Client
+	if client == nil {
+		client = HttpClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, dohUrl+"?"+url.Values{"name": {name}, "type": {"TXT"}, "do": {"1"}}.Encode(), nil)
+	if err != nil {
+		return Bip353Instructions{}, err
+	}
+	req.Header.Set("Accept", "application/dns-json")
+	res, err := client.Do(req)
+	if err != nil {
+		return Bip353Instructions{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return Bip353Instructions{}, fmt.Errorf("resolving %s: %s", name, res.Status)
+	}
+	var answer struct {
+		Status int  `json:"Status"`
+		AD     bool `json:"AD"`
+		Answer []struct {
+			Type int    `json:"type"`
+			Data string `json:"data"`
+		} `json:"Answer"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&answer); err != nil {
+		return Bip353Instructions{}, fmt.Errorf("resolving %s: %w", name, err)
+	}
+
+	const typeTxt = 16
+	var uris []string
+	for _, record := range answer.Answer {
+		if record.Type != typeTxt {
+			continue
+		}
+		text := joinTxtStrings(record.Data)
+		if strings.HasPrefix(strings.ToLower(text), "bitcoin:") {
+			uris = append(uris, text)
+		}
+	}
+	switch {
+	case len(uris) == 0:
+		return Bip353Instructions{}, ErrBip353NotFound
+	case len(uris) > 1:
+		return Bip353Instructions{}, fmt.Errorf("resolving %s: %d payment instructions, BIP-353 allows one", name, len(uris))
+	}
+	if r.RequireDnssec && !answer.AD {
+		return Bip353Instructions{}, ErrDnssecUnvalidated
+	}
+
+	instructions := Bip353Instructions{
+		Address:         user + "@" + domain,
+		Uri:             uris[0],
+		Params:          url.Values{},
+		DnssecValidated: answer.AD,
+	}
+	onchain, rawQuery, _ := strings.Cut(uris[0][len("bitcoin:"):], "?")
+	instructions.OnchainAddress = onchain
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return Bip353Instructions{}, fmt.Errorf("resolving %s: %w", name, err)
+	}
+	for key, values := range query {
+		instructions.Params[strings.ToLower(key)] = values
+	}
+	if offer := instructions.Params.Get("lno"); offer != "" {
+		instructions.Offer = &offer
+	}
+	if bolt11 := instructions.Params.Get("lightning"); bolt11 != "" {
+		instructions.Bolt11 = &bolt11
+	}
+	return instructions, nil
+}
+
+// joinTxtStrings joins the quoted character strings of TXT record data, which
+// long records are split into.
+func joinTxtStrings(data string) string {
+	data = strings.TrimSpace(data)
+	if !strings.HasPrefix(data, `"`) {
+		return data
+	}
+	var b strings.Builder
+	for data != "" {
+		quoted, err := strconv.QuotedPrefix(data)
+		if err != nil {
+			b.WriteString(strings.Trim(data, `"`))
+			break
+		}
+		unquoted, err := strconv.Unquote(quoted)
+		if err != nil {
+			unquoted = strings.Trim(quoted, `"`)
+		}
+		b.WriteString(unquoted)
+		data = strings.TrimSpace(data[len(quoted):])
+	}
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package breez_sdk
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrNoBreezserver is wrapped by the errors of SelectBreezserver and
+// ConnectWithFailover when no Breez server could be used.
+var ErrNoBreezserver = fmt.Errorf("no Breez server reachable")
+
+// DefaultBreezserverProbeTimeout bounds each probe of SelectBreezserver.
+const DefaultBreezserverProbeTimeout = 5 * time.Second
+
+// SelectBreezserver returns the first of urls, in order, that accepts a TLS
+// connection within timeout, DefaultBreezserverProbeTimeout if zero.
+func SelectBreezserver(ctx context.Context, urls []string, timeout time.Duration) (string, error) {
+	if timeout == 0 {
+		timeout = DefaultBreezserverProbeTimeout
+	}
+	var failures []string
+	for _, server := range urls {
+		err := probeBreezserver(ctx, server, timeout)
+		if err == nil {
+			return server, nil
+		}
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", server, err))
+	}
+	return "", fmt.Errorf("%w: %s", ErrNoBreezserver, strings.Join(failures, "; "))
+}
+
+func probeBreezserver(ctx context.Context, server string, timeout time.Duration) error {
+	parsed, err := url.Parse(server)
+	if err != nil {
+		return err
+	}
+	address := parsed.Host
+	if parsed.Port() == "" {
+		address = net.JoinHostPort(parsed.Hostname(), "443")
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	dialer := &tls.Dialer{Config: &tls.Config{ServerName: parsed.Hostname(), NextProtos: []string{"h2"}}}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// ConnectWithFailover connects with req.Config.Breezserver set to each of urls
+// in turn, skipping those that fail a probe, until Connect succeeds or fails
+// with an error other than ServiceConnectivity. The library uses one Breez
+// server for the lifetime of the connection; to fail over later, Close the
+// service and connect again, for example when a breaker from the breaker
+// package opens.
+func ConnectWithFailover(ctx context.Context, req ConnectRequest, listener EventListener, urls []string) (*BlockingBreezServices, error) {
+	var failures []string
+	for _, server := range urls {
+		if err := probeBreezserver(ctx, server, DefaultBreezserverProbeTimeout); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			failures = append(failures, fmt.Sprintf("%s: %v", server, err))
+			continue
+		}
+		attempt := req
+		attempt.Config.Breezserver = server
+		services, err := connect(attempt, listener)
+		if err == nil {
+			return services, nil
+		}
+		if !errors.Is(err, ErrConnectErrorServiceConnectivity) {
+			return nil, err
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", server, err))
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNoBreezserver, strings.Join(failures, "; "))
+}
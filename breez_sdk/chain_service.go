@@ -0,0 +1,93 @@
+package breez_sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrNoChainService is wrapped by the error of ChainServiceConfig.Select when
+// no backend is reachable.
+var ErrNoChainService = fmt.Errorf("no chain service reachable")
+
+// DefaultChainProbeTimeout bounds each probe of ChainServiceConfig.Select.
+const DefaultChainProbeTimeout = 5 * time.Second
+
+// ChainServiceConfig lists the chain backends of a node in failover order.
+//
+// The library talks to the chain through the mempool.space REST API only, so
+// the backends are mempool.space API roots, such as
+// "https://mempool.example.com/api". Self-hosted Esplora or Electrum servers
+// are used by running the mempool backend on top of them, which it supports
+// natively.
+type ChainServiceConfig struct {
+	MempoolspaceUrls []string
+	// ProbeTimeout defaults to DefaultChainProbeTimeout.
+	ProbeTimeout time.Duration
+	// Client defaults to HttpClient.
+	Client *http.Client
+}
+
+// Select returns the first backend that answers the fee and tip height
+// endpoints the library relies on.
+func (c ChainServiceConfig) Select(ctx context.Context) (string, error) {
+	if len(c.MempoolspaceUrls) == 0 {
+		return "", fmt.Errorf("%w: no backends configured", ErrNoChainService)
+	}
+	var failures []string
+	for _, root := range c.MempoolspaceUrls {
+		err := c.probe(ctx, strings.TrimSuffix(root, "/"))
+		if err == nil {
+			return root, nil
+		}
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", root, err))
+	}
+	return "", fmt.Errorf("%w: %s", ErrNoChainService, strings.Join(failures, "; "))
+}
+
+// Apply sets config.MempoolspaceUrl to the backend chosen by Select. The
+// library keeps that backend for the lifetime of the connection, so Apply is
+// called before Connect, or before reconnecting after the backend failed.
+func (c ChainServiceConfig) Apply(ctx context.Context, config *Config) error {
+	root, err := c.Select(ctx)
+	if err != nil {
+		return err
+	}
+	config.MempoolspaceUrl = &root
+	return nil
+}
+
+func (c ChainServiceConfig) probe(ctx context.Context, root string) error {
+	timeout := c.ProbeTimeout
+	if timeout == 0 {
+		timeout = DefaultChainProbeTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	client := c.Client
+	if client == nil {
+		client = HttpClient
+	}
+
+	for _, path := range []string{"/blocks/tip/height", "/v1/fees/recommended"} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, root+path, nil)
+		if err != nil {
+			return err
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			return errors.New(path + ": " + res.Status)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package breez_sdk
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Channel is a channel of the node.
+type Channel struct {
+	ChannelId      string
+	PeerId         string
+	PeerConnected  bool
+	State          ChannelState
+	ShortChannelId *string
+	FundingTxid    string
+	CapacityMsat   uint64
+	// LocalBalanceMsat is the part of the capacity owned by the node,
+	// RemoteBalanceMsat the peer's, both before reserves.
+	LocalBalanceMsat  uint64
+	RemoteBalanceMsat uint64
+}
+
+// ListChannels returns the channels of the node, ordered by channel id. The
+// bindings have no channel call, so the list is read from the node's
+// listpeerchannels dev command, whose output is not a stable interface.
+func (_self *BlockingBreezServices) ListChannels() ([]Channel, error) {
+	output, err := _self.Guarded().ExecuteDevCommand(string(DevCommandListPeerChannels))
+	if err != nil {
+		return nil, err
+	}
+	return parsePeerChannels(output)
+}
+
+// peerChannel is an entry of the listpeerchannels output. Byte fields may be
+// hex strings or arrays of bytes and amounts may be numbers, "<n>msat" strings
+// or {"msat": n} objects, depending on how the node serializes them.
+type peerChannel struct {
+	PeerId         devBytes        `json:"peer_id"`
+	PeerConnected  bool            `json:"peer_connected"`
+	State          json.RawMessage `json:"state"`
+	ShortChannelId *string         `json:"short_channel_id"`
+	ChannelId      devBytes        `json:"channel_id"`
+	FundingTxid    devBytes        `json:"funding_txid"`
+	TotalMsat      devMsat         `json:"total_msat"`
+	ToUsMsat       devMsat         `json:"to_us_msat"`
+}
+
+func parsePeerChannels(output string) ([]Channel, error) {
+	var res struct {
+		Channels []peerChannel `json:"channels"`
+	}
+	if err := json.Unmarshal([]byte(output), &res); err != nil {
+		return nil, fmt.Errorf("parsing listpeerchannels: %w", err)
+	}
+	channels := make([]Channel, 0, len(res.Channels))
+	for _, c := range res.Channels {
+		state, err := clnChannelState(c.State)
+		if err != nil {
+			return nil, fmt.Errorf("parsing listpeerchannels: %w", err)
+		}
+		channel := Channel{
+			ChannelId:         string(c.ChannelId),
+			PeerId:            string(c.PeerId),
+			PeerConnected:     c.PeerConnected,
+			State:             state,
+			ShortChannelId:    c.ShortChannelId,
+			FundingTxid:       string(c.FundingTxid),
+			CapacityMsat:      uint64(c.TotalMsat),
+			LocalBalanceMsat:  uint64(c.ToUsMsat),
+			RemoteBalanceMsat: uint64(c.TotalMsat) - uint64(c.ToUsMsat),
+		}
+		if c.ToUsMsat > c.TotalMsat {
+			channel.RemoteBalanceMsat = 0
+		}
+		channels = append(channels, channel)
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i].ChannelId < channels[j].ChannelId })
+	return channels, nil
+}
+
+// clnChannelStates are the channel states of Core Lightning, in the order of
+// their numeric values.
+var clnChannelStates = []string{
+	"OPENINGD",
+	"CHANNELD_AWAITING_LOCKIN",
+	"CHANNELD_NORMAL",
+	"CHANNELD_SHUTTING_DOWN",
+	"CLOSINGD_SIGEXCHANGE",
+	"CLOSINGD_COMPLETE",
+	"AWAITING_UNILATERAL",
+	"FUNDING_SPEND_SEEN",
+	"ONCHAIN",
+	"DUALOPEND_OPEN_INIT",
+	"DUALOPEND_AWAITING_LOCKIN",
+	"CHANNELD_AWAITING_SPLICE",
+}
+
+func clnChannelState(raw json.RawMessage) (ChannelState, error) {
+	var name string
+	if err := json.Unmarshal(raw, &name); err != nil {
+		var index int
+		if err := json.Unmarshal(raw, &index); err != nil || index < 0 || index >= len(clnChannelStates) {
+			return 0, fmt.Errorf("unknown channel state %s", raw)
+		}
+		name = clnChannelStates[index]
+	}
+	switch strings.ToUpper(name) {
+	case "OPENINGD", "CHANNELD_AWAITING_LOCKIN", "DUALOPEND_OPEN_INIT", "DUALOPEND_AWAITING_LOCKIN":
+		return ChannelStatePendingOpen, nil
+	case "CHANNELD_NORMAL", "CHANNELD_AWAITING_SPLICE":
+		return ChannelStateOpened, nil
+	case "ONCHAIN":
+		return ChannelStateClosed, nil
+	default:
+		return ChannelStatePendingClose, nil
+	}
+}
+
+// devBytes decodes a byte field of dev command output to lower case hex.
+type devBytes string
+
+func (b *devBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*b = devBytes(strings.ToLower(s))
+		return nil
+	}
+	var raw []byte
+	var ints []int
+	if err := json.Unmarshal(data, &ints); err != nil {
+		return err
+	}
+	for _, i := range ints {
+		raw = append(raw, byte(i))
+	}
+	*b = devBytes(hex.EncodeToString(raw))
+	return nil
+}
+
+// devMsat decodes an amount of dev command output.
+type devMsat uint64
+
+func (m *devMsat) UnmarshalJSON(data []byte) error {
+	var object struct {
+		Msat *uint64 `json:"msat"`
+	}
+	if err := json.Unmarshal(data, &object); err == nil && object.Msat != nil {
+		*m = devMsat(*object.Msat)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		value, err := strconv.ParseUint(strings.TrimSuffix(s, "msat"), 10, 64)
+		*m = devMsat(value)
+		return err
+	}
+	var value uint64
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*m = devMsat(value)
+	return nil
+}
+
+// ChannelChangeKind tells what happened to a channel in a ChannelChange.
+type ChannelChangeKind int
+
+const (
+	ChannelOpened ChannelChangeKind = iota
+	ChannelClosed
+)
+
+func (k ChannelChangeKind) String() string {
+	switch k {
+	case ChannelOpened:
+		return "opened"
+	case ChannelClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// ChannelChange is reported by WatchChannels.
+type ChannelChange struct {
+	Kind    ChannelChangeKind
+	Channel Channel
+}
+
+// WatchChannels lists the channels after every Synced event and calls fn for
+// each channel that became open and each one that closed, or vanished, since
+// the previous list. The first list only sets the baseline. onError, if not
+// nil, is called when listing fails. The returned function stops the watch,
+// as does Close.
+func (_self *BlockingBreezServices) WatchChannels(fn func(ChannelChange), onError func(error)) (stop func()) {
+	var lock sync.Mutex
+	var known map[string]Channel
+	stopped := false
+	check := func() {
+		lock.Lock()
+		defer lock.Unlock()
+		if stopped {
+			return
+		}
+		channels, err := _self.ListChannels()
+		if err != nil {
+			if onError != nil {
+				onError(err)
+			}
+			return
+		}
+		current := map[string]Channel{}
+		for _, channel := range channels {
+			current[channel.ChannelId] = channel
+		}
+		if known != nil {
+			for _, channel := range channels {
+				previous, ok := known[channel.ChannelId]
+				if channel.State == ChannelStateOpened && (!ok || previous.State != ChannelStateOpened) {
+					fn(ChannelChange{Kind: ChannelOpened, Channel: channel})
+				}
+				if channel.State == ChannelStateClosed && ok && previous.State != ChannelStateClosed {
+					fn(ChannelChange{Kind: ChannelClosed, Channel: channel})
+				}
+			}
+			var vanished []string
+			for id, previous := range known {
+				if _, ok := current[id]; !ok && previous.State != ChannelStateClosed {
+					vanished = append(vanished, id)
+				}
+			}
+			sort.Strings(vanished)
+			for _, id := range vanished {
+				previous := known[id]
+				previous.State = ChannelStateClosed
+				fn(ChannelChange{Kind: ChannelClosed, Channel: previous})
+			}
+		}
+		known = current
+	}
+
+	go check()
+	unsubscribe := _self.subscribeEvents(func(e BreezEvent) {
+		if _, ok := e.(BreezEventSynced); ok {
+			go check()
+		}
+	})
+	return _self.stopOnClose(func() {
+		unsubscribe()
+		lock.Lock()
+		stopped = true
+		lock.Unlock()
+	})
+}
@@ -0,0 +1,139 @@
+package breez_sdk
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the time source of the Go-side subsystems of a service, such as
+// digests, caches and schedulers. It is replaced with SetClock to test them
+// deterministically.
+type Clock interface {
+	Now() time.Time
+	// AfterFunc calls f once d has elapsed.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a pending Clock.AfterFunc call.
+type Timer interface {
+	// Stop prevents the call from happening. It returns false if the call
+	// already happened or the timer was already stopped.
+	Stop() bool
+}
+
+// SystemClock is the Clock backed by the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// sleep blocks until d has elapsed on clock.
+func sleep(clock Clock, d time.Duration) {
+	done := make(chan struct{})
+	clock.AfterFunc(d, func() { close(done) })
+	<-done
+}
+
+// SetClock replaces the clock used by the Go-side subsystems started on the
+// service afterwards.
+func (_self *BlockingBreezServices) SetClock(clock Clock) {
+	state := _self.state()
+	state.lock.Lock()
+	state.clock = clock
+	state.lock.Unlock()
+	if state.events != nil {
+		state.events.setClock(clock)
+	}
+}
+
+// Clock returns the clock of the service's Go-side subsystems.
+func (_self *BlockingBreezServices) Clock() Clock {
+	state := _self.state()
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	if state.clock == nil {
+		return SystemClock
+	}
+	return state.clock
+}
+
+// ManualClock is a Clock that only moves when told to.
+type ManualClock struct {
+	lock   sync.Mutex
+	now    time.Time
+	nextId uint64
+	timers map[uint64]*manualTimer
+}
+
+// NewManualClock returns a ManualClock set to now.
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{
+		now:    now,
+		timers: map[uint64]*manualTimer{},
+	}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+func (c *ManualClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.nextId++
+	timer := &manualTimer{clock: c, id: c.nextId, at: c.now.Add(d), f: f}
+	c.timers[timer.id] = timer
+	return timer
+}
+
+// Advance moves the clock forward by d and runs the calls that became due, in
+// order, before returning.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	c.now = c.now.Add(d)
+	var due []*manualTimer
+	for id, timer := range c.timers {
+		if !timer.at.After(c.now) {
+			due = append(due, timer)
+			delete(c.timers, id)
+		}
+	}
+	c.lock.Unlock()
+
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].at.Equal(due[j].at) {
+			return due[i].id < due[j].id
+		}
+		return due[i].at.Before(due[j].at)
+	})
+	for _, timer := range due {
+		timer.f()
+	}
+}
+
+type manualTimer struct {
+	clock *ManualClock
+	id    uint64
+	at    time.Time
+	f     func()
+}
+
+func (t *manualTimer) Stop() bool {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+	if _, ok := t.clock.timers[t.id]; !ok {
+		return false
+	}
+	delete(t.clock.timers, t.id)
+	return true
+}
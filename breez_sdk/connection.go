@@ -0,0 +1,142 @@
+package breez_sdk
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ConnectionStatus is the connectivity of the node to the lightning network.
+type ConnectionStatus struct {
+	// ConnectedPeers is the number of peers the node is connected to.
+	ConnectedPeers int
+	// LspConnected is set when one of the peers is the node's LSP. It is
+	// false while no LSP is selected.
+	LspConnected bool
+	// LastSynced is the time of the last Synced event, zero if the node has
+	// not synced since Connect.
+	LastSynced time.Time
+}
+
+// Connected reports whether the node is connected to any peer.
+func (s ConnectionStatus) Connected() bool {
+	return s.ConnectedPeers > 0
+}
+
+// ConnectionStatus returns the current connectivity of the node, as reported
+// by NodeInfo.
+func (_self *BlockingBreezServices) ConnectionStatus() (ConnectionStatus, error) {
+	node, err := _self.Guarded().NodeInfo()
+	if err != nil {
+		return ConnectionStatus{}, err
+	}
+	lspId, err := _self.Guarded().LspId()
+	if err != nil {
+		return ConnectionStatus{}, err
+	}
+
+	status := ConnectionStatus{ConnectedPeers: len(node.ConnectedPeers)}
+	status.LastSynced, _ = _self.LastSynced()
+	if lspId != nil {
+		lsp, err := _self.Guarded().LspInfo()
+		if err != nil {
+			return ConnectionStatus{}, err
+		}
+		for _, peer := range node.ConnectedPeers {
+			if peer == lsp.Pubkey {
+				status.LspConnected = true
+				break
+			}
+		}
+	}
+	return status, nil
+}
+
+// ReconnectNow syncs the node, which reconnects it to its LSP if the
+// connection was lost, and returns the resulting status.
+func (_self *BlockingBreezServices) ReconnectNow() (ConnectionStatus, error) {
+	if err := _self.Guarded().Sync(); err != nil {
+		return ConnectionStatus{}, err
+	}
+	return _self.ConnectionStatus()
+}
+
+// ConnectionStatusChange is reported by WatchConnection when the peer or LSP
+// connectivity of the node changes. Err is set when the status could not be
+// read, in which case Status is not meaningful.
+type ConnectionStatusChange struct {
+	At       time.Time
+	Status   ConnectionStatus
+	Err      error
+	Previous *ConnectionStatusChange
+}
+
+// WatchConnection reads the connection status right away and then every
+// interval, calling fn with the first result and whenever the node gains or
+// loses its peers or its LSP. Changes of LastSynced alone are not reported.
+// interval must be positive. The returned function stops the watch; Close
+// does the same.
+func (_self *BlockingBreezServices) WatchConnection(interval time.Duration, fn func(ConnectionStatusChange)) (stop func(), err error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid connection watch interval %v", interval)
+	}
+	w := &connectionWatch{
+		service:  _self,
+		clock:    _self.Clock(),
+		interval: interval,
+		fn:       fn,
+	}
+	w.lock.Lock()
+	w.timer = w.clock.AfterFunc(0, w.check)
+	w.lock.Unlock()
+
+	return _self.stopOnClose(w.stop), nil
+}
+
+type connectionWatch struct {
+	service  *BlockingBreezServices
+	clock    Clock
+	interval time.Duration
+	fn       func(ConnectionStatusChange)
+	lock     sync.Mutex
+	last     *ConnectionStatusChange
+	timer    Timer
+	stopped  bool
+}
+
+func (w *connectionWatch) check() {
+	status, err := w.service.ConnectionStatus()
+	current := ConnectionStatusChange{At: w.clock.Now(), Status: status, Err: err}
+
+	w.lock.Lock()
+	if w.stopped {
+		w.lock.Unlock()
+		return
+	}
+	previous := w.last
+	changed := previous == nil || (previous.Err == nil) != (err == nil) ||
+		(err == nil && (previous.Status.Connected() != status.Connected() || previous.Status.LspConnected != status.LspConnected))
+	if changed {
+		if previous != nil {
+			previousCopy := *previous
+			previousCopy.Previous = nil
+			current.Previous = &previousCopy
+		}
+		w.last = &current
+	}
+	w.timer = w.clock.AfterFunc(w.interval, w.check)
+	w.lock.Unlock()
+
+	if changed {
+		w.fn(current)
+	}
+}
+
+func (w *connectionWatch) stop() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.stopped = true
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+}
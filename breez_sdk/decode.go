@@ -0,0 +1,49 @@
+package breez_sdk
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// DecodeError is returned by a method of Services when the bindings could not
+// decode what the library returned: a value or error of a variant the bindings
+// do not know, a truncated buffer, or more data than the bindings decoded. Any
+// of these means the bindings and the library do not match. The method returns
+// the zero value of its result with it.
+type DecodeError struct {
+	// Type is the Go type being decoded, if known.
+	Type string
+	// UnknownVariant is the variant or error code of Type that the bindings
+	// do not know, if that is what failed.
+	UnknownVariant *int64
+	// Leftover holds the bytes that were not decoded.
+	Leftover []byte
+	// Err is why reading the buffer failed otherwise.
+	Err error
+}
+
+func (err *DecodeError) Error() string {
+	switch {
+	case err.UnknownVariant != nil:
+		return fmt.Sprintf("unknown variant %d of %s%s", *err.UnknownVariant, err.Type, libraryMismatchHint())
+	case err.Err != nil && err.Type == "":
+		return fmt.Sprintf("decoding: %v%s", err.Err, libraryMismatchHint())
+	case err.Err != nil:
+		return fmt.Sprintf("decoding %s: %v%s", err.Type, err.Err, libraryMismatchHint())
+	default:
+		return fmt.Sprintf("%d bytes remaining in buffer after lifting%s", len(err.Leftover), libraryMismatchHint())
+	}
+}
+
+func (err *DecodeError) Unwrap() error {
+	return err.Err
+}
+
+var strictDecoding atomic.Bool
+
+// SetStrictDecoding makes the methods of Services panic with a *DecodeError
+// instead of returning it. The methods of BlockingBreezServices always panic
+// when decoding fails.
+func SetStrictDecoding(strict bool) {
+	strictDecoding.Store(strict)
+}
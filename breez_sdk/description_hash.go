@@ -0,0 +1,145 @@
+package breez_sdk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DescriptionHash returns the hex encoded SHA-256 of description, which is what
+// an invoice created with UseDescriptionHash commits to instead of the
+// description itself.
+func DescriptionHash(description string) string {
+	hash := sha256.Sum256([]byte(description))
+	return hex.EncodeToString(hash[:])
+}
+
+// DescriptionStore keeps the full descriptions of invoices created with a
+// description hash, so that they can be shown to the payer later.
+type DescriptionStore interface {
+	PutDescription(descriptionHash, description string) error
+	// Description returns the description with the given hash, and false if
+	// it is not stored.
+	Description(descriptionHash string) (string, bool, error)
+}
+
+type memoryDescriptionStore struct {
+	lock         sync.RWMutex
+	descriptions map[string]string
+}
+
+// NewMemoryDescriptionStore returns a DescriptionStore that only lives as long
+// as the process. It is the store of services without SetDescriptionStore.
+func NewMemoryDescriptionStore() DescriptionStore {
+	return &memoryDescriptionStore{descriptions: map[string]string{}}
+}
+
+func (s *memoryDescriptionStore) PutDescription(descriptionHash, description string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.descriptions[descriptionHash] = description
+	return nil
+}
+
+func (s *memoryDescriptionStore) Description(descriptionHash string) (string, bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	description, ok := s.descriptions[descriptionHash]
+	return description, ok, nil
+}
+
+type fileDescriptionStore struct {
+	dir string
+}
+
+// NewFileDescriptionStore returns a DescriptionStore keeping one file per
+// description in dir, which is created if needed.
+func NewFileDescriptionStore(dir string) (DescriptionStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &fileDescriptionStore{dir: dir}, nil
+}
+
+func (s *fileDescriptionStore) path(descriptionHash string) (string, error) {
+	if decoded, err := hex.DecodeString(descriptionHash); err != nil || len(decoded) != sha256.Size {
+		return "", fmt.Errorf("invalid description hash %q", descriptionHash)
+	}
+	return filepath.Join(s.dir, descriptionHash), nil
+}
+
+func (s *fileDescriptionStore) PutDescription(descriptionHash, description string) error {
+	path, err := s.path(descriptionHash)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(description), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s *fileDescriptionStore) Description(descriptionHash string) (string, bool, error) {
+	path, err := s.path(descriptionHash)
+	if err != nil {
+		return "", false, err
+	}
+	description, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return string(description), true, nil
+}
+
+// SetDescriptionStore replaces the store of the descriptions of invoices
+// created by ReceivePaymentWithDescriptionHash.
+func (_self *BlockingBreezServices) SetDescriptionStore(store DescriptionStore) {
+	state := _self.state()
+	state.lock.Lock()
+	state.descriptions = store
+	state.lock.Unlock()
+}
+
+func (_self *BlockingBreezServices) descriptionStore() DescriptionStore {
+	state := _self.state()
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	if state.descriptions == nil {
+		state.descriptions = NewMemoryDescriptionStore()
+	}
+	return state.descriptions
+}
+
+// ReceivePaymentWithDescriptionHash is ReceivePayment with UseDescriptionHash
+// set. The full description is stored before the invoice is created, so it
+// can be retrieved with FullDescription.
+func (_self *BlockingBreezServices) ReceivePaymentWithDescriptionHash(req ReceivePaymentRequest) (ReceivePaymentResponse, error) {
+	descriptionHash := DescriptionHash(req.Description)
+	if err := _self.descriptionStore().PutDescription(descriptionHash, req.Description); err != nil {
+		return ReceivePaymentResponse{}, fmt.Errorf("storing description: %w", err)
+	}
+
+	useDescriptionHash := true
+	req.UseDescriptionHash = &useDescriptionHash
+	res, err := _self.Guarded().ReceivePayment(req)
+	if err != nil {
+		return res, err
+	}
+	if res.LnInvoice.DescriptionHash == nil || *res.LnInvoice.DescriptionHash != descriptionHash {
+		return ReceivePaymentResponse{}, fmt.Errorf("invoice does not commit to the description hash %s", descriptionHash)
+	}
+	return res, nil
+}
+
+// FullDescription returns the description stored for descriptionHash, such as
+// the DescriptionHash of an LnInvoice.
+func (_self *BlockingBreezServices) FullDescription(descriptionHash string) (string, bool, error) {
+	return _self.descriptionStore().Description(descriptionHash)
+}
@@ -0,0 +1,270 @@
+package breez_sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DevCommand is a command of ExecuteDevCommand. The output of the node
+// commands is the node's JSON, which is not a stable interface; the typed
+// wrappers below parse it leniently.
+type DevCommand string
+
+const (
+	DevCommandListPeers              DevCommand = "listpeers"
+	DevCommandListPeerChannels       DevCommand = "listpeerchannels"
+	DevCommandListFunds              DevCommand = "listfunds"
+	DevCommandListPayments           DevCommand = "listpayments"
+	DevCommandListInvoices           DevCommand = "listinvoices"
+	DevCommandGetInfo                DevCommand = "getinfo"
+	DevCommandCloseAllChannels       DevCommand = "closeallchannels"
+	DevCommandStop                   DevCommand = "stop"
+	DevCommandGenerateDiagnosticData DevCommand = "generatediagnosticdata"
+)
+
+// DevCommands are the commands supported by ExecuteDevCommand.
+var DevCommands = []DevCommand{
+	DevCommandListPeers,
+	DevCommandListPeerChannels,
+	DevCommandListFunds,
+	DevCommandListPayments,
+	DevCommandListInvoices,
+	DevCommandGetInfo,
+	DevCommandCloseAllChannels,
+	DevCommandStop,
+	DevCommandGenerateDiagnosticData,
+}
+
+// Destructive reports whether the command changes the node: closing all its
+// channels or stopping it.
+func (c DevCommand) Destructive() bool {
+	return c == DevCommandCloseAllChannels || c == DevCommandStop
+}
+
+// ExecuteDevCommandJson executes command and decodes its output into v.
+func (_self *BlockingBreezServices) ExecuteDevCommandJson(command DevCommand, v interface{}) error {
+	output, err := _self.Guarded().ExecuteDevCommand(string(command))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(output), v); err != nil {
+		return fmt.Errorf("parsing %s: %w", command, err)
+	}
+	return nil
+}
+
+// Peer is a peer of the node.
+type Peer struct {
+	Id        string
+	Connected bool
+	// Addresses are the network addresses the peer is connected at.
+	Addresses []string
+	Features  string
+}
+
+// ListPeers returns the peers of the node, ordered by id.
+func (_self *BlockingBreezServices) ListPeers() ([]Peer, error) {
+	var res struct {
+		Peers []struct {
+			Id        devBytes `json:"id"`
+			Connected bool     `json:"connected"`
+			Netaddr   []string `json:"netaddr"`
+			Features  devBytes `json:"features"`
+		} `json:"peers"`
+	}
+	if err := _self.ExecuteDevCommandJson(DevCommandListPeers, &res); err != nil {
+		return nil, err
+	}
+	peers := make([]Peer, 0, len(res.Peers))
+	for _, p := range res.Peers {
+		peers = append(peers, Peer{
+			Id:        string(p.Id),
+			Connected: p.Connected,
+			Addresses: p.Netaddr,
+			Features:  string(p.Features),
+		})
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].Id < peers[j].Id })
+	return peers, nil
+}
+
+// FundsOutput is an onchain output of the node's wallet.
+type FundsOutput struct {
+	Txid       string
+	Output     uint32
+	AmountMsat uint64
+	Address    string
+	// Status is "unconfirmed", "confirmed" or "spent".
+	Status   string
+	Reserved bool
+}
+
+// Funds are the onchain outputs of the node and the balances of its channels.
+type Funds struct {
+	Outputs  []FundsOutput
+	Channels []Channel
+}
+
+// ListFunds returns the onchain outputs of the node and its channels, as
+// listed by ListChannels.
+func (_self *BlockingBreezServices) ListFunds() (Funds, error) {
+	var res struct {
+		Outputs []struct {
+			Txid       devBytes        `json:"txid"`
+			Output     uint32          `json:"output"`
+			AmountMsat devMsat         `json:"amount_msat"`
+			Address    string          `json:"address"`
+			Status     json.RawMessage `json:"status"`
+			Reserved   bool            `json:"reserved"`
+		} `json:"outputs"`
+	}
+	if err := _self.ExecuteDevCommandJson(DevCommandListFunds, &res); err != nil {
+		return Funds{}, err
+	}
+	funds := Funds{Outputs: make([]FundsOutput, 0, len(res.Outputs))}
+	for _, o := range res.Outputs {
+		funds.Outputs = append(funds.Outputs, FundsOutput{
+			Txid:       string(o.Txid),
+			Output:     o.Output,
+			AmountMsat: uint64(o.AmountMsat),
+			Address:    o.Address,
+			Status:     outputStatus(o.Status),
+			Reserved:   o.Reserved,
+		})
+	}
+	channels, err := _self.ListChannels()
+	if err != nil {
+		return Funds{}, err
+	}
+	funds.Channels = channels
+	return funds, nil
+}
+
+// outputStatus decodes the status of a listfunds output, a name or the index
+// of one.
+func outputStatus(raw json.RawMessage) string {
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		return strings.ToLower(name)
+	}
+	var index int
+	if err := json.Unmarshal(raw, &index); err == nil {
+		switch index {
+		case 0:
+			return "unconfirmed"
+		case 1:
+			return "confirmed"
+		case 2:
+			return "spent"
+		}
+	}
+	return "unknown"
+}
+
+// GetRoutingHints returns the routing hints of the node's usable channels:
+// opened channels with a connected peer, each through the peer's alias of the
+// channel if it has one, at the fees the peer announced. Channels whose peer
+// has not sent its channel update yet are left out. ReceivePayment computes
+// its own hints; these are for tooling building invoices elsewhere.
+func (_self *BlockingBreezServices) GetRoutingHints() ([]RouteHint, error) {
+	var res struct {
+		Channels []struct {
+			peerChannel
+			Alias struct {
+				Remote *string `json:"remote"`
+			} `json:"alias"`
+			Updates struct {
+				Remote *struct {
+					HtlcMinimumMsat          *devMsat `json:"htlc_minimum_msat"`
+					HtlcMaximumMsat          *devMsat `json:"htlc_maximum_msat"`
+					CltvExpiryDelta          uint64   `json:"cltv_expiry_delta"`
+					FeeBaseMsat              devMsat  `json:"fee_base_msat"`
+					FeeProportionalMillionth uint32   `json:"fee_proportional_millionths"`
+				} `json:"remote"`
+			} `json:"updates"`
+		} `json:"channels"`
+	}
+	if err := _self.ExecuteDevCommandJson(DevCommandListPeerChannels, &res); err != nil {
+		return nil, err
+	}
+	var hints []RouteHint
+	for _, c := range res.Channels {
+		state, err := clnChannelState(c.State)
+		if err != nil || state != ChannelStateOpened || !c.PeerConnected {
+			continue
+		}
+		update := c.Updates.Remote
+		shortChannelId := c.ShortChannelId
+		if c.Alias.Remote != nil {
+			shortChannelId = c.Alias.Remote
+		}
+		if update == nil || shortChannelId == nil {
+			continue
+		}
+		hop := RouteHintHop{
+			SrcNodeId:                  string(c.PeerId),
+			ShortChannelId:             *shortChannelId,
+			FeesBaseMsat:               uint32(update.FeeBaseMsat),
+			FeesProportionalMillionths: update.FeeProportionalMillionth,
+			CltvExpiryDelta:            update.CltvExpiryDelta,
+		}
+		if update.HtlcMinimumMsat != nil {
+			msat := uint64(*update.HtlcMinimumMsat)
+			hop.HtlcMinimumMsat = &msat
+		}
+		if update.HtlcMaximumMsat != nil {
+			msat := uint64(*update.HtlcMaximumMsat)
+			hop.HtlcMaximumMsat = &msat
+		}
+		hints = append(hints, RouteHint{Hops: []RouteHintHop{hop}})
+	}
+	sort.Slice(hints, func(i, j int) bool { return hints[i].Hops[0].ShortChannelId < hints[j].Hops[0].ShortChannelId })
+	return hints, nil
+}
+
+// GenerateDiagnostics returns sections of GenerateDiagnosticData, which is a
+// JSON object. A section is a key of the object, or a dotted path such as
+// "node.peers" into the objects it nests. Without sections, all top-level
+// sections are returned. A missing section is an error listing the available
+// ones.
+func (_self *BlockingBreezServices) GenerateDiagnostics(sections ...string) (map[string]json.RawMessage, error) {
+	output, err := _self.Guarded().GenerateDiagnosticData()
+	if err != nil {
+		return nil, err
+	}
+	var root map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(output), &root); err != nil {
+		return nil, fmt.Errorf("parsing diagnostic data: %w", err)
+	}
+	if len(sections) == 0 {
+		return root, nil
+	}
+	selected := make(map[string]json.RawMessage, len(sections))
+	for _, section := range sections {
+		value, ok := diagnosticSection(root, strings.Split(section, "."))
+		if !ok {
+			available := make([]string, 0, len(root))
+			for key := range root {
+				available = append(available, key)
+			}
+			sort.Strings(available)
+			return nil, fmt.Errorf("no diagnostic section %q, have %s", section, strings.Join(available, ", "))
+		}
+		selected[section] = value
+	}
+	return selected, nil
+}
+
+func diagnosticSection(object map[string]json.RawMessage, path []string) (json.RawMessage, bool) {
+	value, ok := object[path[0]]
+	if !ok || len(path) == 1 {
+		return value, ok
+	}
+	var nested map[string]json.RawMessage
+	if err := json.Unmarshal(value, &nested); err != nil {
+		return nil, false
+	}
+	return diagnosticSection(nested, path[1:])
+}
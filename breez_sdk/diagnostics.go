@@ -0,0 +1,272 @@
+package breez_sdk
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// RedactionLevel tells what GenerateDiagnosticReport hides. Amounts are kept
+// at every level.
+type RedactionLevel int
+
+const (
+	// RedactionFull replaces identifiers, such as node and channel ids,
+	// payment hashes, transaction ids, invoices and addresses, by
+	// pseudonyms that are consistent within a report but differ between
+	// reports. It is the default.
+	RedactionFull RedactionLevel = iota
+	// RedactionPartial keeps the first 8 characters of identifiers, enough
+	// for support to match them against the LSP's logs.
+	RedactionPartial
+	// RedactionNone keeps everything.
+	RedactionNone
+)
+
+func (l RedactionLevel) String() string {
+	switch l {
+	case RedactionFull:
+		return "full"
+	case RedactionPartial:
+		return "partial"
+	case RedactionNone:
+		return "none"
+	default:
+		return "unknown"
+	}
+}
+
+func (l RedactionLevel) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// DiagnosticReportOptions configures GenerateDiagnosticReport.
+type DiagnosticReportOptions struct {
+	Redaction RedactionLevel
+	// MaxErrors caps DiagnosticReport.RecentErrors, 20 by default.
+	MaxErrors int
+}
+
+// DiagnosticReport is a summary of the node's state for support tickets.
+type DiagnosticReport struct {
+	GeneratedAt time.Time           `json:"generated_at"`
+	Redaction   RedactionLevel      `json:"redaction"`
+	Node        DiagnosticNode      `json:"node"`
+	Channels    []DiagnosticChannel `json:"channels"`
+	// ChannelsError is why the channels could not be listed, if they could
+	// not.
+	ChannelsError string            `json:"channels_error,omitempty"`
+	RecentErrors  []DiagnosticError `json:"recent_errors"`
+	// Library is the compatibility report of the loaded breez_sdk_bindings
+	// library. Its LoadedFrom is only the file name unless nothing is
+	// redacted.
+	Library LibraryCompatibilityReport `json:"library"`
+}
+
+// DiagnosticNode is the node part of a DiagnosticReport.
+type DiagnosticNode struct {
+	Id                         string     `json:"id"`
+	BlockHeight                uint32     `json:"block_height"`
+	ChannelsBalanceMsat        uint64     `json:"channels_balance_msat"`
+	OnchainBalanceMsat         uint64     `json:"onchain_balance_msat"`
+	PendingOnchainBalanceMsat  uint64     `json:"pending_onchain_balance_msat"`
+	MaxPayableMsat             uint64     `json:"max_payable_msat"`
+	MaxReceivableMsat          uint64     `json:"max_receivable_msat"`
+	TotalInboundLiquidityMsats uint64     `json:"total_inbound_liquidity_msats"`
+	ConnectedPeers             []string   `json:"connected_peers"`
+	LastSynced                 *time.Time `json:"last_synced,omitempty"`
+}
+
+// DiagnosticChannel is a channel of a DiagnosticReport.
+type DiagnosticChannel struct {
+	ChannelId         string `json:"channel_id"`
+	PeerId            string `json:"peer_id"`
+	PeerConnected     bool   `json:"peer_connected"`
+	State             string `json:"state"`
+	CapacityMsat      uint64 `json:"capacity_msat"`
+	LocalBalanceMsat  uint64 `json:"local_balance_msat"`
+	RemoteBalanceMsat uint64 `json:"remote_balance_msat"`
+}
+
+// DiagnosticError is a recent failure of a DiagnosticReport.
+type DiagnosticError struct {
+	At time.Time `json:"at"`
+	// Source is what failed, "payment" for a failed payment.
+	Source  string `json:"source"`
+	Id      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// GenerateDiagnosticReport summarizes the node's state, its channels and its
+// recent failed payments, with identifiers redacted as opts say. Unlike
+// GenerateDiagnosticData, whose output is opaque and unredacted, the report
+// is meant to be attached to support tickets; see WriteJSON.
+func (_self *BlockingBreezServices) GenerateDiagnosticReport(opts DiagnosticReportOptions) (DiagnosticReport, error) {
+	if opts.MaxErrors == 0 {
+		opts.MaxErrors = 20
+	}
+	r, err := newRedactor(opts.Redaction)
+	if err != nil {
+		return DiagnosticReport{}, err
+	}
+
+	node, err := _self.Guarded().NodeInfo()
+	if err != nil {
+		return DiagnosticReport{}, err
+	}
+	report := DiagnosticReport{
+		GeneratedAt: _self.Clock().Now().UTC(),
+		Redaction:   opts.Redaction,
+		Node: DiagnosticNode{
+			Id:                         r.id(node.Id),
+			BlockHeight:                node.BlockHeight,
+			ChannelsBalanceMsat:        node.ChannelsBalanceMsat,
+			OnchainBalanceMsat:         node.OnchainBalanceMsat,
+			PendingOnchainBalanceMsat:  node.PendingOnchainBalanceMsat,
+			MaxPayableMsat:             node.MaxPayableMsat,
+			MaxReceivableMsat:          node.MaxReceivableMsat,
+			TotalInboundLiquidityMsats: node.TotalInboundLiquidityMsats,
+			ConnectedPeers:             []string{},
+		},
+		Channels:     []DiagnosticChannel{},
+		RecentErrors: []DiagnosticError{},
+		Library:      diagnosticLibrary(opts.Redaction),
+	}
+	for _, peer := range node.ConnectedPeers {
+		report.Node.ConnectedPeers = append(report.Node.ConnectedPeers, r.id(peer))
+	}
+	if lastSynced, ok := _self.LastSynced(); ok {
+		lastSynced = lastSynced.UTC()
+		report.Node.LastSynced = &lastSynced
+	}
+
+	channels, err := _self.ListChannels()
+	if err != nil {
+		report.ChannelsError = r.text(err.Error())
+	}
+	for _, c := range channels {
+		report.Channels = append(report.Channels, DiagnosticChannel{
+			ChannelId:         r.id(c.ChannelId),
+			PeerId:            r.id(c.PeerId),
+			PeerConnected:     c.PeerConnected,
+			State:             channelStateName(c.State),
+			CapacityMsat:      c.CapacityMsat,
+			LocalBalanceMsat:  c.LocalBalanceMsat,
+			RemoteBalanceMsat: c.RemoteBalanceMsat,
+		})
+	}
+
+	includeFailures := true
+	limit := uint32(defaultPaymentsPageSize)
+	payments, err := _self.Guarded().ListPayments(ListPaymentsRequest{IncludeFailures: &includeFailures, Limit: &limit})
+	if err != nil {
+		return DiagnosticReport{}, err
+	}
+	for _, payment := range payments {
+		if payment.Status != PaymentStatusFailed || len(report.RecentErrors) == opts.MaxErrors {
+			continue
+		}
+		message := ""
+		if payment.Error != nil {
+			message = *payment.Error
+		}
+		report.RecentErrors = append(report.RecentErrors, DiagnosticError{
+			At:      time.Unix(payment.PaymentTime, 0).UTC(),
+			Source:  "payment",
+			Id:      r.id(payment.Id),
+			Message: r.text(message),
+		})
+	}
+	return report, nil
+}
+
+// diagnosticLibrary returns the library compatibility report, keeping only the
+// file name of the library's path unless level is RedactionNone, since the
+// path may include the user's name.
+func diagnosticLibrary(level RedactionLevel) LibraryCompatibilityReport {
+	report := CheckLibraryCompatibility()
+	if report.LoadedFrom != nil && level != RedactionNone {
+		name := filepath.Base(*report.LoadedFrom)
+		report.LoadedFrom = &name
+	}
+	return report
+}
+
+// WriteJSON writes the report as indented JSON, ready to attach to a support
+// ticket.
+func (r DiagnosticReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+func channelStateName(state ChannelState) string {
+	switch state {
+	case ChannelStatePendingOpen:
+		return "pending_open"
+	case ChannelStateOpened:
+		return "opened"
+	case ChannelStatePendingClose:
+		return "pending_close"
+	case ChannelStateClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// sensitiveText matches the identifiers redacted in free text: hex strings of
+// at least 32 characters, bech32 invoices, offers and addresses, and base58
+// addresses.
+var sensitiveText = regexp.MustCompile(`\b(?:[0-9a-fA-F]{32,}|(?i:ln(?:bc|tb|bcrt|o)[0-9a-z]{20,}|(?:bc|tb|bcrt)1[0-9a-z]{20,})|[13mn2][1-9A-HJ-NP-Za-km-z]{25,34})\b`)
+
+type redactor struct {
+	level RedactionLevel
+	salt  []byte
+}
+
+func newRedactor(level RedactionLevel) (redactor, error) {
+	r := redactor{level: level}
+	if level == RedactionFull {
+		r.salt = make([]byte, 16)
+		if _, err := rand.Read(r.salt); err != nil {
+			return redactor{}, err
+		}
+	}
+	return r, nil
+}
+
+// id redacts an identifier. Full redaction uses a salted hash, as node ids
+// and transaction ids are public and a plain hash could be looked up.
+func (r redactor) id(s string) string {
+	if s == "" {
+		return s
+	}
+	switch r.level {
+	case RedactionNone:
+		return s
+	case RedactionPartial:
+		if len(s) <= 8 {
+			return s
+		}
+		return s[:8] + "…"
+	default:
+		h := sha256.New()
+		h.Write(r.salt)
+		h.Write([]byte(s))
+		return "redacted:" + hex.EncodeToString(h.Sum(nil)[:6])
+	}
+}
+
+// text redacts the identifiers within free text such as error messages.
+func (r redactor) text(s string) string {
+	if r.level == RedactionNone {
+		return s
+	}
+	return sensitiveText.ReplaceAllStringFunc(s, r.id)
+}
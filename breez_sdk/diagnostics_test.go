@@ -0,0 +1,29 @@
+package breez_sdk
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestDiagnosticLibrary(t *testing.T) {
+	full := CheckLibraryCompatibility()
+	if full.LoadedFrom == nil {
+		t.Skipf("loaded library not found: %v", full.Problems)
+	}
+
+	report := diagnosticLibrary(RedactionFull)
+	if report.Platform != runtime.GOOS+"/"+runtime.GOARCH || report.ExpectedVersion != expectedLibraryVersion {
+		t.Errorf("report = %+v", report)
+	}
+	if report.Compatible != full.Compatible || optionalString(report.FoundSha256) != optionalString(full.FoundSha256) {
+		t.Errorf("report = %+v, want the result of CheckLibraryCompatibility %+v", report, full)
+	}
+	if want := filepath.Base(*full.LoadedFrom); *report.LoadedFrom != want {
+		t.Errorf("redacted LoadedFrom = %s, want %s", *report.LoadedFrom, want)
+	}
+
+	if report := diagnosticLibrary(RedactionNone); *report.LoadedFrom != *full.LoadedFrom {
+		t.Errorf("unredacted LoadedFrom = %s, want %s", *report.LoadedFrom, *full.LoadedFrom)
+	}
+}
@@ -0,0 +1,100 @@
+package breez_sdk
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// InvoiceDigest summarizes the invoices paid during one digest window.
+type InvoiceDigest struct {
+	WindowStart   time.Time
+	WindowEnd     time.Time
+	Count         int
+	TotalMsat     uint64
+	PaymentHashes []string
+}
+
+// EnableInvoiceDigest batches InvoicePaid events into digests. The window opens
+// with the first paid invoice and fn is called with the digest once it has
+// elapsed, so fn is only ever called with at least one invoice. Calls to fn never
+// overlap. window must be positive. The returned function stops the digest,
+// flushing the pending one; Close does the same, and every digest is delivered
+// exactly once.
+func (_self *BlockingBreezServices) EnableInvoiceDigest(window time.Duration, fn func(InvoiceDigest)) (stop func(), err error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("invalid invoice digest window %v", window)
+	}
+	digester := &invoiceDigester{
+		clock:  _self.Clock(),
+		window: window,
+		fn:     fn,
+	}
+	unsubscribe := _self.subscribeEvents(func(e BreezEvent) {
+		if paid, ok := e.(BreezEventInvoicePaid); ok {
+			digester.add(paid.Details)
+		}
+	})
+
+	return _self.stopOnClose(func() {
+		unsubscribe()
+		digester.stop()
+	}), nil
+}
+
+type invoiceDigester struct {
+	clock     Clock
+	window    time.Duration
+	fn        func(InvoiceDigest)
+	lock      sync.Mutex
+	flushLock sync.Mutex
+	pending   *InvoiceDigest
+	timer     Timer
+	stopped   bool
+}
+
+func (d *invoiceDigester) add(details InvoicePaidDetails) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.stopped {
+		return
+	}
+
+	if d.pending == nil {
+		d.pending = &InvoiceDigest{WindowStart: d.clock.Now()}
+		d.timer = d.clock.AfterFunc(d.window, d.flush)
+	}
+	d.pending.Count++
+	d.pending.PaymentHashes = append(d.pending.PaymentHashes, details.PaymentHash)
+	if details.Payment != nil {
+		d.pending.TotalMsat += details.Payment.AmountMsat
+	}
+}
+
+func (d *invoiceDigester) flush() {
+	d.flushLock.Lock()
+	defer d.flushLock.Unlock()
+
+	d.lock.Lock()
+	digest := d.pending
+	d.pending = nil
+	d.timer = nil
+	d.lock.Unlock()
+
+	if digest == nil {
+		return
+	}
+	digest.WindowEnd = d.clock.Now()
+	d.fn(*digest)
+}
+
+func (d *invoiceDigester) stop() {
+	d.lock.Lock()
+	d.stopped = true
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.lock.Unlock()
+
+	d.flush()
+}
@@ -0,0 +1,95 @@
+package breez_sdk
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestService returns a service without a node, for the Go-side subsystems
+// driven by its events and clock.
+func newTestService(clock Clock) *BlockingBreezServices {
+	service := &BlockingBreezServices{}
+	service.state().events = newEventHub(nil)
+	service.SetClock(clock)
+	return service
+}
+
+func paidInvoice(paymentHash string, amountMsat uint64) BreezEventInvoicePaid {
+	return BreezEventInvoicePaid{Details: InvoicePaidDetails{
+		PaymentHash: paymentHash,
+		Payment:     &Payment{AmountMsat: amountMsat},
+	}}
+}
+
+func TestInvoiceDigestWindow(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	service := newTestService(clock)
+
+	var digests []InvoiceDigest
+	stop, err := service.EnableInvoiceDigest(time.Minute, func(d InvoiceDigest) {
+		digests = append(digests, d)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	clock.Advance(time.Hour)
+	if len(digests) != 0 {
+		t.Fatalf("digest without invoices: %+v", digests)
+	}
+
+	windowStart := clock.Now()
+	service.state().events.OnEvent(paidInvoice("a", 1_000))
+	clock.Advance(30 * time.Second)
+	service.state().events.OnEvent(paidInvoice("b", 2_000))
+	clock.Advance(29 * time.Second)
+	if len(digests) != 0 {
+		t.Fatalf("digest before the window elapsed: %+v", digests)
+	}
+	clock.Advance(time.Second)
+	if len(digests) != 1 {
+		t.Fatalf("got %d digests, want 1", len(digests))
+	}
+	digest := digests[0]
+	if digest.Count != 2 || digest.TotalMsat != 3_000 || len(digest.PaymentHashes) != 2 {
+		t.Errorf("digest = %+v", digest)
+	}
+	if !digest.WindowStart.Equal(windowStart) || !digest.WindowEnd.Equal(windowStart.Add(time.Minute)) {
+		t.Errorf("window = %v to %v, want %v to %v", digest.WindowStart, digest.WindowEnd, windowStart, windowStart.Add(time.Minute))
+	}
+}
+
+func TestInvoiceDigestStopFlushes(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	service := newTestService(clock)
+
+	var digests []InvoiceDigest
+	stop, err := service.EnableInvoiceDigest(time.Minute, func(d InvoiceDigest) {
+		digests = append(digests, d)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.state().events.OnEvent(paidInvoice("a", 1_000))
+	clock.Advance(10 * time.Second)
+	stop()
+	if len(digests) != 1 || digests[0].Count != 1 {
+		t.Fatalf("digests after stop = %+v", digests)
+	}
+
+	service.state().events.OnEvent(paidInvoice("b", 1_000))
+	clock.Advance(time.Hour)
+	stop()
+	if len(digests) != 1 {
+		t.Fatalf("digest after stop: %+v", digests[1:])
+	}
+}
+
+func TestInvoiceDigestRejectsWindow(t *testing.T) {
+	service := newTestService(NewManualClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)))
+	if _, err := service.EnableInvoiceDigest(0, func(InvoiceDigest) {}); err == nil {
+		t.Error("EnableInvoiceDigest accepted a zero window")
+	}
+}
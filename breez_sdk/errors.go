@@ -0,0 +1,48 @@
+package breez_sdk
+
+import "errors"
+
+// Every method and function of this package returns failures as a plain error.
+// The dynamic type is a pointer to the error enum of the call (*SdkError,
+// *SendPaymentError, ...) which unwraps to the variant, so both
+//
+//	errors.Is(err, ErrSendPaymentErrorRouteNotFound)
+//	errors.As(err, &sendPaymentErr) // var sendPaymentErr *SendPaymentError
+//
+// work through any amount of further wrapping with fmt.Errorf("...: %w", err).
+var (
+	_ interface{ Unwrap() error } = (*ConnectError)(nil)
+	_ interface{ Unwrap() error } = (*LnUrlAuthError)(nil)
+	_ interface{ Unwrap() error } = (*LnUrlPayError)(nil)
+	_ interface{ Unwrap() error } = (*LnUrlWithdrawError)(nil)
+	_ interface{ Unwrap() error } = (*ReceiveOnchainError)(nil)
+	_ interface{ Unwrap() error } = (*ReceivePaymentError)(nil)
+	_ interface{ Unwrap() error } = (*RedeemOnchainError)(nil)
+	_ interface{ Unwrap() error } = (*SdkError)(nil)
+	_ interface{ Unwrap() error } = (*SendOnchainError)(nil)
+	_ interface{ Unwrap() error } = (*SendPaymentError)(nil)
+)
+
+var serviceConnectivityErrors = []error{
+	ErrConnectErrorServiceConnectivity,
+	ErrLnUrlAuthErrorServiceConnectivity,
+	ErrLnUrlPayErrorServiceConnectivity,
+	ErrLnUrlWithdrawErrorServiceConnectivity,
+	ErrReceiveOnchainErrorServiceConnectivity,
+	ErrReceivePaymentErrorServiceConnectivity,
+	ErrRedeemOnchainErrorServiceConnectivity,
+	ErrSdkErrorServiceConnectivity,
+	ErrSendOnchainErrorServiceConnectivity,
+	ErrSendPaymentErrorServiceConnectivity,
+}
+
+// IsServiceConnectivityError reports whether err is the ServiceConnectivity
+// variant of any of the error enums, regardless of which call returned it.
+func IsServiceConnectivityError(err error) bool {
+	for _, target := range serviceConnectivityErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package breez_sdk
+
+import (
+	"sync"
+	"time"
+)
+
+// eventHub is the EventListener handed to the library by ConnectService. It
+// forwards every event to the caller's listener and then to the Go-side
+// subsystems that subscribed to the service.
+type eventHub struct {
+	listener    EventListener
+	lock        sync.RWMutex
+	clock       Clock
+	lastSynced  time.Time
+	nextId      uint64
+	subscribers map[uint64]func(BreezEvent)
+}
+
+// ConnectService connects like Connect and returns the service wrapped in
+// Services. Events are passed to listener and to the Go-side subsystems of the
+// service, such as OnEvent, OnInvoiceSettled and SubscribePaymentUpdates.
+// Those never see an event on a service created by Connect.
+func ConnectService(req ConnectRequest, listener EventListener) (*Services, error) {
+	service, err := connect(req, listener)
+	if err != nil {
+		return nil, err
+	}
+	return service.Guarded(), nil
+}
+
+// connect connects with an event hub in front of listener.
+func connect(req ConnectRequest, listener EventListener) (*BlockingBreezServices, error) {
+	events := newEventHub(listener)
+	service, err := connectWiped(req, events)
+	if err != nil {
+		return nil, err
+	}
+	service.state().events = events
+	return service, nil
+}
+
+func newEventHub(listener EventListener) *eventHub {
+	return &eventHub{
+		listener:    listener,
+		clock:       SystemClock,
+		subscribers: map[uint64]func(BreezEvent){},
+	}
+}
+
+func (h *eventHub) OnEvent(e BreezEvent) {
+	if _, ok := e.(BreezEventSynced); ok {
+		h.lock.Lock()
+		h.lastSynced = h.clock.Now()
+		h.lock.Unlock()
+	}
+
+	if h.listener != nil {
+		h.listener.OnEvent(e)
+	}
+
+	h.lock.RLock()
+	subscribers := make([]func(BreezEvent), 0, len(h.subscribers))
+	for _, fn := range h.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	h.lock.RUnlock()
+
+	for _, fn := range subscribers {
+		fn(e)
+	}
+}
+
+// subscribe registers fn for every subsequent event and returns a function
+// removing it again.
+func (h *eventHub) subscribe(fn func(BreezEvent)) func() {
+	h.lock.Lock()
+	h.nextId++
+	id := h.nextId
+	h.subscribers[id] = fn
+	h.lock.Unlock()
+
+	return func() {
+		h.lock.Lock()
+		delete(h.subscribers, id)
+		h.lock.Unlock()
+	}
+}
+
+func (h *eventHub) setClock(clock Clock) {
+	h.lock.Lock()
+	h.clock = clock
+	h.lock.Unlock()
+}
+
+// LastSynced returns the time of the last Synced event, and false if the node
+// has not synced since ConnectService.
+func (_self *BlockingBreezServices) LastSynced() (time.Time, bool) {
+	events := _self.state().events
+	if events == nil {
+		return time.Time{}, false
+	}
+	events.lock.RLock()
+	defer events.lock.RUnlock()
+	return events.lastSynced, !events.lastSynced.IsZero()
+}
+
+// OnEvent calls fn with every event of the node, after the listener given to
+// ConnectService, until the returned function is called or the service is closed. fn
+// runs on the library's event thread and should return quickly.
+func (_self *BlockingBreezServices) OnEvent(fn func(BreezEvent)) (stop func()) {
+	return _self.stopOnClose(_self.subscribeEvents(fn))
+}
+
+// subscribeEvents registers fn with the service's event hub. Services that were
+// not created by ConnectService have no event stream and fn is never called.
+func (_self *BlockingBreezServices) subscribeEvents(fn func(BreezEvent)) func() {
+	state := _self.state()
+	if state.events == nil {
+		return func() {}
+	}
+	return state.events.subscribe(fn)
+}
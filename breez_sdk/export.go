@@ -0,0 +1,235 @@
+package breez_sdk
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// ExportFormat is the output of ExportPayments.
+type ExportFormat int
+
+const (
+	// ExportFormatCSV writes one row per payment, see ExportPayments.
+	ExportFormatCSV ExportFormat = iota
+	// ExportFormatSummary writes a plain text report of the totals of the
+	// completed payments by type.
+	ExportFormatSummary
+)
+
+// ErrNoRate is returned by FiatRateHistory implementations that have no rate
+// for the requested time.
+var ErrNoRate = fmt.Errorf("no fiat rate")
+
+// FiatRateHistory provides the fiat value of one bitcoin at a past time.
+type FiatRateHistory interface {
+	RateAt(currency string, at time.Time) (float64, error)
+}
+
+// ExportOptions configures ExportPaymentsWithOptions.
+type ExportOptions struct {
+	// FiatCurrency, such as "EUR", adds the fiat values of the payments at
+	// their time, taken from Rates. Payments without rate get empty fiat
+	// cells and are left out of the fiat totals.
+	FiatCurrency string
+	Rates        FiatRateHistory
+	// Location is the time zone of the dates, UTC if nil.
+	Location *time.Location
+}
+
+// exportColumns are the CSV columns without the fiat ones.
+var exportColumns = []string{
+	"date", "id", "type", "status", "amount_sat", "fee_sat", "description",
+	"payment_hash", "label", "ln_address", "error",
+}
+
+// ExportPayments writes the payments matching req to w in format, without
+// fiat values.
+func (_self *BlockingBreezServices) ExportPayments(req ListPaymentsRequest, format ExportFormat, w io.Writer) error {
+	return _self.ExportPaymentsWithOptions(context.Background(), req, format, w, ExportOptions{})
+}
+
+// ExportPaymentsWithOptions writes the payments matching req to w in format.
+//
+// The CSV has a header row and the columns date (RFC 3339), id, type, status,
+// amount_sat, fee_sat, description, payment_hash, label, ln_address and error,
+// followed by fiat_currency, fiat_rate, fiat_amount and fiat_fee when
+// opts.FiatCurrency is set. Amounts are in sat with three decimals, so that
+// msat amounts are exact; fiat amounts are rounded to cents.
+func (_self *BlockingBreezServices) ExportPaymentsWithOptions(ctx context.Context, req ListPaymentsRequest, format ExportFormat, w io.Writer, opts ExportOptions) error {
+	if opts.FiatCurrency != "" && opts.Rates == nil {
+		return errors.New("export: FiatCurrency needs Rates")
+	}
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	switch format {
+	case ExportFormatCSV:
+		return _self.exportCSV(ctx, req, w, opts)
+	case ExportFormatSummary:
+		return _self.exportSummary(ctx, req, w, opts)
+	}
+	return fmt.Errorf("export: unknown format %d", format)
+}
+
+func (_self *BlockingBreezServices) exportCSV(ctx context.Context, req ListPaymentsRequest, w io.Writer, opts ExportOptions) error {
+	writer := csv.NewWriter(w)
+	header := exportColumns
+	if opts.FiatCurrency != "" {
+		header = append(header[:len(header):len(header)], "fiat_currency", "fiat_rate", "fiat_amount", "fiat_fee")
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	err := _self.ForEachPayment(ctx, req, func(payment Payment) error {
+		var hash, label, lnAddress string
+		if details, ok := payment.Details.(PaymentDetailsLn); ok {
+			hash, label = details.Data.PaymentHash, details.Data.Label
+			if details.Data.LnAddress != nil {
+				lnAddress = *details.Data.LnAddress
+			}
+		}
+		row := []string{
+			time.Unix(payment.PaymentTime, 0).In(opts.Location).Format(time.RFC3339),
+			payment.Id,
+			payment.PaymentType.String(),
+			payment.Status.String(),
+			formatSatDecimal(payment.AmountMsat),
+			formatSatDecimal(payment.FeeMsat),
+			stringOrEmpty(payment.Description),
+			hash,
+			label,
+			lnAddress,
+			stringOrEmpty(payment.Error),
+		}
+		if opts.FiatCurrency != "" {
+			rate, ok, err := exportRate(opts, payment)
+			if err != nil {
+				return err
+			}
+			if ok {
+				row = append(row, opts.FiatCurrency, strconv.FormatFloat(rate, 'f', -1, 64),
+					formatFiatDecimal(payment.AmountMsat, rate), formatFiatDecimal(payment.FeeMsat, rate))
+			} else {
+				row = append(row, opts.FiatCurrency, "", "", "")
+			}
+		}
+		return writer.Write(row)
+	})
+	if err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+type exportTotals struct {
+	count       int
+	amountMsat  uint64
+	feeMsat     uint64
+	fiatAmount  float64
+	fiatFee     float64
+	missingFiat int
+}
+
+func (_self *BlockingBreezServices) exportSummary(ctx context.Context, req ListPaymentsRequest, w io.Writer, opts ExportOptions) error {
+	totals := map[PaymentType]*exportTotals{}
+	var first, last int64
+	err := _self.ForEachPayment(ctx, req, func(payment Payment) error {
+		if payment.Status != PaymentStatusComplete {
+			return nil
+		}
+		if first == 0 || payment.PaymentTime < first {
+			first = payment.PaymentTime
+		}
+		if payment.PaymentTime > last {
+			last = payment.PaymentTime
+		}
+		total := totals[payment.PaymentType]
+		if total == nil {
+			total = &exportTotals{}
+			totals[payment.PaymentType] = total
+		}
+		total.count++
+		total.amountMsat += payment.AmountMsat
+		total.feeMsat += payment.FeeMsat
+		if opts.FiatCurrency != "" {
+			rate, ok, err := exportRate(opts, payment)
+			if err != nil {
+				return err
+			}
+			if ok {
+				total.fiatAmount += float64(payment.AmountMsat) / msatPerBtc * rate
+				total.fiatFee += float64(payment.FeeMsat) / msatPerBtc * rate
+			} else {
+				total.missingFiat++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	if first == 0 {
+		fmt.Fprintf(w, "No completed payments\n")
+		return nil
+	}
+	fmt.Fprintf(w, "Payments from %s to %s\n\n",
+		time.Unix(first, 0).In(opts.Location).Format(time.RFC3339),
+		time.Unix(last, 0).In(opts.Location).Format(time.RFC3339))
+	header := "type\tcount\tamount_sat\tfee_sat\t"
+	if opts.FiatCurrency != "" {
+		header += "amount_" + strings.ToLower(opts.FiatCurrency) + "\tfee_" + strings.ToLower(opts.FiatCurrency) + "\twithout_rate\t"
+	}
+	fmt.Fprintln(tw, header)
+	for _, paymentType := range []PaymentType{PaymentTypeReceived, PaymentTypeSent, PaymentTypeClosedChannel} {
+		total := totals[paymentType]
+		if total == nil {
+			continue
+		}
+		line := fmt.Sprintf("%s\t%d\t%s\t%s\t", paymentType, total.count, formatSatDecimal(total.amountMsat), formatSatDecimal(total.feeMsat))
+		if opts.FiatCurrency != "" {
+			line += fmt.Sprintf("%.2f\t%.2f\t%d\t", total.fiatAmount, total.fiatFee, total.missingFiat)
+		}
+		fmt.Fprintln(tw, line)
+	}
+	return tw.Flush()
+}
+
+// exportRate returns the rate at the time of payment, and false if there is
+// none.
+func exportRate(opts ExportOptions, payment Payment) (float64, bool, error) {
+	rate, err := opts.Rates.RateAt(opts.FiatCurrency, time.Unix(payment.PaymentTime, 0))
+	if errors.Is(err, ErrNoRate) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return rate, true, nil
+}
+
+// formatSatDecimal formats msat as sat with three decimals.
+func formatSatDecimal(msat uint64) string {
+	return fmt.Sprintf("%d.%03d", msat/1000, msat%1000)
+}
+
+func formatFiatDecimal(msat uint64, rate float64) string {
+	return strconv.FormatFloat(float64(msat)/msatPerBtc*rate, 'f', 2, 64)
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
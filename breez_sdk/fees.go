@@ -0,0 +1,77 @@
+package breez_sdk
+
+import (
+	"sort"
+	"time"
+)
+
+// ValidUntilTime parses ValidUntil, an RFC 3339 timestamp.
+func (p OpeningFeeParams) ValidUntilTime() (time.Time, error) {
+	return time.Parse(time.RFC3339, p.ValidUntil)
+}
+
+// IsValidAt reports whether the params can still be used at now, typically
+// Clock.Now(). Params with an unparsable ValidUntil are never valid.
+func (p OpeningFeeParams) IsValidAt(now time.Time) bool {
+	validUntil, err := p.ValidUntilTime()
+	if err != nil {
+		return false
+	}
+	return now.Before(validUntil)
+}
+
+// FeeMsat is the opening fee the params charge for a channel opened by a
+// payment of amountMsat: Proportional millionths of the amount, rounded up,
+// and at least MinMsat.
+func (p OpeningFeeParams) FeeMsat(amountMsat uint64) uint64 {
+	fee := (amountMsat*uint64(p.Proportional) + 999_999) / 1_000_000
+	if fee < p.MinMsat {
+		return p.MinMsat
+	}
+	return fee
+}
+
+// LspOffer is the cheapest channel opening an LSP offers for an amount.
+type LspOffer struct {
+	Lsp    LspInformation
+	Params OpeningFeeParams
+	// FeeMsat is the fee of Params for the compared amount.
+	FeeMsat uint64
+}
+
+// CompareLsps fetches the fee menus of all LSPs available to the node and
+// returns, for each LSP with params still valid by the service's Clock, the
+// cheapest opening for a payment of amountMsat, cheapest first. Offers of equal fee are ordered by
+// the longer MaxIdleTime, which keeps the channel open longer.
+func (_self *BlockingBreezServices) CompareLsps(amountMsat uint64) ([]LspOffer, error) {
+	lsps, err := _self.Guarded().ListLsps()
+	if err != nil {
+		return nil, err
+	}
+	now := _self.Clock().Now()
+	var offers []LspOffer
+	for _, lsp := range lsps {
+		var best *LspOffer
+		for _, params := range lsp.OpeningFeeParamsList.Values {
+			if !params.IsValidAt(now) {
+				continue
+			}
+			offer := LspOffer{Lsp: lsp, Params: params, FeeMsat: params.FeeMsat(amountMsat)}
+			if best == nil || lspOfferLess(offer, *best) {
+				best = &offer
+			}
+		}
+		if best != nil {
+			offers = append(offers, *best)
+		}
+	}
+	sort.SliceStable(offers, func(i, j int) bool { return lspOfferLess(offers[i], offers[j]) })
+	return offers, nil
+}
+
+func lspOfferLess(a, b LspOffer) bool {
+	if a.FeeMsat != b.FeeMsat {
+		return a.FeeMsat < b.FeeMsat
+	}
+	return a.Params.MaxIdleTime > b.Params.MaxIdleTime
+}
@@ -0,0 +1,154 @@
+package breez_sdk
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// numberFormat is how a locale writes numbers.
+type numberFormat struct {
+	decimal  string
+	grouping string
+}
+
+// numberFormats holds the locales, by language, that do not write numbers
+// like English.
+var numberFormats = map[string]numberFormat{
+	"de":    {decimal: ",", grouping: "."},
+	"es":    {decimal: ",", grouping: "."},
+	"it":    {decimal: ",", grouping: "."},
+	"nl":    {decimal: ",", grouping: "."},
+	"pt":    {decimal: ",", grouping: "."},
+	"id":    {decimal: ",", grouping: "."},
+	"tr":    {decimal: ",", grouping: "."},
+	"da":    {decimal: ",", grouping: "."},
+	"fr":    {decimal: ",", grouping: " "},
+	"ru":    {decimal: ",", grouping: " "},
+	"uk":    {decimal: ",", grouping: " "},
+	"pl":    {decimal: ",", grouping: " "},
+	"cs":    {decimal: ",", grouping: " "},
+	"sv":    {decimal: ",", grouping: " "},
+	"fi":    {decimal: ",", grouping: " "},
+	"nb":    {decimal: ",", grouping: " "},
+	"de-CH": {decimal: ".", grouping: "’"},
+}
+
+var englishNumberFormat = numberFormat{decimal: ".", grouping: ","}
+
+// language returns the language of a locale such as "de_DE" or "de-DE".
+func language(locale string) string {
+	language, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	return strings.ToLower(language)
+}
+
+func numberFormatOf(locale string) numberFormat {
+	if format, ok := numberFormats[strings.ReplaceAll(locale, "_", "-")]; ok {
+		return format
+	}
+	if format, ok := numberFormats[language(locale)]; ok {
+		return format
+	}
+	return englishNumberFormat
+}
+
+// formatNumber writes the integer digits and fraction digits with the
+// separators of format.
+func (format numberFormat) formatNumber(integer string, fraction string) string {
+	var b strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(format.grouping)
+		}
+		b.WriteRune(digit)
+	}
+	if fraction != "" {
+		b.WriteString(format.decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// FormatFiat formats amount in the currency described by info for locale, such
+// as "en-US" or "de_DE": rounded to the currency's fraction size, with the
+// locale's separators and the currency symbol placed by its template,
+// position and spacing, taking the locale overrides of info into account.
+func FormatFiat(amount float64, info CurrencyInfo, locale string) string {
+	spacing := info.Spacing
+	symbol := info.Symbol
+	for _, override := range info.LocaleOverrides {
+		if strings.EqualFold(override.Locale, locale) || strings.EqualFold(override.Locale, language(locale)) {
+			if override.Spacing != nil {
+				spacing = override.Spacing
+			}
+			overrideSymbol := override.Symbol
+			symbol = &overrideSymbol
+			break
+		}
+	}
+
+	digits := strconv.FormatFloat(math.Abs(amount), 'f', int(info.FractionSize), 64)
+	integer, fraction, _ := strings.Cut(digits, ".")
+	formatted := formatFiatSymbol(numberFormatOf(locale).formatNumber(integer, fraction), symbol, spacing)
+	// The sign goes before a leading symbol, and an amount rounding to zero
+	// has none.
+	if amount < 0 && strings.Trim(digits, "0.") != "" {
+		return "-" + formatted
+	}
+	return formatted
+}
+
+// formatFiatSymbol places the currency symbol around the unsigned number.
+func formatFiatSymbol(number string, symbol *Symbol, spacing *uint32) string {
+	if symbol == nil {
+		return number
+	}
+	if symbol.Template != nil && strings.Contains(*symbol.Template, "1") {
+		return strings.Replace(*symbol.Template, "1", number, 1)
+	}
+	if symbol.Grapheme == nil {
+		return number
+	}
+	space := ""
+	if spacing != nil {
+		space = strings.Repeat(" ", int(*spacing))
+	}
+	if symbol.Position != nil && *symbol.Position > 0 {
+		return number + space + *symbol.Grapheme
+	}
+	return *symbol.Grapheme + space + number
+}
+
+// Unit is a denomination of bitcoin amounts for FormatMsat.
+type Unit int
+
+const (
+	UnitMsat Unit = iota
+	UnitSat
+	UnitBtc
+)
+
+// FormatMsat formats msat in unit with English separators, such as
+// "1,234 sat" or "0.00001234 BTC". Fractions of a sat are only shown when
+// present.
+func FormatMsat(msat uint64, unit Unit) string {
+	format := englishNumberFormat
+	switch unit {
+	case UnitSat:
+		fraction := ""
+		if msat%msatPerSat != 0 {
+			fraction = strings.TrimRight(strconv.FormatUint(msatPerSat+msat%msatPerSat, 10)[1:], "0")
+		}
+		return format.formatNumber(strconv.FormatUint(msat/msatPerSat, 10), fraction) + " sat"
+	case UnitBtc:
+		fraction := strconv.FormatUint(msatPerBtc+msat%msatPerBtc, 10)[1:]
+		if strings.HasSuffix(fraction, "000") {
+			fraction = fraction[:8]
+		} else {
+			fraction = strings.TrimRight(fraction, "0")
+		}
+		return format.formatNumber(strconv.FormatUint(msat/msatPerBtc, 10), fraction) + " BTC"
+	default:
+		return format.formatNumber(strconv.FormatUint(msat, 10), "") + " msat"
+	}
+}
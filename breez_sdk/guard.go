@@ -0,0 +1,112 @@
+package breez_sdk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Services wraps BlockingBreezServices so that its methods return failures of
+// the bindings as errors instead of panicking: a *DecodeError when what the
+// library returned could not be decoded, a *RustPanicError when the library
+// panicked, and ErrServiceClosed once Close or Shutdown has started. The methods added on the Go side are
+// reached through the embedded service, and make their own calls into the
+// library through Services too.
+//
+// The wrapping lives outside the generated bindings so that regenerating them
+// keeps it.
+type Services struct {
+	*BlockingBreezServices
+}
+
+// ErrServiceClosed is returned by the methods of Services once Close or
+// Shutdown has started.
+var ErrServiceClosed = fmt.Errorf("service closed")
+
+// Guarded returns the service wrapped in Services.
+func (_self *BlockingBreezServices) Guarded() *Services {
+	return &Services{_self}
+}
+
+// call runs fn unless the service is closed, turning the panics of the
+// bindings into errors.
+func (s *Services) call(fn func() error) (err error) {
+	if s.closed() {
+		return ErrServiceClosed
+	}
+	defer recoverCall(&err)
+	return fn()
+}
+
+// guard is call for methods returning a value.
+func guard[T any](s *Services, fn func() (T, error)) (result T, err error) {
+	err = s.call(func() error {
+		result, err = fn()
+		return err
+	})
+	return result, err
+}
+
+// recoverCall recovers a panic of the bindings into *err. Panics it does not
+// recognize are propagated.
+func recoverCall(err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	recovered := recoveredError(r)
+	if recovered == nil {
+		panic(r)
+	}
+	var decodeErr *DecodeError
+	if errors.As(recovered, &decodeErr) && strictDecoding.Load() {
+		panic(decodeErr)
+	}
+	*err = recovered
+}
+
+// unknownVariantPanic matches the panics of the generated readers for a
+// variant or error code they do not know.
+var unknownVariantPanic = regexp.MustCompile(`^(?:invalid enum value|Unknown error code) (-?\d+) in FfiConverter(?:Type)?(\w+)\.read\(\)$`)
+
+const junkPanicPrefix = "Junk remaining in buffer after lifting: "
+
+// bindingPanics are the other panics of the bindings that are not decode
+// failures nor panics of the library.
+var bindingPanics = regexp.MustCompile(`object call counter would overflow$|^no callback in handle map|^bad write length|^reading (reader|written data):`)
+
+// recoveredError returns the error a panic of the bindings stands for, or nil
+// if r is not one.
+func recoveredError(r interface{}) error {
+	switch r := r.(type) {
+	case *DecodeError:
+		return r
+	case string:
+		if m := unknownVariantPanic.FindStringSubmatch(r); m != nil {
+			variant, _ := strconv.ParseInt(m[1], 10, 64)
+			return &DecodeError{Type: m[2], UnknownVariant: &variant}
+		}
+	case error:
+		message := r.Error()
+		switch {
+		case strings.HasSuffix(message, "object has already been destroyed"):
+			return ErrServiceClosed
+		case errors.Is(r, io.EOF) || errors.Is(r, io.ErrUnexpectedEOF):
+			return &DecodeError{Err: io.ErrUnexpectedEOF}
+		case strings.HasPrefix(message, "bad read length"):
+			return &DecodeError{Type: "string", Err: r}
+		case strings.HasPrefix(message, junkPanicPrefix):
+			return &DecodeError{Leftover: []byte(strings.TrimPrefix(message, junkPanicPrefix))}
+		}
+		// The bindings panic with the message of a panic of the library as
+		// the error.
+		if _, ok := r.(runtime.Error); !ok && !bindingPanics.MatchString(message) {
+			return newRustPanicError(message)
+		}
+	}
+	return nil
+}
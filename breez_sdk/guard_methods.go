@@ -0,0 +1,207 @@
+package breez_sdk
+
+var _ BlockingBreezServicesInterface = (*Services)(nil)
+
+func (s *Services) Disconnect() error {
+	return s.call(s.BlockingBreezServices.Disconnect)
+}
+
+func (s *Services) ConfigureNode(req ConfigureNodeRequest) error {
+	return s.call(func() error { return s.BlockingBreezServices.ConfigureNode(req) })
+}
+
+func (s *Services) SendPayment(req SendPaymentRequest) (SendPaymentResponse, error) {
+	return guard(s, func() (SendPaymentResponse, error) { return s.BlockingBreezServices.SendPayment(req) })
+}
+
+func (s *Services) SendSpontaneousPayment(req SendSpontaneousPaymentRequest) (SendPaymentResponse, error) {
+	return guard(s, func() (SendPaymentResponse, error) { return s.BlockingBreezServices.SendSpontaneousPayment(req) })
+}
+
+func (s *Services) ReceivePayment(req ReceivePaymentRequest) (ReceivePaymentResponse, error) {
+	return guard(s, func() (ReceivePaymentResponse, error) { return s.BlockingBreezServices.ReceivePayment(req) })
+}
+
+func (s *Services) PayLnurl(req LnUrlPayRequest) (LnUrlPayResult, error) {
+	return guard(s, func() (LnUrlPayResult, error) { return s.BlockingBreezServices.PayLnurl(req) })
+}
+
+func (s *Services) WithdrawLnurl(request LnUrlWithdrawRequest) (LnUrlWithdrawResult, error) {
+	return guard(s, func() (LnUrlWithdrawResult, error) { return s.BlockingBreezServices.WithdrawLnurl(request) })
+}
+
+func (s *Services) LnurlAuth(reqData LnUrlAuthRequestData) (LnUrlCallbackStatus, error) {
+	return guard(s, func() (LnUrlCallbackStatus, error) { return s.BlockingBreezServices.LnurlAuth(reqData) })
+}
+
+func (s *Services) ReportIssue(req ReportIssueRequest) error {
+	return s.call(func() error { return s.BlockingBreezServices.ReportIssue(req) })
+}
+
+func (s *Services) NodeCredentials() (*NodeCredentials, error) {
+	return guard(s, func() (*NodeCredentials, error) { return s.BlockingBreezServices.NodeCredentials() })
+}
+
+func (s *Services) NodeInfo() (NodeState, error) {
+	return guard(s, func() (NodeState, error) { return s.BlockingBreezServices.NodeInfo() })
+}
+
+func (s *Services) SignMessage(req SignMessageRequest) (SignMessageResponse, error) {
+	return guard(s, func() (SignMessageResponse, error) { return s.BlockingBreezServices.SignMessage(req) })
+}
+
+func (s *Services) CheckMessage(req CheckMessageRequest) (CheckMessageResponse, error) {
+	return guard(s, func() (CheckMessageResponse, error) { return s.BlockingBreezServices.CheckMessage(req) })
+}
+
+func (s *Services) BackupStatus() (BackupStatus, error) {
+	return guard(s, func() (BackupStatus, error) { return s.BlockingBreezServices.BackupStatus() })
+}
+
+func (s *Services) Backup() error {
+	return s.call(s.BlockingBreezServices.Backup)
+}
+
+func (s *Services) ListPayments(req ListPaymentsRequest) ([]Payment, error) {
+	return guard(s, func() ([]Payment, error) { return s.BlockingBreezServices.ListPayments(req) })
+}
+
+func (s *Services) PaymentByHash(hash string) (*Payment, error) {
+	return guard(s, func() (*Payment, error) { return s.BlockingBreezServices.PaymentByHash(hash) })
+}
+
+func (s *Services) SetPaymentMetadata(hash string, metadata string) error {
+	return s.call(func() error { return s.BlockingBreezServices.SetPaymentMetadata(hash, metadata) })
+}
+
+func (s *Services) RedeemOnchainFunds(req RedeemOnchainFundsRequest) (RedeemOnchainFundsResponse, error) {
+	return guard(s, func() (RedeemOnchainFundsResponse, error) { return s.BlockingBreezServices.RedeemOnchainFunds(req) })
+}
+
+func (s *Services) FetchFiatRates() ([]Rate, error) {
+	return guard(s, func() ([]Rate, error) { return s.BlockingBreezServices.FetchFiatRates() })
+}
+
+func (s *Services) ListFiatCurrencies() ([]FiatCurrency, error) {
+	return guard(s, func() ([]FiatCurrency, error) { return s.BlockingBreezServices.ListFiatCurrencies() })
+}
+
+func (s *Services) ListLsps() ([]LspInformation, error) {
+	return guard(s, func() ([]LspInformation, error) { return s.BlockingBreezServices.ListLsps() })
+}
+
+func (s *Services) ConnectLsp(lspId string) error {
+	return s.call(func() error { return s.BlockingBreezServices.ConnectLsp(lspId) })
+}
+
+func (s *Services) FetchLspInfo(lspId string) (*LspInformation, error) {
+	return guard(s, func() (*LspInformation, error) { return s.BlockingBreezServices.FetchLspInfo(lspId) })
+}
+
+func (s *Services) OpenChannelFee(req OpenChannelFeeRequest) (OpenChannelFeeResponse, error) {
+	return guard(s, func() (OpenChannelFeeResponse, error) { return s.BlockingBreezServices.OpenChannelFee(req) })
+}
+
+func (s *Services) LspId() (*string, error) {
+	return guard(s, func() (*string, error) { return s.BlockingBreezServices.LspId() })
+}
+
+func (s *Services) LspInfo() (LspInformation, error) {
+	return guard(s, func() (LspInformation, error) { return s.BlockingBreezServices.LspInfo() })
+}
+
+func (s *Services) CloseLspChannels() error {
+	return s.call(s.BlockingBreezServices.CloseLspChannels)
+}
+
+func (s *Services) RegisterWebhook(webhookUrl string) error {
+	return s.call(func() error { return s.BlockingBreezServices.RegisterWebhook(webhookUrl) })
+}
+
+func (s *Services) UnregisterWebhook(webhookUrl string) error {
+	return s.call(func() error { return s.BlockingBreezServices.UnregisterWebhook(webhookUrl) })
+}
+
+func (s *Services) ReceiveOnchain(req ReceiveOnchainRequest) (SwapInfo, error) {
+	return guard(s, func() (SwapInfo, error) { return s.BlockingBreezServices.ReceiveOnchain(req) })
+}
+
+func (s *Services) InProgressSwap() (*SwapInfo, error) {
+	return guard(s, func() (*SwapInfo, error) { return s.BlockingBreezServices.InProgressSwap() })
+}
+
+func (s *Services) RescanSwaps() error {
+	return s.call(s.BlockingBreezServices.RescanSwaps)
+}
+
+func (s *Services) RedeemSwap(swapAddress string) error {
+	return s.call(func() error { return s.BlockingBreezServices.RedeemSwap(swapAddress) })
+}
+
+func (s *Services) ListRefundables() ([]SwapInfo, error) {
+	return guard(s, func() ([]SwapInfo, error) { return s.BlockingBreezServices.ListRefundables() })
+}
+
+func (s *Services) PrepareRefund(req PrepareRefundRequest) (PrepareRefundResponse, error) {
+	return guard(s, func() (PrepareRefundResponse, error) { return s.BlockingBreezServices.PrepareRefund(req) })
+}
+
+func (s *Services) Refund(req RefundRequest) (RefundResponse, error) {
+	return guard(s, func() (RefundResponse, error) { return s.BlockingBreezServices.Refund(req) })
+}
+
+func (s *Services) ListSwaps(req ListSwapsRequest) ([]SwapInfo, error) {
+	return guard(s, func() ([]SwapInfo, error) { return s.BlockingBreezServices.ListSwaps(req) })
+}
+
+func (s *Services) FetchReverseSwapFees(req ReverseSwapFeesRequest) (ReverseSwapPairInfo, error) {
+	return guard(s, func() (ReverseSwapPairInfo, error) { return s.BlockingBreezServices.FetchReverseSwapFees(req) })
+}
+
+func (s *Services) OnchainPaymentLimits() (OnchainPaymentLimitsResponse, error) {
+	return guard(s, func() (OnchainPaymentLimitsResponse, error) { return s.BlockingBreezServices.OnchainPaymentLimits() })
+}
+
+func (s *Services) PrepareOnchainPayment(req PrepareOnchainPaymentRequest) (PrepareOnchainPaymentResponse, error) {
+	return guard(s, func() (PrepareOnchainPaymentResponse, error) {
+		return s.BlockingBreezServices.PrepareOnchainPayment(req)
+	})
+}
+
+func (s *Services) InProgressOnchainPayments() ([]ReverseSwapInfo, error) {
+	return guard(s, func() ([]ReverseSwapInfo, error) { return s.BlockingBreezServices.InProgressOnchainPayments() })
+}
+
+func (s *Services) ClaimReverseSwap(lockupAddress string) error {
+	return s.call(func() error { return s.BlockingBreezServices.ClaimReverseSwap(lockupAddress) })
+}
+
+func (s *Services) PayOnchain(req PayOnchainRequest) (PayOnchainResponse, error) {
+	return guard(s, func() (PayOnchainResponse, error) { return s.BlockingBreezServices.PayOnchain(req) })
+}
+
+func (s *Services) ExecuteDevCommand(command string) (string, error) {
+	return guard(s, func() (string, error) { return s.BlockingBreezServices.ExecuteDevCommand(command) })
+}
+
+func (s *Services) GenerateDiagnosticData() (string, error) {
+	return guard(s, func() (string, error) { return s.BlockingBreezServices.GenerateDiagnosticData() })
+}
+
+func (s *Services) Sync() error {
+	return s.call(s.BlockingBreezServices.Sync)
+}
+
+func (s *Services) RecommendedFees() (RecommendedFees, error) {
+	return guard(s, func() (RecommendedFees, error) { return s.BlockingBreezServices.RecommendedFees() })
+}
+
+func (s *Services) BuyBitcoin(req BuyBitcoinRequest) (BuyBitcoinResponse, error) {
+	return guard(s, func() (BuyBitcoinResponse, error) { return s.BlockingBreezServices.BuyBitcoin(req) })
+}
+
+func (s *Services) PrepareRedeemOnchainFunds(req PrepareRedeemOnchainFundsRequest) (PrepareRedeemOnchainFundsResponse, error) {
+	return guard(s, func() (PrepareRedeemOnchainFundsResponse, error) {
+		return s.BlockingBreezServices.PrepareRedeemOnchainFunds(req)
+	})
+}
@@ -0,0 +1,76 @@
+package breez_sdk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestGuardRecoversDecodePanics(t *testing.T) {
+	services := newTestService(SystemClock).Guarded()
+
+	_, err := guard(services, func() (Payment, error) {
+		panic(fmt.Sprintf("invalid enum value %v in FfiConverterTypePaymentDetails.read()", 7))
+	})
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.Type != "PaymentDetails" || decodeErr.UnknownVariant == nil || *decodeErr.UnknownVariant != 7 {
+		t.Errorf("unknown variant: err = %#v", err)
+	}
+
+	err = services.call(func() error {
+		panic(fmt.Errorf("Junk remaining in buffer after lifting: %s", "xyz"))
+	})
+	if !errors.As(err, &decodeErr) || string(decodeErr.Leftover) != "xyz" {
+		t.Errorf("leftover: err = %#v", err)
+	}
+
+	err = services.call(func() error {
+		panic(io.ErrUnexpectedEOF)
+	})
+	if !errors.As(err, &decodeErr) || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("truncated buffer: err = %#v", err)
+	}
+}
+
+func TestGuardStrictDecoding(t *testing.T) {
+	SetStrictDecoding(true)
+	defer SetStrictDecoding(false)
+
+	defer func() {
+		if _, ok := recover().(*DecodeError); !ok {
+			t.Error("strict decoding did not panic with a *DecodeError")
+		}
+	}()
+	newTestService(SystemClock).Guarded().call(func() error {
+		panic(fmt.Sprintf("Unknown error code %d in FfiConverterTypeSdkError.read()", 9))
+	})
+}
+
+func TestGuardPropagatesOtherPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("recovered %v, want boom", r)
+		}
+	}()
+	newTestService(SystemClock).Guarded().call(func() error {
+		panic("boom")
+	})
+}
+
+func TestGuardReturnsRustPanics(t *testing.T) {
+	var hooked *RustPanicError
+	SetRustPanicHook(func(err *RustPanicError) { hooked = err })
+	defer SetRustPanicHook(nil)
+
+	err := newTestService(SystemClock).Guarded().call(func() error {
+		panic(fmt.Errorf("%s", "called `Option::unwrap()` on a `None` value"))
+	})
+	var panicErr *RustPanicError
+	if !errors.As(err, &panicErr) || panicErr.Message != "called `Option::unwrap()` on a `None` value" {
+		t.Fatalf("err = %#v", err)
+	}
+	if hooked != panicErr {
+		t.Errorf("hook got %v, want %v", hooked, panicErr)
+	}
+}
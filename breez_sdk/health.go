@@ -0,0 +1,124 @@
+package breez_sdk
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+func (s HealthCheckStatus) String() string {
+	switch s {
+	case HealthCheckStatusOperational:
+		return "operational"
+	case HealthCheckStatusMaintenance:
+		return "maintenance"
+	case HealthCheckStatusServiceDisruption:
+		return "service_disruption"
+	default:
+		return "unknown"
+	}
+}
+
+// ReportPaymentFailure reports a failed payment to Breez, see ReportIssue.
+func (_self *BlockingBreezServices) ReportPaymentFailure(paymentHash string, comment *string) error {
+	return _self.Guarded().ReportIssue(ReportIssueRequestPaymentFailure{
+		Data: ReportPaymentFailureDetails{PaymentHash: paymentHash, Comment: comment},
+	})
+}
+
+// HealthStatusChange is reported by a HealthMonitor when the result of the
+// health check changes. Err is set when the check itself failed, in which case
+// Status is not meaningful.
+type HealthStatusChange struct {
+	At       time.Time
+	Status   HealthCheckStatus
+	Err      error
+	Previous *HealthStatusChange
+}
+
+// HealthMonitor runs ServiceHealthCheck periodically.
+type HealthMonitor struct {
+	check    func() (ServiceHealthCheckResponse, error)
+	interval time.Duration
+	clock    Clock
+	fn       func(HealthStatusChange)
+	lock     sync.Mutex
+	last     *HealthStatusChange
+	timer    Timer
+	stopped  bool
+}
+
+// StartHealthMonitor checks the health of the Breez services right away and
+// then every interval, calling fn with the first result and whenever it
+// changes. interval must be positive. Pass SystemClock unless testing.
+func StartHealthMonitor(clock Clock, apiKey string, interval time.Duration, fn func(HealthStatusChange)) (*HealthMonitor, error) {
+	return startHealthMonitor(clock, func() (_ ServiceHealthCheckResponse, err error) {
+		defer recoverCall(&err)
+		return ServiceHealthCheck(apiKey)
+	}, interval, fn)
+}
+
+func startHealthMonitor(clock Clock, check func() (ServiceHealthCheckResponse, error), interval time.Duration, fn func(HealthStatusChange)) (*HealthMonitor, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid health check interval %v", interval)
+	}
+	m := &HealthMonitor{
+		check:    check,
+		interval: interval,
+		clock:    clock,
+		fn:       fn,
+	}
+	m.lock.Lock()
+	m.timer = clock.AfterFunc(0, m.run)
+	m.lock.Unlock()
+	return m, nil
+}
+
+// Last returns the last result of the health check, and false before the first
+// check completed.
+func (m *HealthMonitor) Last() (HealthStatusChange, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.last == nil {
+		return HealthStatusChange{}, false
+	}
+	return *m.last, true
+}
+
+// Stop stops the checks. A check that is running completes without calling
+// fn.
+func (m *HealthMonitor) Stop() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.stopped = true
+	if m.timer != nil {
+		m.timer.Stop()
+	}
+}
+
+func (m *HealthMonitor) run() {
+	res, err := m.check()
+	current := HealthStatusChange{At: m.clock.Now(), Status: res.Status, Err: err}
+
+	m.lock.Lock()
+	if m.stopped {
+		m.lock.Unlock()
+		return
+	}
+	previous := m.last
+	changed := previous == nil || (previous.Err == nil) != (err == nil) || (err == nil && previous.Status != res.Status)
+	if changed {
+		if previous != nil {
+			previousCopy := *previous
+			previousCopy.Previous = nil
+			current.Previous = &previousCopy
+		}
+		m.last = &current
+	}
+	m.timer = m.clock.AfterFunc(m.interval, m.run)
+	m.lock.Unlock()
+
+	if changed {
+		m.fn(current)
+	}
+}
@@ -0,0 +1,69 @@
+package breez_sdk
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHealthMonitorReportsChanges(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	status := HealthCheckStatusOperational
+	var checkErr error
+	checks := 0
+	check := func() (ServiceHealthCheckResponse, error) {
+		checks++
+		return ServiceHealthCheckResponse{Status: status}, checkErr
+	}
+
+	var changes []HealthStatusChange
+	m, err := startHealthMonitor(clock, check, time.Minute, func(c HealthStatusChange) {
+		changes = append(changes, c)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(0)
+	if len(changes) != 1 || changes[0].Status != HealthCheckStatusOperational || changes[0].Previous != nil {
+		t.Fatalf("first check = %+v", changes)
+	}
+	clock.Advance(time.Minute)
+	if checks != 2 || len(changes) != 1 {
+		t.Fatalf("%d checks, %d changes, want 2 and 1", checks, len(changes))
+	}
+
+	status = HealthCheckStatusMaintenance
+	clock.Advance(time.Minute)
+	if len(changes) != 2 || changes[1].Status != HealthCheckStatusMaintenance {
+		t.Fatalf("changes = %+v", changes)
+	}
+	if changes[1].Previous == nil || changes[1].Previous.Status != HealthCheckStatusOperational {
+		t.Errorf("previous = %+v", changes[1].Previous)
+	}
+
+	checkErr = errors.New("unreachable")
+	clock.Advance(time.Minute)
+	if len(changes) != 3 || changes[2].Err == nil {
+		t.Fatalf("changes = %+v", changes)
+	}
+	if last, ok := m.Last(); !ok || last.Err == nil {
+		t.Errorf("last = %+v", last)
+	}
+
+	m.Stop()
+	checkErr = nil
+	clock.Advance(time.Hour)
+	if checks != 4 || len(changes) != 3 {
+		t.Errorf("%d checks, %d changes after stop, want 4 and 3", checks, len(changes))
+	}
+}
+
+func TestHealthMonitorRejectsInterval(t *testing.T) {
+	clock := NewManualClock(time.Time{})
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if _, err := StartHealthMonitor(clock, "", interval, func(HealthStatusChange) {}); err == nil {
+			t.Errorf("interval %v accepted", interval)
+		}
+	}
+}
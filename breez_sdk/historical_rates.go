@@ -0,0 +1,143 @@
+package breez_sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMempoolspaceUrl is the mempool.space API HistoricalRates uses unless
+// configured otherwise.
+const DefaultMempoolspaceUrl = "https://mempool.space/api"
+
+// HistoricalRates fetches past fiat rates from the historical price API of a
+// mempool.space instance, which the library's FetchFiatRates has no
+// counterpart for. Prices are hourly; rates of the currencies mempool.space
+// has no price series for are derived from the USD price and its exchange
+// rates. Responses are cached per hour, so HistoricalRates is cheap to use as
+// the FiatRateHistory of an export. Its zero value uses
+// DefaultMempoolspaceUrl and HttpClient.
+type HistoricalRates struct {
+	// MempoolspaceUrl is the API root, such as Config.MempoolspaceUrl.
+	MempoolspaceUrl string
+	Client          *http.Client
+
+	lock  sync.Mutex
+	cache map[int64][]Rate
+}
+
+var _ FiatRateHistory = (*HistoricalRates)(nil)
+
+var defaultHistoricalRates = &HistoricalRates{}
+
+// FetchFiatRatesAt returns the fiat rates at timestamp, in Unix seconds, from
+// mempool.space. See HistoricalRates to use another instance.
+func FetchFiatRatesAt(ctx context.Context, timestamp int64) ([]Rate, error) {
+	return defaultHistoricalRates.FetchAt(ctx, timestamp)
+}
+
+// FetchAt returns the rates at timestamp, in Unix seconds, sorted by coin.
+func (h *HistoricalRates) FetchAt(ctx context.Context, timestamp int64) ([]Rate, error) {
+	hour := timestamp - timestamp%3600
+	h.lock.Lock()
+	rates, ok := h.cache[hour]
+	h.lock.Unlock()
+	if ok {
+		return rates, nil
+	}
+
+	rates, err := h.fetch(ctx, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	h.lock.Lock()
+	if h.cache == nil {
+		h.cache = map[int64][]Rate{}
+	}
+	h.cache[hour] = rates
+	h.lock.Unlock()
+	return rates, nil
+}
+
+// RateAt returns the rate of currency at the time at, or ErrNoRate.
+func (h *HistoricalRates) RateAt(currency string, at time.Time) (float64, error) {
+	rates, err := h.FetchAt(context.Background(), at.Unix())
+	if err != nil {
+		return 0, err
+	}
+	for _, rate := range rates {
+		if strings.EqualFold(rate.Coin, currency) {
+			return rate.Value, nil
+		}
+	}
+	return 0, fmt.Errorf("%w for %s at %s", ErrNoRate, currency, at.UTC().Format(time.RFC3339))
+}
+
+// historicalPriceResponse is the body of GET /v1/historical-price.
+type historicalPriceResponse struct {
+	Prices        []map[string]float64 `json:"prices"`
+	ExchangeRates map[string]float64   `json:"exchangeRates"`
+}
+
+func (h *HistoricalRates) fetch(ctx context.Context, timestamp int64) ([]Rate, error) {
+	root := h.MempoolspaceUrl
+	if root == "" {
+		root = DefaultMempoolspaceUrl
+	}
+	client := h.Client
+	if client == nil {
+		client = HttpClient
+	}
+	endpoint := strings.TrimSuffix(root, "/") + "/v1/historical-price?" + url.Values{
+		"timestamp": {strconv.FormatInt(timestamp, 10)},
+	}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", endpoint, res.Status)
+	}
+	var body historicalPriceResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	if len(body.Prices) == 0 {
+		return nil, fmt.Errorf("%w at %d", ErrNoRate, timestamp)
+	}
+
+	values := map[string]float64{}
+	for coin, value := range body.Prices[0] {
+		if coin != "time" && value > 0 {
+			values[coin] = value
+		}
+	}
+	if usd, ok := values["USD"]; ok {
+		for pair, exchangeRate := range body.ExchangeRates {
+			coin := strings.TrimPrefix(pair, "USD")
+			if _, ok := values[coin]; !ok && coin != pair && exchangeRate > 0 {
+				values[coin] = usd * exchangeRate
+			}
+		}
+	}
+
+	rates := make([]Rate, 0, len(values))
+	for coin, value := range values {
+		rates = append(rates, Rate{Coin: coin, Value: value})
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Coin < rates[j].Coin })
+	return rates, nil
+}
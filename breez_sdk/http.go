@@ -0,0 +1,41 @@
+package breez_sdk
+
+import (
+	"net/http"
+	"time"
+)
+
+// HttpClient is used for the HTTP requests made on the Go side: the LNURL
+// requests of PrepareLnurlPay, HistoricalRates and the probes of
+// ChainServiceConfig. Replace it, or its Transport, to go through a proxy, pin
+// certificates or log requests. It is read on every request, so it should be
+// set before the helpers are used.
+//
+// The requests the library makes itself, such as those of PayLnurl,
+// WithdrawLnurl, LnurlAuth and FetchFiatRates, do not go through it.
+var HttpClient = &http.Client{Timeout: 30 * time.Second}
+
+// HeaderTransport returns a RoundTripper adding headers to every request sent
+// through base, or http.DefaultTransport if base is nil. Headers already set on
+// a request are kept.
+func HeaderTransport(base http.RoundTripper, headers http.Header) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return headerTransport{base: base, headers: headers}
+}
+
+type headerTransport struct {
+	base    http.RoundTripper
+	headers http.Header
+}
+
+func (t headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for key, values := range t.headers {
+		if _, ok := req.Header[key]; !ok {
+			req.Header[key] = values
+		}
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,275 @@
+package breez_sdk
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// SettledInvoice is a paid invoice joined with what an order-management
+// system needs to book it.
+type SettledInvoice struct {
+	Payment     Payment
+	PaymentHash string
+	Bolt11      string
+	// Request is the request the invoice was created with, and nil unless it
+	// was created by ReceivePaymentWithOptions on this service.
+	Request *ReceivePaymentRequest
+	// Metadata is the metadata of the payment, including the one passed to
+	// ReceivePaymentWithOptions, see mergeMetadata.
+	Metadata *string
+	// LspFeeMsat is the channel opening fee the LSP deducted from the amount.
+	LspFeeMsat uint64
+	// FiatRates are the BTC exchange rates at settlement, nil if they could not
+	// be fetched.
+	FiatRates []Rate
+	SettledAt time.Time
+}
+
+// FiatValue returns the value of the received amount in the fiat currency coin,
+// such as "USD", at the rate of the settlement.
+func (i SettledInvoice) FiatValue(coin string) (float64, bool) {
+	for _, rate := range i.FiatRates {
+		if rate.Coin == coin {
+			return float64(i.Payment.AmountMsat) / msatPerBtc * rate.Value, true
+		}
+	}
+	return 0, false
+}
+
+type storedInvoice struct {
+	request   ReceivePaymentRequest
+	metadata  *string
+	expiresAt time.Time
+}
+
+// storeInvoice records an invoice created by this service in the local invoice
+// store until it is settled or expires. The settler is started for invoices
+// with metadata, which it writes once they are paid.
+func (_self *BlockingBreezServices) storeInvoice(req ReceivePaymentRequest, res ReceivePaymentResponse, metadata *string) {
+	if metadata != nil {
+		_self.startSettler()
+	}
+	state := _self.state()
+	now := _self.Clock().Now()
+	invoice := res.LnInvoice
+	expiresAt := time.Unix(int64(invoice.Timestamp+invoice.Expiry), 0)
+
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	if state.invoices == nil {
+		state.invoices = map[string]storedInvoice{}
+	}
+	for hash, stored := range state.invoices {
+		if now.After(stored.expiresAt) {
+			delete(state.invoices, hash)
+		}
+	}
+	state.invoices[invoice.PaymentHash] = storedInvoice{
+		request:   req,
+		metadata:  metadata,
+		expiresAt: expiresAt,
+	}
+}
+
+// hasStoredMetadata reports whether the invoice with the given hash is stored
+// with metadata to write once it is paid.
+func (_self *BlockingBreezServices) hasStoredMetadata(paymentHash string) bool {
+	state := _self.state()
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	stored, ok := state.invoices[paymentHash]
+	return ok && stored.metadata != nil
+}
+
+func (_self *BlockingBreezServices) takeStoredInvoice(paymentHash string) (storedInvoice, bool) {
+	state := _self.state()
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	stored, ok := state.invoices[paymentHash]
+	delete(state.invoices, paymentHash)
+	return stored, ok
+}
+
+// OnInvoiceSettled calls fn with a SettledInvoice for every paid invoice. Metadata
+// given to ReceivePaymentWithOptions is written to the payment before fn is
+// called. Invoices are settled off the library's event thread, one at a time in
+// the order they were paid, and every callback gets the same SettledInvoice.
+// The returned function stops the callbacks, as does Close.
+func (_self *BlockingBreezServices) OnInvoiceSettled(fn func(SettledInvoice)) (stop func()) {
+	settler := _self.startSettler()
+	id := settler.subscribe(fn)
+	return _self.stopOnClose(func() { settler.remove(id) })
+}
+
+// startSettler returns the invoice settler of the service, starting it on
+// first use.
+func (_self *BlockingBreezServices) startSettler() *invoiceSettler {
+	state := _self.state()
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	settler := state.settler
+	if settler == nil {
+		settler = &invoiceSettler{service: _self, subscribers: map[uint64]func(SettledInvoice){}}
+		settler.idle = sync.NewCond(&settler.lock)
+		state.settler = settler
+		settler.unsubscribe = _self.subscribeEvents(func(e BreezEvent) {
+			if paid, ok := e.(BreezEventInvoicePaid); ok {
+				settler.enqueue(paid.Details)
+			}
+		})
+		state.addShutdownHookLocked(settler.close)
+	}
+	return settler
+}
+
+// invoiceSettler settles the invoices paid on a service for all OnInvoiceSettled
+// callbacks, and writes the metadata of the stored invoices even when there are
+// none. Settling calls the library and fetches fiat rates, so it runs on a
+// goroutine of its own rather than on the event thread.
+type invoiceSettler struct {
+	service     *BlockingBreezServices
+	unsubscribe func()
+	lock        sync.Mutex
+	idle        *sync.Cond
+	nextId      uint64
+	subscribers map[uint64]func(SettledInvoice)
+	pending     []InvoicePaidDetails
+	running     bool
+	closed      bool
+}
+
+func (s *invoiceSettler) subscribe(fn func(SettledInvoice)) uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.nextId++
+	s.subscribers[s.nextId] = fn
+	return s.nextId
+}
+
+func (s *invoiceSettler) remove(id uint64) {
+	s.lock.Lock()
+	delete(s.subscribers, id)
+	s.lock.Unlock()
+}
+
+func (s *invoiceSettler) enqueue(details InvoicePaidDetails) {
+	pendingMetadata := s.service.hasStoredMetadata(details.PaymentHash)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed || (len(s.subscribers) == 0 && !pendingMetadata) {
+		return
+	}
+	s.pending = append(s.pending, details)
+	if !s.running {
+		s.running = true
+		go s.run()
+	}
+}
+
+func (s *invoiceSettler) run() {
+	s.lock.Lock()
+	for len(s.pending) > 0 && !s.closed {
+		details := s.pending[0]
+		s.pending = s.pending[1:]
+		withRates := len(s.subscribers) > 0
+		s.lock.Unlock()
+
+		settled, ok := s.service.settleInvoice(details, withRates)
+
+		s.lock.Lock()
+		if !ok || s.closed || len(s.subscribers) == 0 {
+			continue
+		}
+		subscribers := make([]func(SettledInvoice), 0, len(s.subscribers))
+		for _, fn := range s.subscribers {
+			subscribers = append(subscribers, fn)
+		}
+		s.lock.Unlock()
+		for _, fn := range subscribers {
+			fn(settled)
+		}
+		s.lock.Lock()
+	}
+	s.running = false
+	s.idle.Broadcast()
+	s.lock.Unlock()
+}
+
+// close stops settling and waits for the invoice being settled, so that the
+// library is not called after Close.
+func (s *invoiceSettler) close() {
+	s.unsubscribe()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.closed = true
+	s.pending = nil
+	for s.running {
+		s.idle.Wait()
+	}
+}
+
+// settleInvoice writes the stored metadata of the invoice and returns it
+// settled, with fiat rates unless withRates is false.
+func (_self *BlockingBreezServices) settleInvoice(details InvoicePaidDetails, withRates bool) (SettledInvoice, bool) {
+	payment := details.Payment
+	if payment == nil {
+		var err error
+		if payment, err = _self.Guarded().PaymentByHash(details.PaymentHash); err != nil || payment == nil {
+			return SettledInvoice{}, false
+		}
+	}
+
+	settled := SettledInvoice{
+		Payment:     *payment,
+		PaymentHash: details.PaymentHash,
+		Bolt11:      details.Bolt11,
+		Metadata:    payment.Metadata,
+		LspFeeMsat:  payment.FeeMsat,
+		SettledAt:   _self.Clock().Now(),
+	}
+	if stored, ok := _self.takeStoredInvoice(details.PaymentHash); ok {
+		request := stored.request
+		settled.Request = &request
+		if stored.metadata != nil {
+			metadata, err := _self.updatePaymentMetadata(details.PaymentHash, func(current *string) (*string, error) {
+				return mergeMetadata(current, *stored.metadata)
+			})
+			if err == nil {
+				settled.Metadata = metadata
+				settled.Payment.Metadata = metadata
+			}
+		}
+	}
+	if withRates {
+		if rates, err := _self.Guarded().FetchFiatRates(); err == nil {
+			settled.FiatRates = rates
+		}
+	}
+	return settled, true
+}
+
+// mergeMetadata returns the metadata of a payment with the metadata stored with
+// its invoice added, or nil to keep current. Metadata that is not a JSON object
+// is only written if the payment has none, and the keys of the payment's
+// metadata, such as the one of MirrorLnurlPayMetadata, are kept.
+func mergeMetadata(current *string, stored string) (*string, error) {
+	if current == nil || *current == "" {
+		return &stored, nil
+	}
+	var merged, added map[string]json.RawMessage
+	if json.Unmarshal([]byte(*current), &merged) != nil || merged == nil || json.Unmarshal([]byte(stored), &added) != nil {
+		return nil, nil
+	}
+	changed := false
+	for key, value := range added {
+		if _, ok := merged[key]; !ok {
+			merged[key] = value
+			changed = true
+		}
+	}
+	if !changed {
+		return nil, nil
+	}
+	return encodeMetadata(merged)
+}
@@ -0,0 +1,28 @@
+package breez_sdk
+
+import "testing"
+
+func TestMergeMetadata(t *testing.T) {
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		current *string
+		stored  string
+		want    *string
+	}{
+		{nil, `{"order_id":"1"}`, str(`{"order_id":"1"}`)},
+		{str(""), `"plain"`, str(`"plain"`)},
+		{str(`{"lnurl_pay":{"comment":"hi"}}`), `{"order_id":"1"}`, str(`{"lnurl_pay":{"comment":"hi"},"order_id":"1"}`)},
+		{str(`{"order_id":"2"}`), `{"order_id":"1"}`, nil},
+		{str(`{"lnurl_pay":{}}`), `"plain"`, nil},
+		{str(`null`), `{"order_id":"1"}`, nil},
+	}
+	for _, test := range tests {
+		got, err := mergeMetadata(test.current, test.stored)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (got == nil) != (test.want == nil) || (got != nil && *got != *test.want) {
+			t.Errorf("mergeMetadata(%v, %s) = %v, want %v", optionalString(test.current), test.stored, optionalString(got), optionalString(test.want))
+		}
+	}
+}
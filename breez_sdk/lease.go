@@ -0,0 +1,350 @@
+package breez_sdk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultLeaseTTL is the lifetime of a session lease when LeaseOptions.TTL is
+// not set. Leases are renewed every third of it.
+const DefaultLeaseTTL = 30 * time.Second
+
+// Lease is a session lease on a node, held by the instance connected to it.
+type Lease struct {
+	Holder string `json:"holder"`
+	// Token grows with every acquisition. Stores compare it to tell whether
+	// a lease is still the one an instance holds.
+	Token      uint64    `json:"token"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// LeaseStore keeps the session leases of nodes where all instances that may
+// connect to them can reach it, such as a shared database.
+type LeaseStore interface {
+	// Lease returns the lease on key, and false if there is none.
+	Lease(key string) (Lease, bool, error)
+	// SwapLease replaces the lease on key with next if the stored lease has
+	// the token oldToken, zero meaning that there is none, and reports
+	// whether it did. A next lease with a zero token removes the lease. The
+	// comparison and the replacement must be atomic.
+	SwapLease(key string, oldToken uint64, next Lease) (bool, error)
+}
+
+// LeaseHeldError is returned by ConnectWithLease when another instance holds
+// an unexpired lease on the node.
+type LeaseHeldError struct {
+	Lease Lease
+}
+
+func (err *LeaseHeldError) Error() string {
+	return fmt.Sprintf("node is leased to %s until %s", err.Lease.Holder, err.Lease.ExpiresAt.Format(time.RFC3339))
+}
+
+// LeaseLost is reported to LeaseOptions.OnLost when another instance took the
+// lease over.
+type LeaseLost struct {
+	Lease Lease
+	// TakenBy is the lease of the new holder, the zero Lease if the lease
+	// was removed.
+	TakenBy Lease
+}
+
+// LeaseOptions configure ConnectWithLease.
+type LeaseOptions struct {
+	Store LeaseStore
+	// Holder identifies this instance, the host name and process id by
+	// default.
+	Holder string
+	// TTL defaults to DefaultLeaseTTL.
+	TTL time.Duration
+	// ForceTakeover acquires the lease even while another instance holds
+	// it. That instance notices at its next renewal and closes itself.
+	ForceTakeover bool
+	// OnLost is called before the service is closed because its lease was
+	// taken over.
+	OnLost func(LeaseLost)
+	// Clock defaults to SystemClock.
+	Clock Clock
+}
+
+// ConnectWithLease acquires the session lease of the node of req.Seed and then
+// connects like Connect, so that two instances never run the same node at
+// once. The lease is renewed while the service runs and released by Close.
+// When another instance holds the lease, ConnectWithLease fails with a
+// *LeaseHeldError unless opts.ForceTakeover is set.
+//
+// Fencing is cooperative: an instance whose lease was taken over keeps
+// running until its next renewal, at most a third of the TTL, and then
+// calls opts.OnLost and closes itself.
+func ConnectWithLease(req ConnectRequest, listener EventListener, opts LeaseOptions) (*BlockingBreezServices, error) {
+	if opts.Holder == "" {
+		host, _ := os.Hostname()
+		opts.Holder = fmt.Sprintf("%s:%d", host, os.Getpid())
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultLeaseTTL
+	}
+	if opts.Clock == nil {
+		opts.Clock = SystemClock
+	}
+
+	keeper := &leaseKeeper{opts: opts, key: leaseKey(req.Seed)}
+	if err := keeper.acquire(); err != nil {
+		return nil, err
+	}
+	service, err := connect(req, listener)
+	if err != nil {
+		keeper.release()
+		return nil, err
+	}
+
+	keeper.service = service
+	state := service.state()
+	state.lock.Lock()
+	state.lease = keeper
+	state.lock.Unlock()
+	service.RegisterShutdownHook(keeper.release)
+	keeper.lock.Lock()
+	keeper.scheduleLocked()
+	keeper.lock.Unlock()
+	return service, nil
+}
+
+// WhoHoldsLease returns the current lease on the node of a service connected
+// with ConnectWithLease, and false if there is none or the service was
+// connected otherwise.
+func (_self *BlockingBreezServices) WhoHoldsLease() (Lease, bool, error) {
+	state := _self.state()
+	state.lock.Lock()
+	keeper := state.lease
+	state.lock.Unlock()
+	if keeper == nil {
+		return Lease{}, false, nil
+	}
+	return keeper.opts.Store.Lease(keeper.key)
+}
+
+// leaseKey derives the store key of a node from its seed without revealing it.
+func leaseKey(seed []uint8) string {
+	hash := sha256.Sum256(append([]byte("breez-sdk-lease:"), seed...))
+	return hex.EncodeToString(hash[:16])
+}
+
+type leaseKeeper struct {
+	opts    LeaseOptions
+	key     string
+	service *BlockingBreezServices
+	lock    sync.Mutex
+	held    Lease
+	timer   Timer
+	stopped bool
+}
+
+func (k *leaseKeeper) acquire() error {
+	current, ok, err := k.opts.Store.Lease(k.key)
+	if err != nil {
+		return err
+	}
+	now := k.opts.Clock.Now()
+	if ok && current.Holder != k.opts.Holder && now.Before(current.ExpiresAt) && !k.opts.ForceTakeover {
+		return &LeaseHeldError{Lease: current}
+	}
+	next := Lease{
+		Holder:     k.opts.Holder,
+		Token:      current.Token + 1,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(k.opts.TTL),
+	}
+	swapped, err := k.opts.Store.SwapLease(k.key, current.Token, next)
+	if err != nil {
+		return err
+	}
+	if !swapped {
+		// Another instance acquired it in between.
+		current, _, err := k.opts.Store.Lease(k.key)
+		if err != nil {
+			return err
+		}
+		return &LeaseHeldError{Lease: current}
+	}
+	k.held = next
+	return nil
+}
+
+func (k *leaseKeeper) scheduleLocked() {
+	k.timer = k.opts.Clock.AfterFunc(k.opts.TTL/3, k.renew)
+}
+
+func (k *leaseKeeper) renew() {
+	k.lock.Lock()
+	if k.stopped {
+		k.lock.Unlock()
+		return
+	}
+	next := k.held
+	next.ExpiresAt = k.opts.Clock.Now().Add(k.opts.TTL)
+	swapped, err := k.opts.Store.SwapLease(k.key, k.held.Token, next)
+	if err != nil || swapped {
+		// A store failure is retried at the next renewal, before the lease
+		// expires.
+		if swapped {
+			k.held = next
+		}
+		k.scheduleLocked()
+		k.lock.Unlock()
+		return
+	}
+	k.stopped = true
+	lost := LeaseLost{Lease: k.held}
+	k.lock.Unlock()
+
+	lost.TakenBy, _, _ = k.opts.Store.Lease(k.key)
+	if k.opts.OnLost != nil {
+		k.opts.OnLost(lost)
+	}
+	k.service.Close()
+}
+
+// release stops the renewals and removes the lease if it is still held.
+func (k *leaseKeeper) release() {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if k.stopped {
+		return
+	}
+	k.stopped = true
+	if k.timer != nil {
+		k.timer.Stop()
+	}
+	k.opts.Store.SwapLease(k.key, k.held.Token, Lease{})
+}
+
+type memoryLeaseStore struct {
+	lock   sync.Mutex
+	leases map[string]Lease
+}
+
+// NewMemoryLeaseStore returns a LeaseStore that only guards the instances of
+// one process.
+func NewMemoryLeaseStore() LeaseStore {
+	return &memoryLeaseStore{leases: map[string]Lease{}}
+}
+
+func (s *memoryLeaseStore) Lease(key string) (Lease, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	lease, ok := s.leases[key]
+	return lease, ok, nil
+}
+
+func (s *memoryLeaseStore) SwapLease(key string, oldToken uint64, next Lease) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.leases[key].Token != oldToken {
+		return false, nil
+	}
+	if next.Token == 0 {
+		delete(s.leases, key)
+	} else {
+		s.leases[key] = next
+	}
+	return true, nil
+}
+
+// staleLeaseLock is the age after which the lock file of a crashed swap is
+// removed.
+const staleLeaseLock = 10 * time.Second
+
+type fileLeaseStore struct {
+	dir   string
+	clock Clock
+}
+
+// NewFileLeaseStore returns a LeaseStore keeping one file per lease in dir,
+// which is created if needed. It guards the instances of one machine, or of
+// several sharing dir over a file system with atomic exclusive creates. clock
+// times the waits for a locked lease; pass SystemClock unless testing.
+func NewFileLeaseStore(dir string, clock Clock) (LeaseStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &fileLeaseStore{dir: dir, clock: clock}, nil
+}
+
+func (s *fileLeaseStore) Lease(key string) (Lease, bool, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, key+".lease"))
+	if os.IsNotExist(err) {
+		return Lease{}, false, nil
+	}
+	if err != nil {
+		return Lease{}, false, err
+	}
+	var lease Lease
+	if err := json.Unmarshal(data, &lease); err != nil {
+		return Lease{}, false, err
+	}
+	return lease, true, nil
+}
+
+func (s *fileLeaseStore) SwapLease(key string, oldToken uint64, next Lease) (bool, error) {
+	unlock, err := s.lockKey(key)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	current, _, err := s.Lease(key)
+	if err != nil {
+		return false, err
+	}
+	if current.Token != oldToken {
+		return false, nil
+	}
+	path := filepath.Join(s.dir, key+".lease")
+	if next.Token == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return false, err
+		}
+		return true, nil
+	}
+	data, err := json.Marshal(next)
+	if err != nil {
+		return false, err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return false, err
+	}
+	return true, os.Rename(tmp, path)
+}
+
+// lockKey creates the lock file of key, waiting for another swap holding it.
+func (s *fileLeaseStore) lockKey(key string) (unlock func(), err error) {
+	path := filepath.Join(s.dir, key+".lock")
+	for i := 0; ; i++ {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if info, err := os.Stat(path); err == nil && s.clock.Now().Sub(info.ModTime()) > staleLeaseLock {
+			os.Remove(path)
+			continue
+		}
+		if i == 100 {
+			return nil, fmt.Errorf("lease %s is locked by %s", key, path)
+		}
+		sleep(s.clock, 20*time.Millisecond)
+	}
+}
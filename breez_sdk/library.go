@@ -0,0 +1,143 @@
+package breez_sdk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// The FFI namespace the Go scaffolding in this package was generated against.
+// Every exported symbol of the bundled library is prefixed with it, so a library
+// built from a different breez-sdk revision will not share it.
+const expectedLibraryNamespace = "a35c"
+
+// The breez-sdk release the bundled libraries were built from.
+const expectedLibraryVersion = "0.6.6"
+
+// The uniffi runtime version the scaffolding was generated with.
+const expectedUniffiVersion = "0.23.0"
+
+// SHA-256 checksums of the shared libraries bundled under lib/, keyed by GOOS/GOARCH.
+var expectedLibraryChecksums = map[string]string{
+	"android/386":   "861ac0b2bf1240230296eee00cfdc95aa2e82e7a609d2acb656338c65649c02f",
+	"android/arm":   "28834b5a536e7c00f30aa3e55545cee284952686feff3d53116a79b21a749891",
+	"android/arm64": "562adf9df7aaa8ca08a977240c3b9df52f6a4948d0d812f19c31c25081a31e52",
+	"android/amd64": "f5dd0eeddd5509cf909992603fb9abf7cbfff8e4a3d1dc80582fb15e08ced3bb",
+	"darwin/arm64":  "f6fe48b5f00af8ed1b7c16c0ba5aa512c9d9160e251a9b60ca79613e614989b9",
+	"darwin/amd64":  "199d8497673587db611b043ee0c0f1be6002f8a917fd1e15b88d7f63b1fdcfa4",
+	"linux/arm64":   "e3e957de8e3494f4d2c5b2096d4c04147d8c649590796b43c3c254e8c58f3c44",
+	"linux/amd64":   "80737303bf36abf7b2e48c19c3cd01d02b0de2ed34cd3586b3d7211e2e8bacac",
+	"windows/amd64": "be51aa5da02c1696b41914697a433ede2497bf77670106d7b65ce4d1f9737c86",
+}
+
+// LibraryCompatibilityReport describes how the loaded breez_sdk_bindings library
+// compares to the one this package was built for.
+type LibraryCompatibilityReport struct {
+	Platform          string   `json:"platform"`
+	ExpectedVersion   string   `json:"expected_version"`
+	ExpectedNamespace string   `json:"expected_namespace"`
+	ExpectedUniffi    string   `json:"expected_uniffi"`
+	ExpectedSha256    *string  `json:"expected_sha256,omitempty"`
+	FoundSha256       *string  `json:"found_sha256,omitempty"`
+	LoadedFrom        *string  `json:"loaded_from,omitempty"`
+	Compatible        bool     `json:"compatible"`
+	Problems          []string `json:"problems,omitempty"`
+}
+
+// CheckLibraryCompatibility locates the breez_sdk_bindings library the process
+// has loaded and compares its checksum with the one bundled with this package.
+// A library that cannot be located or checksummed is reported, but only a
+// checksum mismatch makes the report incompatible.
+func CheckLibraryCompatibility() LibraryCompatibilityReport {
+	platform := runtime.GOOS + "/" + runtime.GOARCH
+	report := LibraryCompatibilityReport{
+		Platform:          platform,
+		ExpectedVersion:   expectedLibraryVersion,
+		ExpectedNamespace: expectedLibraryNamespace,
+		ExpectedUniffi:    expectedUniffiVersion,
+		Compatible:        true,
+	}
+
+	if expected, ok := expectedLibraryChecksums[platform]; ok {
+		report.ExpectedSha256 = &expected
+	} else {
+		report.Problems = append(report.Problems, fmt.Sprintf("no bundled library for platform %s", platform))
+	}
+
+	path, err := loadedLibraryPath()
+	if err != nil {
+		report.Problems = append(report.Problems, fmt.Sprintf("locating loaded library: %v", err))
+		return report
+	}
+	report.LoadedFrom = &path
+
+	found, err := fileSha256(path)
+	if err != nil {
+		report.Problems = append(report.Problems, fmt.Sprintf("checksumming %s: %v", path, err))
+		return report
+	}
+	report.FoundSha256 = &found
+
+	if report.ExpectedSha256 != nil && *report.ExpectedSha256 != found {
+		report.Compatible = false
+		report.Problems = append(report.Problems, fmt.Sprintf("library checksum mismatch: expected %s, found %s", *report.ExpectedSha256, found))
+	}
+	return report
+}
+
+func (r LibraryCompatibilityReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "breez_sdk_bindings (%s): compatible=%t\n", r.Platform, r.Compatible)
+	fmt.Fprintf(&b, "  expected version:   %s\n", r.ExpectedVersion)
+	fmt.Fprintf(&b, "  expected namespace: %s (uniffi %s)\n", r.ExpectedNamespace, r.ExpectedUniffi)
+	fmt.Fprintf(&b, "  expected sha256:    %s\n", optionalString(r.ExpectedSha256))
+	fmt.Fprintf(&b, "  found sha256:       %s\n", optionalString(r.FoundSha256))
+	fmt.Fprintf(&b, "  loaded from:        %s\n", optionalString(r.LoadedFrom))
+	for _, problem := range r.Problems {
+		fmt.Fprintf(&b, "  problem: %s\n", problem)
+	}
+	return b.String()
+}
+
+var (
+	mismatchHintOnce sync.Once
+	mismatchHint     string
+)
+
+// libraryMismatchHint returns the compatibility report when the loaded library
+// does not match, to be attached to errors caused by FFI contract violations.
+// The loaded library does not change, so it is checksummed only once.
+func libraryMismatchHint() string {
+	mismatchHintOnce.Do(func() {
+		if report := CheckLibraryCompatibility(); !report.Compatible {
+			mismatchHint = "\n" + report.String()
+		}
+	})
+	return mismatchHint
+}
+
+func fileSha256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func optionalString(value *string) string {
+	if value == nil {
+		return "<unknown>"
+	}
+	return *value
+}
@@ -0,0 +1,32 @@
+//go:build !windows
+
+package breez_sdk
+
+/*
+#cgo linux LDFLAGS: -ldl
+
+#define _GNU_SOURCE
+#include <dlfcn.h>
+#include <stddef.h>
+
+void ffi_breez_sdk_a35c_rustbuffer_free(void);
+
+static const char* breez_sdk_loaded_library_path() {
+	Dl_info info;
+	if (dladdr((void*)ffi_breez_sdk_a35c_rustbuffer_free, &info) == 0) {
+		return NULL;
+	}
+	return info.dli_fname;
+}
+*/
+import "C"
+
+import "fmt"
+
+func loadedLibraryPath() (string, error) {
+	path := C.breez_sdk_loaded_library_path()
+	if path == nil {
+		return "", fmt.Errorf("dladdr could not resolve the library")
+	}
+	return C.GoString(path), nil
+}
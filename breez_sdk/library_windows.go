@@ -0,0 +1,35 @@
+//go:build windows
+
+package breez_sdk
+
+/*
+#include <windows.h>
+
+void ffi_breez_sdk_a35c_rustbuffer_free(void);
+
+static DWORD breez_sdk_loaded_library_path(char* path, DWORD size) {
+	HMODULE module;
+	if (!GetModuleHandleExA(
+			GET_MODULE_HANDLE_EX_FLAG_FROM_ADDRESS | GET_MODULE_HANDLE_EX_FLAG_UNCHANGED_REFCOUNT,
+			(LPCSTR)ffi_breez_sdk_a35c_rustbuffer_free,
+			&module)) {
+		return 0;
+	}
+	return GetModuleFileNameA(module, path, size);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+func loadedLibraryPath() (string, error) {
+	var buffer [C.MAX_PATH]C.char
+	length := C.breez_sdk_loaded_library_path(&buffer[0], C.DWORD(len(buffer)))
+	if length == 0 {
+		return "", fmt.Errorf("GetModuleFileName failed: %d", C.GetLastError())
+	}
+	return C.GoStringN((*C.char)(unsafe.Pointer(&buffer[0])), C.int(length)), nil
+}
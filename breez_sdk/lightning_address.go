@@ -0,0 +1,147 @@
+package breez_sdk
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ErrNotLightningAddress is returned by ResolveLightningAddress for input that
+// is not a lightning address.
+var ErrNotLightningAddress = fmt.Errorf("not a lightning address")
+
+// LightningAddress is a resolved lightning address.
+type LightningAddress struct {
+	Address string
+	// Data is the LNURL-pay request of the address, to be used in
+	// LnUrlPayRequest.
+	Data     LnUrlPayRequestData
+	Metadata LnUrlMetadata
+}
+
+// MinSendableSat is the smallest amount in sat that can be sent to the address.
+func (a LightningAddress) MinSendableSat() uint64 {
+	return (a.Data.MinSendable + 999) / 1000
+}
+
+// MaxSendableSat is the largest amount in sat that can be sent to the address.
+func (a LightningAddress) MaxSendableSat() uint64 {
+	return a.Data.MaxSendable / 1000
+}
+
+// LnUrlMetadata is the metadata of an LNURL-pay request, the entries of its
+// metadata JSON array.
+type LnUrlMetadata struct {
+	// Description is the text/plain entry.
+	Description     string
+	LongDescription *string
+	Image           *LnUrlImage
+	// Identifier and Email are the text/identifier and text/email entries of
+	// lightning addresses.
+	Identifier *string
+	Email      *string
+}
+
+// LnUrlImage is an image/png or image/jpeg metadata entry.
+type LnUrlImage struct {
+	MimeType string
+	Data     []byte
+}
+
+// ResolveLightningAddress fetches the LNURL-pay request of a lightning address
+// such as "user@example.com", checks that it is served by the address' domain
+// and returns it with its parsed metadata.
+func ResolveLightningAddress(address string) (LightningAddress, error) {
+	address = strings.TrimPrefix(strings.TrimSpace(address), "lightning:")
+	user, domain, ok := strings.Cut(address, "@")
+	if !ok || user == "" || domain == "" || strings.ContainsAny(domain, "/@") {
+		return LightningAddress{}, ErrNotLightningAddress
+	}
+
+	input, err := ParseInput(address)
+	if err != nil {
+		return LightningAddress{}, err
+	}
+	var data LnUrlPayRequestData
+	switch input := input.(type) {
+	case InputTypeLnUrlPay:
+		data = input.Data
+	case InputTypeLnUrlError:
+		return LightningAddress{}, fmt.Errorf("resolving %s: %s", address, input.Data.Reason)
+	default:
+		return LightningAddress{}, ErrNotLightningAddress
+	}
+	if !strings.EqualFold(data.Domain, domain) {
+		return LightningAddress{}, fmt.Errorf("resolving %s: served by %s", address, data.Domain)
+	}
+
+	metadata, err := data.ParsedMetadata()
+	if err != nil {
+		return LightningAddress{}, fmt.Errorf("resolving %s: %w", address, err)
+	}
+	return LightningAddress{
+		Address:  strings.ToLower(user) + "@" + strings.ToLower(domain),
+		Data:     data,
+		Metadata: metadata,
+	}, nil
+}
+
+// ErrInvalidLnUrlMetadata is wrapped by the errors of ParsedMetadata.
+var ErrInvalidLnUrlMetadata = fmt.Errorf("invalid LNURL metadata")
+
+// ParsedMetadata parses MetadataStr, checking that it has exactly one
+// text/plain entry and at most one of the other entries. Unknown entries are
+// ignored.
+func (d LnUrlPayRequestData) ParsedMetadata() (LnUrlMetadata, error) {
+	return parseLnUrlMetadata(d.MetadataStr)
+}
+
+func parseLnUrlMetadata(metadataStr string) (LnUrlMetadata, error) {
+	var entries []json.RawMessage
+	if err := json.Unmarshal([]byte(metadataStr), &entries); err != nil {
+		return LnUrlMetadata{}, fmt.Errorf("%w: %v", ErrInvalidLnUrlMetadata, err)
+	}
+
+	var metadata LnUrlMetadata
+	seen := map[string]bool{}
+	for i, raw := range entries {
+		var entry []string
+		if err := json.Unmarshal(raw, &entry); err != nil || len(entry) != 2 {
+			return LnUrlMetadata{}, fmt.Errorf("%w: entry %d is not a [type, content] pair", ErrInvalidLnUrlMetadata, i)
+		}
+		mimeType, content := entry[0], entry[1]
+		kind := mimeType
+		if strings.HasPrefix(mimeType, "image/") {
+			kind = "image"
+		}
+		if seen[kind] {
+			return LnUrlMetadata{}, fmt.Errorf("%w: more than one %s entry", ErrInvalidLnUrlMetadata, kind)
+		}
+		seen[kind] = true
+
+		switch mimeType {
+		case "text/plain":
+			metadata.Description = content
+		case "text/long-desc":
+			metadata.LongDescription = &content
+		case "text/identifier":
+			metadata.Identifier = &content
+		case "text/email":
+			metadata.Email = &content
+		case "image/png;base64", "image/jpeg;base64":
+			image, err := base64.StdEncoding.DecodeString(content)
+			if err != nil {
+				return LnUrlMetadata{}, fmt.Errorf("%w: image: %v", ErrInvalidLnUrlMetadata, err)
+			}
+			metadata.Image = &LnUrlImage{
+				MimeType: strings.TrimSuffix(mimeType, ";base64"),
+				Data:     image,
+			}
+		}
+	}
+	if !seen["text/plain"] {
+		return LnUrlMetadata{}, fmt.Errorf("%w: no text/plain entry", ErrInvalidLnUrlMetadata)
+	}
+	return metadata, nil
+}
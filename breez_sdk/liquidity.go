@@ -0,0 +1,222 @@
+package breez_sdk
+
+import (
+	"fmt"
+	"sync"
+)
+
+// LiquidityPolicy configures StartLiquidityManager. Zero fields disable the
+// check they belong to.
+type LiquidityPolicy struct {
+	// MinInboundMsat is the inbound liquidity below which a
+	// LiquidityActionInboundLow is reported, with the fee of the channel
+	// opening that the next receive of the missing amount would cause.
+	// The LSP only opens channels for incoming payments, so the opening
+	// itself is left to the next ReceivePayment.
+	MinInboundMsat uint64
+	// MaxChannelOpenFeeMsat is the opening fee up to which
+	// LiquidityAction.WithinBudget is set for LiquidityActionInboundLow.
+	MaxChannelOpenFeeMsat uint64
+
+	// ColdAddress receives the local balance above MaxLocalMsat through a
+	// reverse swap, leaving TargetLocalMsat in the channels.
+	ColdAddress     string
+	MaxLocalMsat    uint64
+	TargetLocalMsat uint64
+	// MaxSwapFeeSat caps the total fees of a reverse swap, which is skipped
+	// when they are higher. Zero allows any fee.
+	MaxSwapFeeSat uint64
+	// SatPerVbyte is the feerate of the claim transaction, the half-hour
+	// fee of RecommendedFees by default.
+	SatPerVbyte uint32
+
+	// DryRun reports the actions without executing any.
+	DryRun bool
+}
+
+// LiquidityActionKind tells what a LiquidityAction is about.
+type LiquidityActionKind int
+
+const (
+	LiquidityActionInboundLow LiquidityActionKind = iota
+	LiquidityActionSwapOut
+)
+
+func (k LiquidityActionKind) String() string {
+	switch k {
+	case LiquidityActionInboundLow:
+		return "inbound_low"
+	case LiquidityActionSwapOut:
+		return "swap_out"
+	default:
+		return "unknown"
+	}
+}
+
+// LiquidityAction is reported by the liquidity manager for every action it
+// took, or would have taken in a dry run, and for every one it skipped.
+type LiquidityAction struct {
+	Kind      LiquidityActionKind
+	AmountSat uint64
+	FeeSat    uint64
+	// WithinBudget is set when the fee is within the policy's limit.
+	WithinBudget bool
+	DryRun       bool
+	// Skipped explains why the action was not executed, such as a fee over
+	// budget or a swap already in progress.
+	Skipped string
+	Err     error
+	// Swap is the reverse swap started by an executed swap out.
+	Swap *ReverseSwapInfo
+}
+
+// StartLiquidityManager checks the liquidity of the node against policy after
+// every Synced event and calls fn with every action. Checks never overlap; a
+// sync during a check is skipped. The returned function stops the manager, as
+// does Close.
+func (_self *BlockingBreezServices) StartLiquidityManager(policy LiquidityPolicy, fn func(LiquidityAction)) (stop func()) {
+	m := &liquidityManager{service: _self, policy: policy, fn: fn}
+	unsubscribe := _self.subscribeEvents(func(e BreezEvent) {
+		if _, ok := e.(BreezEventSynced); ok {
+			go m.check()
+		}
+	})
+	return _self.stopOnClose(func() {
+		unsubscribe()
+		m.lock.Lock()
+		m.stopped = true
+		m.lock.Unlock()
+	})
+}
+
+type liquidityManager struct {
+	service *BlockingBreezServices
+	policy  LiquidityPolicy
+	fn      func(LiquidityAction)
+	lock    sync.Mutex
+	running bool
+	stopped bool
+}
+
+func (m *liquidityManager) check() {
+	m.lock.Lock()
+	if m.running || m.stopped {
+		m.lock.Unlock()
+		return
+	}
+	m.running = true
+	m.lock.Unlock()
+	defer func() {
+		m.lock.Lock()
+		m.running = false
+		m.lock.Unlock()
+	}()
+
+	node, err := m.service.Guarded().NodeInfo()
+	if err != nil {
+		return
+	}
+	if m.policy.MinInboundMsat > 0 && node.TotalInboundLiquidityMsats < m.policy.MinInboundMsat {
+		m.report(m.inboundLow(node))
+	}
+	if m.policy.ColdAddress != "" && m.policy.MaxLocalMsat > 0 && node.ChannelsBalanceMsat > m.policy.MaxLocalMsat {
+		m.report(m.swapOut(node))
+	}
+}
+
+func (m *liquidityManager) report(action LiquidityAction) {
+	m.lock.Lock()
+	stopped := m.stopped
+	m.lock.Unlock()
+	if !stopped {
+		m.fn(action)
+	}
+}
+
+func (m *liquidityManager) inboundLow(node NodeState) LiquidityAction {
+	missingMsat := m.policy.MinInboundMsat - node.TotalInboundLiquidityMsats
+	action := LiquidityAction{
+		Kind:      LiquidityActionInboundLow,
+		AmountSat: missingMsat / 1000,
+		DryRun:    m.policy.DryRun,
+	}
+	res, err := m.service.Guarded().OpenChannelFee(OpenChannelFeeRequest{AmountMsat: &missingMsat})
+	if err != nil {
+		action.Err = err
+		return action
+	}
+	if res.FeeMsat != nil {
+		action.FeeSat = *res.FeeMsat / 1000
+		action.WithinBudget = m.policy.MaxChannelOpenFeeMsat == 0 || *res.FeeMsat <= m.policy.MaxChannelOpenFeeMsat
+	}
+	return action
+}
+
+func (m *liquidityManager) swapOut(node NodeState) LiquidityAction {
+	action := LiquidityAction{
+		Kind:      LiquidityActionSwapOut,
+		AmountSat: (node.ChannelsBalanceMsat - m.policy.TargetLocalMsat) / 1000,
+		DryRun:    m.policy.DryRun,
+	}
+	if m.policy.TargetLocalMsat > node.ChannelsBalanceMsat {
+		action.AmountSat = 0
+	}
+
+	inProgress, err := m.service.Guarded().InProgressOnchainPayments()
+	if err != nil {
+		action.Err = err
+		return action
+	}
+	if len(inProgress) > 0 {
+		action.Skipped = "a reverse swap is in progress"
+		return action
+	}
+	limits, err := m.service.Guarded().OnchainPaymentLimits()
+	if err != nil {
+		action.Err = err
+		return action
+	}
+	if action.AmountSat > limits.MaxSat {
+		action.AmountSat = limits.MaxSat
+	}
+	if action.AmountSat < limits.MinSat {
+		action.Skipped = fmt.Sprintf("amount below the swap minimum of %d sat", limits.MinSat)
+		return action
+	}
+
+	feerate := m.policy.SatPerVbyte
+	if feerate == 0 {
+		fees, err := m.service.Guarded().RecommendedFees()
+		if err != nil {
+			action.Err = err
+			return action
+		}
+		feerate = uint32(fees.HalfHourFee)
+	}
+	prepared, err := m.service.Guarded().PrepareOnchainPayment(PrepareOnchainPaymentRequest{
+		AmountSat:      action.AmountSat,
+		AmountType:     SwapAmountTypeSend,
+		ClaimTxFeerate: feerate,
+	})
+	if err != nil {
+		action.Err = err
+		return action
+	}
+	action.FeeSat = prepared.TotalFees
+	action.WithinBudget = m.policy.MaxSwapFeeSat == 0 || prepared.TotalFees <= m.policy.MaxSwapFeeSat
+	if !action.WithinBudget {
+		action.Skipped = fmt.Sprintf("fees of %d sat over the budget of %d sat", prepared.TotalFees, m.policy.MaxSwapFeeSat)
+		return action
+	}
+	if m.policy.DryRun {
+		return action
+	}
+
+	res, err := m.service.Guarded().PayOnchain(PayOnchainRequest{RecipientAddress: m.policy.ColdAddress, PrepareRes: prepared})
+	if err != nil {
+		action.Err = err
+		return action
+	}
+	action.Swap = &res.ReverseSwapInfo
+	return action
+}
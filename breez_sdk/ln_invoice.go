@@ -0,0 +1,88 @@
+package breez_sdk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidInvoice is wrapped by the errors of LnInvoice.Validate and
+// VerifyPreimage.
+var ErrInvalidInvoice = fmt.Errorf("invalid invoice")
+
+var networkNames = map[Network]string{
+	NetworkBitcoin: "bitcoin",
+	NetworkTestnet: "testnet",
+	NetworkSignet:  "signet",
+	NetworkRegtest: "regtest",
+}
+
+func (n Network) String() string {
+	if name, ok := networkNames[n]; ok {
+		return name
+	}
+	return fmt.Sprintf("Network(%d)", uint(n))
+}
+
+// CreatedAt is the creation time of the invoice.
+func (i LnInvoice) CreatedAt() time.Time {
+	return time.Unix(int64(i.Timestamp), 0)
+}
+
+// ExpiryTime is the time after which the invoice can no longer be paid.
+func (i LnInvoice) ExpiryTime() time.Time {
+	return i.CreatedAt().Add(time.Duration(i.Expiry) * time.Second)
+}
+
+// IsExpired reports whether the invoice has expired at now, typically
+// Clock.Now().
+func (i LnInvoice) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiryTime())
+}
+
+// TimeUntilExpiry is the time left at now to pay the invoice, negative once it
+// has expired.
+func (i LnInvoice) TimeUntilExpiry(now time.Time) time.Duration {
+	return i.ExpiryTime().Sub(now)
+}
+
+// AmountSat returns the amount of the invoice rounded up to whole sat, and
+// false for invoices without amount.
+func (i LnInvoice) AmountSat() (uint64, bool) {
+	if i.AmountMsat == nil {
+		return 0, false
+	}
+	return (*i.AmountMsat + 999) / 1000, true
+}
+
+// Validate checks that the invoice is for network and well formed: it needs a
+// 32 byte payment hash and either a description or a description hash. Expiry
+// is not checked, see IsExpired.
+func (i LnInvoice) Validate(network Network) error {
+	if i.Network != network {
+		return fmt.Errorf("%w: invoice for %s, expected %s", ErrInvalidInvoice, i.Network, network)
+	}
+	if hash, err := hex.DecodeString(i.PaymentHash); err != nil || len(hash) != sha256.Size {
+		return fmt.Errorf("%w: malformed payment hash %q", ErrInvalidInvoice, i.PaymentHash)
+	}
+	if i.Description == nil && i.DescriptionHash == nil {
+		return fmt.Errorf("%w: no description", ErrInvalidInvoice)
+	}
+	return nil
+}
+
+// VerifyPreimage checks that the hex encoded preimage hashes to paymentHash,
+// proving that the payment with that hash was settled.
+func VerifyPreimage(paymentHash, preimage string) error {
+	image, err := hex.DecodeString(preimage)
+	if err != nil || len(image) != 32 {
+		return fmt.Errorf("%w: malformed preimage", ErrInvalidInvoice)
+	}
+	digest := sha256.Sum256(image)
+	if hex.EncodeToString(digest[:]) != strings.ToLower(paymentHash) {
+		return fmt.Errorf("%w: preimage does not match payment hash %s", ErrInvalidInvoice, paymentHash)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package breez_sdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// PreparedLnurlPay is an LNURL-pay request resolved to the invoice that will be
+// paid, for the user to confirm before PayPreparedLnurl.
+type PreparedLnurlPay struct {
+	Request    LnUrlPayRequest
+	Invoice    LnInvoice
+	AmountMsat uint64
+	// SuccessAction is the unprocessed success action returned by the service,
+	// if any.
+	SuccessAction json.RawMessage
+}
+
+type lnurlPayCallbackResponse struct {
+	Status        string          `json:"status"`
+	Reason        string          `json:"reason"`
+	Pr            string          `json:"pr"`
+	SuccessAction json.RawMessage `json:"successAction"`
+}
+
+// PrepareLnurlPay requests the invoice of req from the LNURL service and checks
+// it against the request, without paying it. Unlike PayLnurl, the invoice
+// paid by PayPreparedLnurl is then exactly the one shown to the user.
+func PrepareLnurlPay(ctx context.Context, req LnUrlPayRequest) (PreparedLnurlPay, error) {
+	data := req.Data
+	if req.AmountMsat < data.MinSendable || req.AmountMsat > data.MaxSendable {
+		return PreparedLnurlPay{}, &LnUrlPayError{err: &LnUrlPayErrorInvalidAmount{
+			message: fmt.Sprintf("amount %d msat is outside of %d-%d msat", req.AmountMsat, data.MinSendable, data.MaxSendable),
+		}}
+	}
+
+	callback, err := url.Parse(data.Callback)
+	if err != nil {
+		return PreparedLnurlPay{}, &LnUrlPayError{err: &LnUrlPayErrorInvalidUri{message: err.Error()}}
+	}
+	query := callback.Query()
+	query.Set("amount", strconv.FormatUint(req.AmountMsat, 10))
+	if req.Comment != nil && *req.Comment != "" {
+		if len([]rune(*req.Comment)) > int(data.CommentAllowed) {
+			return PreparedLnurlPay{}, &LnUrlPayError{err: &LnUrlPayErrorGeneric{
+				message: fmt.Sprintf("comment is longer than %d characters", data.CommentAllowed),
+			}}
+		}
+		query.Set("comment", *req.Comment)
+	}
+	callback.RawQuery = query.Encode()
+
+	var res lnurlPayCallbackResponse
+	if err := getLnurlJson(ctx, callback.String(), &res); err != nil {
+		return PreparedLnurlPay{}, &LnUrlPayError{err: &LnUrlPayErrorServiceConnectivity{message: err.Error()}}
+	}
+	if res.Status == "ERROR" {
+		return PreparedLnurlPay{}, &LnUrlPayError{err: &LnUrlPayErrorGeneric{message: res.Reason}}
+	}
+
+	invoice, err := ParseInvoice(res.Pr)
+	if err != nil {
+		return PreparedLnurlPay{}, &LnUrlPayError{err: &LnUrlPayErrorInvalidInvoice{message: err.Error()}}
+	}
+	if invoice.AmountMsat == nil || *invoice.AmountMsat != req.AmountMsat {
+		return PreparedLnurlPay{}, &LnUrlPayError{err: &LnUrlPayErrorInvalidInvoice{
+			message: "invoice amount does not match the requested amount",
+		}}
+	}
+	metadataHash := sha256.Sum256([]byte(data.MetadataStr))
+	if invoice.DescriptionHash == nil || *invoice.DescriptionHash != hex.EncodeToString(metadataHash[:]) {
+		return PreparedLnurlPay{}, &LnUrlPayError{err: &LnUrlPayErrorInvalidInvoice{
+			message: "invoice description hash does not match the metadata",
+		}}
+	}
+
+	return PreparedLnurlPay{
+		Request:       req,
+		Invoice:       invoice,
+		AmountMsat:    req.AmountMsat,
+		SuccessAction: res.SuccessAction,
+	}, nil
+}
+
+// PayPreparedLnurl pays the invoice of prepared. The success action is not
+// processed; it is available unprocessed in prepared.SuccessAction.
+func (_self *BlockingBreezServices) PayPreparedLnurl(prepared PreparedLnurlPay) (SendPaymentResponse, error) {
+	return _self.Guarded().SendPayment(SendPaymentRequest{
+		Bolt11:        prepared.Invoice.Bolt11,
+		UseTrampoline: prepared.Request.UseTrampoline,
+		Label:         prepared.Request.PaymentLabel,
+	})
+}
+
+func getLnurlJson(ctx context.Context, endpoint string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	res, err := HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", endpoint, res.Status)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
@@ -0,0 +1,101 @@
+package breez_sdk
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// LnurlPayMetadataKey is the key under which MirrorLnurlPayMetadata stores the
+// LNURL-pay data of a payment in its metadata, which then has the form
+//
+//	{"lnurl_pay": {"comment": "...", "ln_address": "...", "domain": "..."}}
+//
+// so that it can be queried with MetadataFilters such as
+// {JsonPath: "$.lnurl_pay.comment", JsonValue: "\"thanks\""}.
+const LnurlPayMetadataKey = "lnurl_pay"
+
+// LnurlPayMetadata is the value stored under LnurlPayMetadataKey.
+type LnurlPayMetadata struct {
+	Comment   *string `json:"comment,omitempty"`
+	LnAddress *string `json:"ln_address,omitempty"`
+	Domain    *string `json:"domain,omitempty"`
+}
+
+// MirrorLnurlPayMetadata copies the LNURL-pay comment and payer identifiers of
+// every paid invoice into the payment's metadata. Existing metadata is kept, as
+// long as it is a JSON object. The metadata is written off the library's event
+// thread. The returned function stops the mirroring and waits for the writes
+// in progress, as does Close.
+func (_self *BlockingBreezServices) MirrorLnurlPayMetadata() (stop func()) {
+	var (
+		lock    sync.Mutex
+		stopped bool
+		writes  sync.WaitGroup
+	)
+	unsubscribe := _self.subscribeEvents(func(e BreezEvent) {
+		if paid, ok := e.(BreezEventInvoicePaid); ok && paid.Details.Payment != nil {
+			payment := *paid.Details.Payment
+			lock.Lock()
+			if stopped {
+				lock.Unlock()
+				return
+			}
+			writes.Add(1)
+			lock.Unlock()
+			go func() {
+				defer writes.Done()
+				_ = _self.mirrorLnurlPayMetadata(payment)
+			}()
+		}
+	})
+	return _self.stopOnClose(func() {
+		unsubscribe()
+		lock.Lock()
+		stopped = true
+		lock.Unlock()
+		writes.Wait()
+	})
+}
+
+// BackfillLnurlPayMetadata mirrors the LNURL-pay data of the already received
+// payments, see MirrorLnurlPayMetadata.
+func (_self *BlockingBreezServices) BackfillLnurlPayMetadata(ctx context.Context) error {
+	filters := []PaymentTypeFilter{PaymentTypeFilterReceived}
+	return _self.ForEachPayment(ctx, ListPaymentsRequest{Filters: &filters}, _self.mirrorLnurlPayMetadata)
+}
+
+func (_self *BlockingBreezServices) mirrorLnurlPayMetadata(payment Payment) error {
+	details, ok := payment.Details.(PaymentDetailsLn)
+	if !ok {
+		return nil
+	}
+	lnurlPay := LnurlPayMetadata{
+		Comment:   details.Data.LnurlPayComment,
+		LnAddress: details.Data.LnAddress,
+		Domain:    details.Data.LnurlPayDomain,
+	}
+	if lnurlPay.Comment == nil && lnurlPay.LnAddress == nil && lnurlPay.Domain == nil {
+		return nil
+	}
+
+	value, err := json.Marshal(lnurlPay)
+	if err != nil {
+		return err
+	}
+	_, err = _self.updatePaymentMetadata(details.Data.PaymentHash, func(current *string) (*string, error) {
+		metadata := map[string]json.RawMessage{}
+		if current != nil && *current != "" {
+			if err := json.Unmarshal([]byte(*current), &metadata); err != nil || metadata == nil {
+				// Not an object we can extend without losing what the app stored.
+				return nil, nil
+			}
+		}
+		if string(metadata[LnurlPayMetadataKey]) == string(value) {
+			return nil, nil
+		}
+		metadata[LnurlPayMetadataKey] = value
+		return encodeMetadata(metadata)
+	})
+	return err
+}
@@ -0,0 +1,53 @@
+package breez_sdk
+
+import "sync"
+
+// logStreams is the LogStream AddLogStream installs in the library, forwarding
+// entries to every added stream.
+type logStreams struct {
+	lock      sync.RWMutex
+	installed bool
+	nextId    uint64
+	streams   map[uint64]LogStream
+}
+
+var sharedLogStreams = &logStreams{streams: map[uint64]LogStream{}}
+
+func (s *logStreams) Log(l LogEntry) {
+	s.lock.RLock()
+	streams := make([]LogStream, 0, len(s.streams))
+	for _, stream := range s.streams {
+		streams = append(streams, stream)
+	}
+	s.lock.RUnlock()
+
+	for _, stream := range streams {
+		stream.Log(l)
+	}
+}
+
+// AddLogStream adds stream to the streams receiving the library's log
+// entries, and returns a function removing it again. Unlike SetLogStream,
+// which the library accepts only once per process, any number of streams can
+// be added, so that several components embedding the SDK each get the logs.
+// It cannot be combined with SetLogStream.
+func AddLogStream(stream LogStream) (remove func(), err error) {
+	s := sharedLogStreams
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.installed {
+		if err := SetLogStream(s); err != nil {
+			return nil, err
+		}
+		s.installed = true
+	}
+
+	s.nextId++
+	id := s.nextId
+	s.streams[id] = stream
+	return func() {
+		s.lock.Lock()
+		delete(s.streams, id)
+		s.lock.Unlock()
+	}, nil
+}
@@ -0,0 +1,121 @@
+package breez_sdk
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogRecord is a LogEntry with the structure the Rust tracing layer flattens into
+// the log line lifted back out of it.
+type LogRecord struct {
+	Time    time.Time
+	Level   string
+	Message string
+	Fields  map[string]string
+}
+
+// ParseLogEntry splits the trailing `key=value` fields off the entry's line.
+// Values may be double quoted to contain spaces. The time is the moment the
+// entry was received, as the library does not send one.
+func ParseLogEntry(entry LogEntry, at time.Time) LogRecord {
+	record := LogRecord{
+		Time:    at,
+		Level:   entry.Level,
+		Message: entry.Line,
+		Fields:  map[string]string{},
+	}
+
+	tokens := tokenizeLogLine(entry.Line)
+	first := len(tokens)
+	for first > 0 {
+		if _, _, ok := splitLogField(tokens[first-1].text); !ok {
+			break
+		}
+		first--
+	}
+	if first == len(tokens) {
+		return record
+	}
+
+	for _, token := range tokens[first:] {
+		key, value, _ := splitLogField(token.text)
+		record.Fields[key] = value
+	}
+	if first == 0 {
+		record.Message = ""
+	} else {
+		record.Message = strings.TrimSpace(entry.Line[:tokens[first].start])
+	}
+	return record
+}
+
+// SetStructuredLogStream is SetLogStream for callers that want LogRecords,
+// timed by clock as they arrive. Pass SystemClock unless testing.
+func SetStructuredLogStream(clock Clock, fn func(LogRecord)) error {
+	return SetLogStream(structuredLogStream{clock: clock, fn: fn})
+}
+
+type structuredLogStream struct {
+	clock Clock
+	fn    func(LogRecord)
+}
+
+func (s structuredLogStream) Log(l LogEntry) {
+	s.fn(ParseLogEntry(l, s.clock.Now()))
+}
+
+type logToken struct {
+	text  string
+	start int
+}
+
+func tokenizeLogLine(line string) []logToken {
+	var tokens []logToken
+	start := -1
+	quoted := false
+	for i, r := range line {
+		switch {
+		case r == '"':
+			if start == -1 {
+				start = i
+			}
+			quoted = !quoted
+		case r == ' ' && !quoted:
+			if start != -1 {
+				tokens = append(tokens, logToken{line[start:i], start})
+				start = -1
+			}
+		default:
+			if start == -1 {
+				start = i
+			}
+		}
+	}
+	if start != -1 {
+		tokens = append(tokens, logToken{line[start:], start})
+	}
+	return tokens
+}
+
+func splitLogField(token string) (string, string, bool) {
+	key, value, found := strings.Cut(token, "=")
+	if !found || key == "" {
+		return "", "", false
+	}
+	for i, r := range key {
+		isLetter := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		isDigit := r >= '0' && r <= '9'
+		if !isLetter && !(i > 0 && (isDigit || r == '.')) {
+			return "", "", false
+		}
+	}
+	if strings.HasPrefix(value, "\"") {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return "", "", false
+		}
+		value = unquoted
+	}
+	return key, value, true
+}
@@ -0,0 +1,328 @@
+package breez_sdk
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// migrationBundleVersion is the version of the format written by
+// MigrationBundle.Write.
+const migrationBundleVersion = 1
+
+// MigrationBlockerKind tells what a MigrationBlocker is about.
+type MigrationBlockerKind int
+
+const (
+	MigrationBlockerPendingSwap MigrationBlockerKind = iota
+	MigrationBlockerPendingReverseSwap
+	MigrationBlockerInFlightPayment
+	MigrationBlockerPendingChannel
+	MigrationBlockerBackupPending
+)
+
+func (k MigrationBlockerKind) String() string {
+	switch k {
+	case MigrationBlockerPendingSwap:
+		return "pending_swap"
+	case MigrationBlockerPendingReverseSwap:
+		return "pending_reverse_swap"
+	case MigrationBlockerInFlightPayment:
+		return "in_flight_payment"
+	case MigrationBlockerPendingChannel:
+		return "pending_channel"
+	case MigrationBlockerBackupPending:
+		return "backup_pending"
+	default:
+		return "unknown"
+	}
+}
+
+func (k MigrationBlockerKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+// MigrationBlocker is something that must settle before the node is moved,
+// as the new environment could not finish it.
+type MigrationBlocker struct {
+	Kind MigrationBlockerKind `json:"kind"`
+	// Id is the swap address, reverse swap id, payment hash or channel id.
+	Id     string `json:"id"`
+	Detail string `json:"detail"`
+}
+
+// MigrationBlockedError is returned by ExportMigration when there are
+// blockers and MigrationOptions.AllowBlockers is not set.
+type MigrationBlockedError struct {
+	Blockers []MigrationBlocker
+}
+
+func (e *MigrationBlockedError) Error() string {
+	return fmt.Sprintf("migration blocked by %d item(s), the first a %s: %s", len(e.Blockers), e.Blockers[0].Kind, e.Blockers[0].Detail)
+}
+
+// MigrationPreflight lists what blocks moving the node: swaps and reverse
+// swaps in progress, payments in flight among the latest ones, channels being
+// opened or closed and changes not yet backed up. It is empty when the node
+// can be moved.
+func (_self *BlockingBreezServices) MigrationPreflight() ([]MigrationBlocker, error) {
+	blockers := []MigrationBlocker{}
+
+	swaps, err := _self.Guarded().ListSwaps(ListSwapsRequest{
+		Status: &[]SwapStatus{SwapStatusWaitingConfirmation, SwapStatusRedeemable, SwapStatusRefundable},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, swap := range swaps {
+		blockers = append(blockers, MigrationBlocker{
+			Kind:   MigrationBlockerPendingSwap,
+			Id:     swap.BitcoinAddress,
+			Detail: fmt.Sprintf("swap to %s is %s", swap.BitcoinAddress, swapStatusName(swap.Status)),
+		})
+	}
+
+	reverseSwaps, err := _self.Guarded().InProgressOnchainPayments()
+	if err != nil {
+		return nil, err
+	}
+	for _, swap := range reverseSwaps {
+		blockers = append(blockers, MigrationBlocker{
+			Kind:   MigrationBlockerPendingReverseSwap,
+			Id:     swap.Id,
+			Detail: fmt.Sprintf("reverse swap %s of %d sat is in progress", swap.Id, swap.OnchainAmountSat),
+		})
+	}
+
+	limit := uint32(defaultPaymentsPageSize)
+	payments, err := _self.Guarded().ListPayments(ListPaymentsRequest{Limit: &limit})
+	if err != nil {
+		return nil, err
+	}
+	for _, payment := range payments {
+		if payment.Status == PaymentStatusPending {
+			blockers = append(blockers, MigrationBlocker{
+				Kind:   MigrationBlockerInFlightPayment,
+				Id:     payment.Id,
+				Detail: fmt.Sprintf("payment %s of %d msat is pending", payment.Id, payment.AmountMsat),
+			})
+		}
+	}
+
+	channels, err := _self.ListChannels()
+	if err != nil {
+		return nil, err
+	}
+	for _, channel := range channels {
+		if channel.State == ChannelStatePendingOpen || channel.State == ChannelStatePendingClose {
+			blockers = append(blockers, MigrationBlocker{
+				Kind:   MigrationBlockerPendingChannel,
+				Id:     channel.ChannelId,
+				Detail: fmt.Sprintf("channel %s is %s", channel.ChannelId, channelStateName(channel.State)),
+			})
+		}
+	}
+
+	backup, err := _self.Guarded().BackupStatus()
+	if err != nil {
+		return nil, err
+	}
+	if !backup.BackedUp {
+		blockers = append(blockers, MigrationBlocker{
+			Kind:   MigrationBlockerBackupPending,
+			Detail: "the latest changes are not backed up",
+		})
+	}
+	return blockers, nil
+}
+
+func swapStatusName(status SwapStatus) string {
+	switch status {
+	case SwapStatusInitial:
+		return "initial"
+	case SwapStatusWaitingConfirmation:
+		return "waiting for confirmation"
+	case SwapStatusRedeemable:
+		return "redeemable"
+	case SwapStatusRedeemed:
+		return "redeemed"
+	case SwapStatusRefundable:
+		return "refundable"
+	case SwapStatusCompleted:
+		return "completed"
+	default:
+		return "unknown"
+	}
+}
+
+// MigrationOptions configures ExportMigration.
+type MigrationOptions struct {
+	// WorkingDir is the working directory of the node, from which the
+	// static channel backup is read.
+	WorkingDir string
+	// AllowBlockers exports the node despite blockers, which are then
+	// recorded in the bundle.
+	AllowBlockers bool
+}
+
+// MigrationSwap is the state of a swap needed to redeem or refund it from
+// another environment. Byte fields are hex encoded.
+type MigrationSwap struct {
+	BitcoinAddress   string   `json:"bitcoin_address"`
+	CreatedAt        int64    `json:"created_at"`
+	LockHeight       int64    `json:"lock_height"`
+	Status           string   `json:"status"`
+	PaymentHash      string   `json:"payment_hash"`
+	Preimage         string   `json:"preimage"`
+	PrivateKey       string   `json:"private_key"`
+	PublicKey        string   `json:"public_key"`
+	SwapperPublicKey string   `json:"swapper_public_key"`
+	Script           string   `json:"script"`
+	Bolt11           *string  `json:"bolt11,omitempty"`
+	ConfirmedTxIds   []string `json:"confirmed_tx_ids"`
+	UnconfirmedTxIds []string `json:"unconfirmed_tx_ids"`
+	RefundTxIds      []string `json:"refund_tx_ids"`
+	ConfirmedAt      *uint32  `json:"confirmed_at,omitempty"`
+}
+
+// MigrationBundle is everything needed to move the node to another
+// environment, such as a different Greenlight deployment or a self-hosted
+// Core Lightning node, except for the seed, which must be moved separately.
+// It contains the device credentials and the swap private keys, so it must be
+// stored as securely as the seed.
+type MigrationBundle struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	NodeId    string    `json:"node_id"`
+	// GreenlightDevice is the device certificate and key of the node, nil for
+	// nodes without Greenlight credentials.
+	GreenlightDevice []byte             `json:"greenlight_device,omitempty"`
+	StaticBackup     StaticBackupFile   `json:"static_backup"`
+	Swaps            []MigrationSwap    `json:"swaps"`
+	Blockers         []MigrationBlocker `json:"blockers"`
+}
+
+// ExportMigration collects the node's credentials, static channel backup and
+// swaps into a MigrationBundle. It runs MigrationPreflight first and returns a
+// *MigrationBlockedError if there are blockers, unless opts.AllowBlockers is
+// set. The node is left running; stop using it before starting it elsewhere.
+func (_self *BlockingBreezServices) ExportMigration(opts MigrationOptions) (MigrationBundle, error) {
+	blockers, err := _self.MigrationPreflight()
+	if err != nil {
+		return MigrationBundle{}, err
+	}
+	if len(blockers) > 0 && !opts.AllowBlockers {
+		return MigrationBundle{}, &MigrationBlockedError{Blockers: blockers}
+	}
+
+	node, err := _self.Guarded().NodeInfo()
+	if err != nil {
+		return MigrationBundle{}, err
+	}
+	bundle := MigrationBundle{
+		Version:   migrationBundleVersion,
+		CreatedAt: _self.Clock().Now().UTC(),
+		NodeId:    node.Id,
+		Swaps:     []MigrationSwap{},
+		Blockers:  blockers,
+	}
+
+	credentials, err := _self.Guarded().NodeCredentials()
+	if err != nil {
+		return MigrationBundle{}, err
+	}
+	if credentials != nil {
+		if greenlight, ok := (*credentials).(NodeCredentialsGreenlight); ok {
+			bundle.GreenlightDevice = greenlight.Credentials.Device
+		}
+	}
+
+	scb, err := StaticBackup(StaticBackupRequest{WorkingDir: opts.WorkingDir})
+	if err != nil {
+		return MigrationBundle{}, err
+	}
+	entries := []string{}
+	if scb.Backup != nil {
+		entries = *scb.Backup
+	}
+	bundle.StaticBackup = StaticBackupFile{
+		Version:   staticBackupFileVersion,
+		CreatedAt: bundle.CreatedAt,
+		Scb:       entries,
+		Sha256:    staticBackupChecksum(entries),
+	}
+
+	swaps, err := _self.Guarded().ListSwaps(ListSwapsRequest{})
+	if err != nil {
+		return MigrationBundle{}, err
+	}
+	for _, swap := range swaps {
+		bundle.Swaps = append(bundle.Swaps, MigrationSwap{
+			BitcoinAddress:   swap.BitcoinAddress,
+			CreatedAt:        swap.CreatedAt,
+			LockHeight:       swap.LockHeight,
+			Status:           swapStatusName(swap.Status),
+			PaymentHash:      hex.EncodeToString(swap.PaymentHash),
+			Preimage:         hex.EncodeToString(swap.Preimage),
+			PrivateKey:       hex.EncodeToString(swap.PrivateKey),
+			PublicKey:        hex.EncodeToString(swap.PublicKey),
+			SwapperPublicKey: hex.EncodeToString(swap.SwapperPublicKey),
+			Script:           hex.EncodeToString(swap.Script),
+			Bolt11:           swap.Bolt11,
+			ConfirmedTxIds:   swap.ConfirmedTxIds,
+			UnconfirmedTxIds: swap.UnconfirmedTxIds,
+			RefundTxIds:      swap.RefundTxIds,
+			ConfirmedAt:      swap.ConfirmedAt,
+		})
+	}
+	return bundle, nil
+}
+
+// Write writes the bundle to w as indented JSON.
+func (b MigrationBundle) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(b)
+}
+
+// WriteFile writes the bundle to path, readable by the owner only, replacing
+// the file atomically.
+func (b MigrationBundle) WriteFile(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := b.Write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ReadMigrationBundle reads a bundle written by MigrationBundle.Write and
+// verifies its version and static channel backup.
+func ReadMigrationBundle(r io.Reader) (MigrationBundle, error) {
+	var bundle MigrationBundle
+	if err := json.NewDecoder(r).Decode(&bundle); err != nil {
+		return MigrationBundle{}, fmt.Errorf("reading migration bundle: %w", err)
+	}
+	if bundle.Version != migrationBundleVersion {
+		return MigrationBundle{}, fmt.Errorf("unsupported migration bundle version %d", bundle.Version)
+	}
+	if bundle.StaticBackup.Sha256 != staticBackupChecksum(bundle.StaticBackup.Scb) {
+		return MigrationBundle{}, fmt.Errorf("%w: checksum mismatch", ErrInvalidStaticBackup)
+	}
+	return bundle, nil
+}
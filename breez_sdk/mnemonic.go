@@ -0,0 +1,273 @@
+package breez_sdk
+
+import (
+	"crypto/sha256"
+	"embed"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MnemonicLanguage is one of the BIP-39 word lists.
+type MnemonicLanguage int
+
+const (
+	MnemonicLanguageEnglish MnemonicLanguage = iota
+	MnemonicLanguageChineseSimplified
+	MnemonicLanguageChineseTraditional
+	MnemonicLanguageCzech
+	MnemonicLanguageFrench
+	MnemonicLanguageItalian
+	MnemonicLanguageJapanese
+	MnemonicLanguageKorean
+	MnemonicLanguageSpanish
+)
+
+// mnemonicLanguages are the word lists in the order ValidateMnemonic prefers
+// them when a phrase is valid in several.
+var mnemonicLanguages = []MnemonicLanguage{
+	MnemonicLanguageEnglish,
+	MnemonicLanguageChineseSimplified,
+	MnemonicLanguageChineseTraditional,
+	MnemonicLanguageCzech,
+	MnemonicLanguageFrench,
+	MnemonicLanguageItalian,
+	MnemonicLanguageJapanese,
+	MnemonicLanguageKorean,
+	MnemonicLanguageSpanish,
+}
+
+var mnemonicLanguageNames = map[MnemonicLanguage]string{
+	MnemonicLanguageEnglish:            "english",
+	MnemonicLanguageChineseSimplified:  "chinese_simplified",
+	MnemonicLanguageChineseTraditional: "chinese_traditional",
+	MnemonicLanguageCzech:              "czech",
+	MnemonicLanguageFrench:             "french",
+	MnemonicLanguageItalian:            "italian",
+	MnemonicLanguageJapanese:           "japanese",
+	MnemonicLanguageKorean:             "korean",
+	MnemonicLanguageSpanish:            "spanish",
+}
+
+func (l MnemonicLanguage) String() string {
+	if name, ok := mnemonicLanguageNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("MnemonicLanguage(%d)", int(l))
+}
+
+var (
+	// ErrInvalidMnemonic is wrapped by every error of ValidateMnemonic.
+	ErrInvalidMnemonic = fmt.Errorf("invalid mnemonic")
+	// ErrMnemonicWordCount is returned for a phrase that does not have 12,
+	// 15, 18, 21 or 24 words.
+	ErrMnemonicWordCount = fmt.Errorf("%w: word count must be 12, 15, 18, 21 or 24", ErrInvalidMnemonic)
+	// ErrMnemonicChecksum is returned for a phrase of known words whose
+	// checksum does not match, usually a mistyped or swapped word.
+	ErrMnemonicChecksum = fmt.Errorf("%w: checksum mismatch", ErrInvalidMnemonic)
+)
+
+// MnemonicWordError is returned by ValidateMnemonic for a word that is not in
+// the word list of the phrase's language.
+type MnemonicWordError struct {
+	// Index is the position of the word in the phrase, from 0.
+	Index    int
+	Word     string
+	Language MnemonicLanguage
+}
+
+func (err *MnemonicWordError) Error() string {
+	return fmt.Sprintf("invalid mnemonic: word %d %q is not in the %s word list", err.Index+1, err.Word, err.Language)
+}
+
+func (err *MnemonicWordError) Unwrap() error {
+	return ErrInvalidMnemonic
+}
+
+//go:embed wordlists/*.txt
+var wordlistFiles embed.FS
+
+type wordlist struct {
+	words []string
+	index map[string]int
+}
+
+var (
+	wordlistsOnce sync.Once
+	wordlists     map[MnemonicLanguage]wordlist
+)
+
+func loadWordlists() map[MnemonicLanguage]wordlist {
+	wordlistsOnce.Do(func() {
+		wordlists = map[MnemonicLanguage]wordlist{}
+		for _, language := range mnemonicLanguages {
+			data, err := wordlistFiles.ReadFile("wordlists/" + language.String() + ".txt")
+			if err != nil {
+				panic(err)
+			}
+			list := wordlist{words: strings.Fields(string(data)), index: map[string]int{}}
+			for i, word := range list.words {
+				list.index[word] = i
+			}
+			wordlists[language] = list
+		}
+	})
+	return wordlists
+}
+
+// ValidateMnemonic checks phrase against the BIP-39 word lists and returns its
+// language. Words are separated by any white space and compared in lower case
+// and decomposed form, so accented words typed precomposed are found. Unknown
+// words are reported as a *MnemonicWordError for the language most of the
+// words are in; a phrase of known words with the wrong length or checksum
+// fails with ErrMnemonicWordCount or ErrMnemonicChecksum.
+func ValidateMnemonic(phrase string) (MnemonicLanguage, error) {
+	language, _, err := parseMnemonic(phrase)
+	return language, err
+}
+
+// parseMnemonic validates phrase and returns its language and its words as
+// they appear in the word list.
+func parseMnemonic(phrase string) (MnemonicLanguage, []string, error) {
+	words := strings.Fields(phrase)
+	for i, word := range words {
+		words[i] = decomposeMnemonicWord(strings.ToLower(word))
+	}
+
+	lists := loadWordlists()
+	best, bestCount := MnemonicLanguageEnglish, -1
+	var candidates []MnemonicLanguage
+	for _, language := range mnemonicLanguages {
+		count := 0
+		for _, word := range words {
+			if _, ok := lists[language].index[word]; ok {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = language, count
+		}
+		if count == len(words) {
+			candidates = append(candidates, language)
+		}
+	}
+	if len(candidates) == 0 {
+		for i, word := range words {
+			if _, ok := lists[best].index[word]; !ok {
+				return best, nil, &MnemonicWordError{Index: i, Word: word, Language: best}
+			}
+		}
+	}
+
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return candidates[0], nil, ErrMnemonicWordCount
+	}
+	// Word lists overlap, such as English and French, so the checksum
+	// decides between them.
+	for _, language := range candidates {
+		if mnemonicChecksumValid(lists[language], words) {
+			return language, words, nil
+		}
+	}
+	return candidates[0], nil, ErrMnemonicChecksum
+}
+
+// mnemonicChecksumValid checks the checksum of words, each of which encodes 11
+// bits: the entropy followed by the first bits of its SHA-256 hash, one per 32
+// bits of entropy.
+func mnemonicChecksumValid(list wordlist, words []string) bool {
+	bits := make([]bool, 0, len(words)*11)
+	for _, word := range words {
+		index := list.index[word]
+		for b := 10; b >= 0; b-- {
+			bits = append(bits, index>>b&1 == 1)
+		}
+	}
+	checksumBits := len(bits) / 33
+	entropy := make([]byte, (len(bits)-checksumBits)/8)
+	for i := range entropy {
+		for b := 0; b < 8; b++ {
+			if bits[i*8+b] {
+				entropy[i] |= 1 << (7 - b)
+			}
+		}
+	}
+	hash := sha256.Sum256(entropy)
+	wipe(entropy)
+	for i := 0; i < checksumBits; i++ {
+		if bits[len(entropy)*8+i] != (hash[i/8]>>(7-i%8)&1 == 1) {
+			return false
+		}
+	}
+	return true
+}
+
+// mnemonicDecompositions maps the precomposed characters of the word lists to
+// their decomposed form, in which the lists are stored. Hangul syllables are
+// decomposed algorithmically.
+var mnemonicDecompositions = map[rune]string{
+	'á': "a\u0301",
+	'è': "e\u0300",
+	'é': "e\u0301",
+	'í': "i\u0301",
+	'ñ': "n\u0303",
+	'ó': "o\u0301",
+	'ú': "u\u0301",
+	'が': "か\u3099",
+	'ぎ': "き\u3099",
+	'ぐ': "く\u3099",
+	'げ': "け\u3099",
+	'ご': "こ\u3099",
+	'ざ': "さ\u3099",
+	'じ': "し\u3099",
+	'ず': "す\u3099",
+	'ぜ': "せ\u3099",
+	'ぞ': "そ\u3099",
+	'だ': "た\u3099",
+	'づ': "つ\u3099",
+	'で': "て\u3099",
+	'ど': "と\u3099",
+	'ば': "は\u3099",
+	'ぱ': "は\u309a",
+	'び': "ひ\u3099",
+	'ぴ': "ひ\u309a",
+	'ぶ': "ふ\u3099",
+	'ぷ': "ふ\u309a",
+	'べ': "へ\u3099",
+	'ぺ': "へ\u309a",
+	'ぼ': "ほ\u3099",
+	'ぽ': "ほ\u309a",
+}
+
+// decomposeMnemonicWord decomposes the characters of word the way the word
+// lists are.
+func decomposeMnemonicWord(word string) string {
+	var b strings.Builder
+	for _, r := range word {
+		if decomposed, ok := mnemonicDecompositions[r]; ok {
+			b.WriteString(decomposed)
+			continue
+		}
+		const (
+			hangulBase    = 0xAC00
+			leadingBase   = 0x1100
+			vowelBase     = 0x1161
+			trailingBase  = 0x11A7
+			vowelCount    = 21
+			trailingCount = 28
+			syllables     = 11172
+		)
+		if s := int(r) - hangulBase; s >= 0 && s < syllables {
+			b.WriteRune(rune(leadingBase + s/(vowelCount*trailingCount)))
+			b.WriteRune(rune(vowelBase + s%(vowelCount*trailingCount)/trailingCount))
+			if t := s % trailingCount; t > 0 {
+				b.WriteRune(rune(trailingBase + t))
+			}
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
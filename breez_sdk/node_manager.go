@@ -0,0 +1,123 @@
+package breez_sdk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrNodeConnected is returned by NodeManager.Connect for a name that is
+// already connected.
+var ErrNodeConnected = fmt.Errorf("node already connected")
+
+// NodeManager runs several nodes in one process. Each node is identified by a
+// name and gets its own working directory below the manager's base directory,
+// and its own event listener.
+type NodeManager struct {
+	baseDir string
+	lock    sync.Mutex
+	nodes   map[string]*BlockingBreezServices
+}
+
+// NewNodeManager returns a NodeManager keeping the working directories of its
+// nodes in baseDir.
+func NewNodeManager(baseDir string) *NodeManager {
+	return &NodeManager{
+		baseDir: baseDir,
+		nodes:   map[string]*BlockingBreezServices{},
+	}
+}
+
+// WorkingDir returns the working directory of the node called name.
+func (m *NodeManager) WorkingDir(name string) string {
+	return filepath.Join(m.baseDir, name)
+}
+
+// Connect connects the node called name, overriding req.Config.WorkingDir with
+// its own directory. Names must be valid single path elements.
+func (m *NodeManager) Connect(name string, req ConnectRequest, listener EventListener) (*BlockingBreezServices, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return nil, fmt.Errorf("invalid node name %q", name)
+	}
+
+	m.lock.Lock()
+	if _, ok := m.nodes[name]; ok {
+		m.lock.Unlock()
+		return nil, ErrNodeConnected
+	}
+	// Reserve the name while connecting.
+	m.nodes[name] = nil
+	m.lock.Unlock()
+
+	service, err := m.connect(name, req, listener)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if err != nil {
+		delete(m.nodes, name)
+		return nil, err
+	}
+	m.nodes[name] = service
+	service.RegisterShutdownHook(func() {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		if m.nodes[name] == service {
+			delete(m.nodes, name)
+		}
+	})
+	return service, nil
+}
+
+func (m *NodeManager) connect(name string, req ConnectRequest, listener EventListener) (*BlockingBreezServices, error) {
+	workingDir := m.WorkingDir(name)
+	if err := os.MkdirAll(workingDir, 0o700); err != nil {
+		return nil, err
+	}
+	req.Config.WorkingDir = workingDir
+	return connect(req, listener)
+}
+
+// Node returns the connected node called name.
+func (m *NodeManager) Node(name string) (*BlockingBreezServices, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	service := m.nodes[name]
+	return service, service != nil
+}
+
+// Names returns the names of the connected nodes, sorted.
+func (m *NodeManager) Names() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	names := make([]string, 0, len(m.nodes))
+	for name, service := range m.nodes {
+		if service != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Close closes the node called name, see BlockingBreezServices.Close.
+func (m *NodeManager) Close(name string) error {
+	service, ok := m.Node(name)
+	if !ok {
+		return nil
+	}
+	return service.Close()
+}
+
+// CloseAll closes every node and returns the first error.
+func (m *NodeManager) CloseAll() error {
+	var first error
+	for _, name := range m.Names() {
+		if err := m.Close(name); err != nil && first == nil {
+			first = fmt.Errorf("closing %s: %w", name, err)
+		}
+	}
+	return first
+}
@@ -0,0 +1,39 @@
+package breez_sdk
+
+import "sync"
+
+// RustPanicError is returned by a method of Services when the library
+// panicked while handling the call. The library may be left in an
+// inconsistent state, so long-running processes should treat it as a fatal
+// condition for the service and reconnect.
+type RustPanicError struct {
+	Message string
+}
+
+func (err *RustPanicError) Error() string {
+	return "rust panic: " + err.Message
+}
+
+var (
+	rustPanicHookLock sync.RWMutex
+	rustPanicHook     func(*RustPanicError)
+)
+
+// SetRustPanicHook registers fn to be called with every panic of the library,
+// before the error is returned to the caller. Passing nil removes the hook.
+func SetRustPanicHook(fn func(*RustPanicError)) {
+	rustPanicHookLock.Lock()
+	rustPanicHook = fn
+	rustPanicHookLock.Unlock()
+}
+
+func newRustPanicError(message string) *RustPanicError {
+	err := &RustPanicError{Message: message}
+	rustPanicHookLock.RLock()
+	hook := rustPanicHook
+	rustPanicHookLock.RUnlock()
+	if hook != nil {
+		hook(err)
+	}
+	return err
+}
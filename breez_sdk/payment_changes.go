@@ -0,0 +1,120 @@
+package breez_sdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// ErrInvalidCursor is returned by ListPaymentsChanges for a cursor it did not
+// create.
+var ErrInvalidCursor = fmt.Errorf("invalid payments cursor")
+
+// PaymentChanges are the payments added or updated since a cursor.
+type PaymentChanges struct {
+	// Payments are the new and updated payments, oldest first, failed ones
+	// included.
+	Payments []Payment
+	// Cursor is passed to the next ListPaymentsChanges call.
+	Cursor string
+}
+
+// paymentsCursor is the decoded cursor. From is the payment time from which on
+// payments may still change: the time of the oldest pending payment, or of the
+// newest payment if none is pending. Seen holds the digests of the payments
+// listed from then on.
+type paymentsCursor struct {
+	From int64             `json:"from"`
+	Seen map[string]string `json:"seen"`
+}
+
+// ListPaymentsChanges returns the payments that were added or changed since
+// sinceCursor, with the cursor for the next call. An empty sinceCursor returns
+// the whole history. A payment counts as changed when any of its fields
+// differs, such as its status, fee or metadata.
+//
+// Only payments from the oldest one pending at the time of the cursor on are
+// compared, so metadata set on an older, settled payment is not reported.
+func (_self *BlockingBreezServices) ListPaymentsChanges(ctx context.Context, sinceCursor string) (PaymentChanges, error) {
+	cursor, err := decodePaymentsCursor(sinceCursor)
+	if err != nil {
+		return PaymentChanges{}, err
+	}
+
+	includeFailures := true
+	req := ListPaymentsRequest{IncludeFailures: &includeFailures}
+	if cursor.From > 0 {
+		req.FromTimestamp = &cursor.From
+	}
+	var payments []Payment
+	err = _self.ForEachPayment(ctx, req, func(payment Payment) error {
+		payments = append(payments, payment)
+		return nil
+	})
+	if err != nil {
+		return PaymentChanges{}, err
+	}
+
+	next := paymentsCursor{From: cursor.From, Seen: map[string]string{}}
+	if len(payments) > 0 {
+		// Payments are listed newest first.
+		next.From = payments[0].PaymentTime
+		for _, payment := range payments {
+			if payment.Status == PaymentStatusPending && payment.PaymentTime < next.From {
+				next.From = payment.PaymentTime
+			}
+		}
+	}
+
+	changes := PaymentChanges{Payments: []Payment{}}
+	for i := len(payments) - 1; i >= 0; i-- {
+		payment := payments[i]
+		digest, err := paymentDigest(payment)
+		if err != nil {
+			return PaymentChanges{}, err
+		}
+		if cursor.Seen[payment.Id] != digest {
+			changes.Payments = append(changes.Payments, payment)
+		}
+		if payment.PaymentTime >= next.From {
+			next.Seen[payment.Id] = digest
+		}
+	}
+	changes.Cursor, err = encodePaymentsCursor(next)
+	return changes, err
+}
+
+func paymentDigest(payment Payment) (string, error) {
+	encoded, err := json.Marshal(payment)
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256(encoded)
+	return hex.EncodeToString(digest[:8]), nil
+}
+
+func encodePaymentsCursor(cursor paymentsCursor) (string, error) {
+	encoded, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
+
+func decodePaymentsCursor(s string) (paymentsCursor, error) {
+	if s == "" {
+		return paymentsCursor{}, nil
+	}
+	encoded, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return paymentsCursor{}, ErrInvalidCursor
+	}
+	var cursor paymentsCursor
+	if err := json.Unmarshal(encoded, &cursor); err != nil {
+		return paymentsCursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
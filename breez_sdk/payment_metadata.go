@@ -0,0 +1,118 @@
+package breez_sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// PaymentMetadataErrors is returned by SetEachPaymentMetadata when some of the
+// updates failed. The other updates were applied.
+type PaymentMetadataErrors struct {
+	// Failed holds the error of every failed update, by payment hash.
+	Failed map[string]error
+}
+
+func (err *PaymentMetadataErrors) Error() string {
+	if len(err.Failed) == 0 {
+		return "setting payment metadata failed"
+	}
+	hashes := make([]string, 0, len(err.Failed))
+	for hash := range err.Failed {
+		hashes = append(hashes, hash)
+	}
+	sort.Strings(hashes)
+	return fmt.Sprintf("setting payment metadata failed for %d payments, first %s: %v", len(hashes), hashes[0], err.Failed[hashes[0]])
+}
+
+// SetEachPaymentMetadata sets the metadata of many payments, by payment hash,
+// with one SetPaymentMetadata call per payment. It is a convenience loop, not
+// a batch: the updates are neither atomic nor cheaper than calling
+// SetPaymentMetadata yourself. Every update is attempted, and the ones that
+// failed are reported in a *PaymentMetadataErrors.
+func (_self *BlockingBreezServices) SetEachPaymentMetadata(metadata map[string]string) error {
+	failed := map[string]error{}
+	for hash, value := range metadata {
+		if err := _self.Guarded().SetPaymentMetadata(hash, value); err != nil {
+			failed[hash] = err
+		}
+	}
+	if len(failed) > 0 {
+		return &PaymentMetadataErrors{Failed: failed}
+	}
+	return nil
+}
+
+// metadataLocks serializes the metadata updates the Go side makes to a
+// payment, by payment hash.
+type metadataLocks struct {
+	lock  sync.Mutex
+	locks map[string]*metadataLock
+}
+
+type metadataLock struct {
+	sync.Mutex
+	refs int
+}
+
+func (l *metadataLocks) acquire(paymentHash string) (release func()) {
+	l.lock.Lock()
+	if l.locks == nil {
+		l.locks = map[string]*metadataLock{}
+	}
+	held, ok := l.locks[paymentHash]
+	if !ok {
+		held = &metadataLock{}
+		l.locks[paymentHash] = held
+	}
+	held.refs++
+	l.lock.Unlock()
+
+	held.Lock()
+	return func() {
+		held.Unlock()
+		l.lock.Lock()
+		held.refs--
+		if held.refs == 0 {
+			delete(l.locks, paymentHash)
+		}
+		l.lock.Unlock()
+	}
+}
+
+// updatePaymentMetadata sets the metadata of the payment with the given hash
+// to what update returns for its current metadata, and returns the metadata
+// the payment is left with. update returns nil to keep the current metadata.
+// Updates of the same payment run one at a time, each on the metadata left by
+// the previous one, so that the subsystems sharing a payment's metadata do not
+// overwrite each other.
+func (_self *BlockingBreezServices) updatePaymentMetadata(paymentHash string, update func(current *string) (*string, error)) (*string, error) {
+	release := _self.state().metadata.acquire(paymentHash)
+	defer release()
+
+	payment, err := _self.Guarded().PaymentByHash(paymentHash)
+	if err != nil {
+		return nil, err
+	}
+	if payment == nil {
+		return nil, fmt.Errorf("payment %s not found", paymentHash)
+	}
+	metadata, err := update(payment.Metadata)
+	if err != nil || metadata == nil {
+		return payment.Metadata, err
+	}
+	if err := _self.Guarded().SetPaymentMetadata(paymentHash, *metadata); err != nil {
+		return payment.Metadata, err
+	}
+	return metadata, nil
+}
+
+func encodeMetadata(metadata map[string]json.RawMessage) (*string, error) {
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	s := string(encoded)
+	return &s, nil
+}
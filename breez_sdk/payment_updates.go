@@ -0,0 +1,226 @@
+package breez_sdk
+
+import (
+	"context"
+	"sync"
+)
+
+// SubscribePaymentUpdates delivers the states of the payment with the given
+// hash. The channel first receives the current state if the payment is
+// already known, then every change of its status, and is closed once the
+// payment is complete or failed, or the service is closed. Only the latest
+// state is kept for a slow reader. cancel stops the subscription and closes
+// the channel; it must be called if the payment may never reach a final state.
+func (_self *BlockingBreezServices) SubscribePaymentUpdates(paymentHash string) (<-chan Payment, context.CancelFunc) {
+	watch := &paymentWatch{
+		service:     _self,
+		paymentHash: paymentHash,
+		updates:     make(chan Payment, 1),
+		wake:        make(chan struct{}, 1),
+	}
+	watch.stop = _self.stopOnClose(watch.cancel)
+	go watch.run()
+
+	unsubscribe := _self.subscribeEvents(watch.onEvent)
+	watch.lock.Lock()
+	watch.unsubscribe = unsubscribe
+	closed := watch.closed
+	watch.lock.Unlock()
+	if closed {
+		unsubscribe()
+		return watch.updates, watch.stop
+	}
+
+	watch.refresh()
+	return watch.updates, watch.stop
+}
+
+// paymentWatch follows a payment for SubscribePaymentUpdates. Events that need
+// the stored payment wake its worker, which looks the payment up off the
+// event thread.
+type paymentWatch struct {
+	service     *BlockingBreezServices
+	paymentHash string
+	lock        sync.Mutex
+	updates     chan Payment
+	wake        chan struct{}
+	// failure is delivered by the worker if the failed payment is not stored.
+	failure     *Payment
+	delivered   bool
+	lastStatus  PaymentStatus
+	closed      bool
+	unsubscribe func()
+	stop        func()
+}
+
+func (w *paymentWatch) onEvent(e BreezEvent) {
+	switch e := e.(type) {
+	case BreezEventInvoicePaid:
+		if e.Details.PaymentHash != w.paymentHash {
+			return
+		}
+		if e.Details.Payment != nil {
+			w.deliver(*e.Details.Payment)
+		} else {
+			w.requestRefresh(nil)
+		}
+	case BreezEventPaymentSucceed:
+		if paymentHash(e.Details) == w.paymentHash {
+			w.deliver(e.Details)
+		}
+	case BreezEventPaymentFailed:
+		invoice := e.Details.Invoice
+		if invoice == nil || invoice.PaymentHash != w.paymentHash {
+			return
+		}
+		w.requestRefresh(&Payment{
+			Id:          invoice.PaymentHash,
+			PaymentType: PaymentTypeSent,
+			AmountMsat:  optionalUint64(invoice.AmountMsat),
+			Status:      PaymentStatusFailed,
+			Error:       &e.Details.Error,
+			Description: invoice.Description,
+			Details: PaymentDetailsLn{Data: LnPaymentDetails{
+				PaymentHash:       invoice.PaymentHash,
+				Bolt11:            invoice.Bolt11,
+				DestinationPubkey: invoice.PayeePubkey,
+			}},
+		})
+	case BreezEventSynced:
+		// Status changes of pending payments are only picked up on sync.
+		w.lock.Lock()
+		pending := !w.delivered || w.lastStatus == PaymentStatusPending
+		w.lock.Unlock()
+		if pending {
+			w.requestRefresh(nil)
+		}
+	}
+}
+
+// requestRefresh wakes the worker to deliver the stored state of the payment,
+// or failure if it is not stored.
+func (w *paymentWatch) requestRefresh(failure *Payment) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.closed {
+		return
+	}
+	if failure != nil {
+		w.failure = failure
+	}
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run serves refresh requests until the watch is closed.
+func (w *paymentWatch) run() {
+	for range w.wake {
+		w.lock.Lock()
+		failure := w.failure
+		w.failure = nil
+		w.lock.Unlock()
+
+		if !w.refresh() && failure != nil {
+			// The payment was never stored, report the failure as is.
+			w.deliver(*failure)
+		}
+	}
+}
+
+// refresh delivers the stored state of the payment, reporting whether it was
+// found.
+func (w *paymentWatch) refresh() bool {
+	payment, err := w.service.Guarded().PaymentByHash(w.paymentHash)
+	if err != nil || payment == nil {
+		return false
+	}
+	w.deliver(*payment)
+	return true
+}
+
+func (w *paymentWatch) deliver(payment Payment) {
+	w.lock.Lock()
+	if w.closed || (w.delivered && w.lastStatus == payment.Status) {
+		w.lock.Unlock()
+		return
+	}
+	w.delivered = true
+	w.lastStatus = payment.Status
+
+	// Replace a state the reader has not picked up yet.
+	select {
+	case <-w.updates:
+	default:
+	}
+	w.updates <- payment
+
+	final := payment.Status == PaymentStatusComplete || payment.Status == PaymentStatusFailed
+	if final {
+		w.closeLocked()
+	}
+	w.lock.Unlock()
+
+	if final {
+		// Drop the shutdown hook of the finished watch.
+		w.stop()
+	}
+}
+
+func (w *paymentWatch) cancel() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if !w.closed {
+		w.closeLocked()
+	}
+}
+
+func (w *paymentWatch) closeLocked() {
+	w.closed = true
+	close(w.updates)
+	close(w.wake)
+	if w.unsubscribe != nil {
+		w.unsubscribe()
+	}
+}
+
+// paymentHash returns the hash of a lightning payment, which is also its id.
+func paymentHash(payment Payment) string {
+	if details, ok := payment.Details.(PaymentDetailsLn); ok {
+		return details.Data.PaymentHash
+	}
+	return payment.Id
+}
+
+func optionalUint64(value *uint64) uint64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+// WaitForPayment blocks until the payment with the given hash is complete or
+// failed, and returns it in that state, or until ctx is done or the service is
+// closed, returning ErrServiceClosed. A failed payment is returned without an
+// error; check its Status.
+func (_self *BlockingBreezServices) WaitForPayment(ctx context.Context, paymentHash string) (Payment, error) {
+	updates, cancel := _self.SubscribePaymentUpdates(paymentHash)
+	defer cancel()
+
+	var last Payment
+	for {
+		select {
+		case payment, ok := <-updates:
+			if !ok {
+				if last.Status != PaymentStatusComplete && last.Status != PaymentStatusFailed {
+					return Payment{}, ErrServiceClosed
+				}
+				return last, nil
+			}
+			last = payment
+		case <-ctx.Done():
+			return Payment{}, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package breez_sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// The number of payments PaymentsIterator requests per ListPayments call.
+const defaultPaymentsPageSize uint32 = 100
+
+// ErrStopIteration can be returned by a ForEachPayment callback to stop the
+// iteration without ForEachPayment returning an error.
+var ErrStopIteration = fmt.Errorf("stop iteration")
+
+// PaymentsIterator lazily pages through the payments matching a
+// ListPaymentsRequest. The request's Offset is where the iteration starts and
+// its Limit, if set, caps the total number of payments returned.
+type PaymentsIterator struct {
+	service   *BlockingBreezServices
+	req       ListPaymentsRequest
+	offset    uint32
+	remaining *uint32
+	page      []Payment
+	index     int
+	exhausted bool
+	current   Payment
+	err       error
+}
+
+// Payments returns an iterator over the payments matching req.
+func (_self *BlockingBreezServices) Payments(req ListPaymentsRequest) *PaymentsIterator {
+	it := &PaymentsIterator{
+		service: _self,
+		req:     req,
+	}
+	if req.Offset != nil {
+		it.offset = *req.Offset
+	}
+	if req.Limit != nil {
+		remaining := *req.Limit
+		it.remaining = &remaining
+	}
+	return it
+}
+
+// Next advances to the next payment, fetching the next page when the current
+// one is used up. It returns false when there are no more payments, ctx is done
+// or fetching failed; Err tells these apart.
+func (it *PaymentsIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if it.index >= len(it.page) {
+		if it.exhausted {
+			return false
+		}
+		if err := ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		if err := it.fetchPage(); err != nil {
+			it.err = err
+			return false
+		}
+		if len(it.page) == 0 {
+			return false
+		}
+	}
+	it.current = it.page[it.index]
+	it.index++
+	return true
+}
+
+// Payment returns the payment Next advanced to.
+func (it *PaymentsIterator) Payment() Payment {
+	return it.current
+}
+
+// Err returns the error that ended the iteration, if any.
+func (it *PaymentsIterator) Err() error {
+	return it.err
+}
+
+func (it *PaymentsIterator) fetchPage() error {
+	pageSize := defaultPaymentsPageSize
+	if it.remaining != nil && *it.remaining < pageSize {
+		pageSize = *it.remaining
+	}
+	if pageSize == 0 {
+		it.page, it.index, it.exhausted = nil, 0, true
+		return nil
+	}
+
+	req := it.req
+	offset := it.offset
+	req.Offset = &offset
+	req.Limit = &pageSize
+	page, err := it.service.Guarded().ListPayments(req)
+	if err != nil {
+		return err
+	}
+
+	it.page, it.index = page, 0
+	it.offset += uint32(len(page))
+	if it.remaining != nil {
+		*it.remaining -= uint32(len(page))
+	}
+	if uint32(len(page)) < pageSize {
+		it.exhausted = true
+	}
+	return nil
+}
+
+// ForEachPayment calls fn for every payment matching req, fetching them page by
+// page. Iteration stops at the first error returned by fn, which is returned
+// unless it is ErrStopIteration.
+func (_self *BlockingBreezServices) ForEachPayment(ctx context.Context, req ListPaymentsRequest, fn func(Payment) error) error {
+	it := _self.Payments(req)
+	for it.Next(ctx) {
+		if err := fn(it.Payment()); err != nil {
+			if errors.Is(err, ErrStopIteration) {
+				return nil
+			}
+			return err
+		}
+	}
+	return it.Err()
+}
+
+var paymentTypeNames = map[PaymentType]string{
+	PaymentTypeSent:          "sent",
+	PaymentTypeReceived:      "received",
+	PaymentTypeClosedChannel: "closed_channel",
+}
+
+func (t PaymentType) String() string {
+	if name, ok := paymentTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("PaymentType(%d)", uint(t))
+}
+
+var paymentStatusNames = map[PaymentStatus]string{
+	PaymentStatusPending:  "pending",
+	PaymentStatusComplete: "complete",
+	PaymentStatusFailed:   "failed",
+}
+
+func (s PaymentStatus) String() string {
+	if name, ok := paymentStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("PaymentStatus(%d)", uint(s))
+}
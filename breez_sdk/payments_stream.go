@@ -0,0 +1,121 @@
+package breez_sdk
+
+//go:generate sh -c "(echo '// Code generated from the preamble of breez_sdk.go by go generate; DO NOT EDIT.'; awk '/^\\*\\/$/ {exit} p {print} /^\\/\\*$/ {p=1}' breez_sdk.go) > breez_sdk.h"
+
+// #include "breez_sdk.h"
+import "C"
+
+import (
+	"bytes"
+	"io"
+	"runtime"
+	"unsafe"
+)
+
+// PaymentStream decodes the payments of a single ListPayments call one at a
+// time, straight from the buffer returned by the library, instead of lifting
+// them all into a slice. The buffer is released once the stream is exhausted
+// or closed.
+type PaymentStream struct {
+	buffer    rustBuffer
+	reader    *bytes.Reader
+	remaining int32
+	current   Payment
+	err       error
+	released  bool
+}
+
+// ListPaymentsStream is ListPayments returning a PaymentStream.
+func (_self *BlockingBreezServices) ListPaymentsStream(req ListPaymentsRequest) (*PaymentStream, error) {
+	_pointer := _self.ffiObject.incrementPointer("*BlockingBreezServices")
+	defer _self.ffiObject.decrementPointer()
+
+	_uniffiRV, _uniffiErr := rustCallWithError(FfiConverterTypeSdkError{}, func(_uniffiStatus *C.RustCallStatus) C.RustBuffer {
+		return C.breez_sdk_a35c_BlockingBreezServices_list_payments(
+			_pointer, FfiConverterTypeListPaymentsRequestINSTANCE.lower(req), _uniffiStatus)
+	})
+	if _uniffiErr != nil {
+		return nil, _uniffiErr
+	}
+
+	stream := &PaymentStream{buffer: fromCRustBuffer(_uniffiRV)}
+	stream.reader = bytes.NewReader(unsafe.Slice((*byte)(stream.buffer.data), stream.buffer.length))
+	runtime.SetFinalizer(stream, (*PaymentStream).Close)
+	if !stream.decode(func() { stream.remaining = readInt32(stream.reader) }) {
+		return nil, stream.err
+	}
+	if stream.remaining == 0 {
+		stream.finish()
+		if stream.err != nil {
+			return nil, stream.err
+		}
+	}
+	return stream, nil
+}
+
+// Next decodes the next payment. It returns false when the stream is exhausted,
+// closed or a payment could not be decoded, in which case Err is set.
+func (s *PaymentStream) Next() bool {
+	if s.released || s.remaining <= 0 {
+		return false
+	}
+	if !s.decode(func() { s.current = FfiConverterTypePaymentINSTANCE.read(s.reader) }) {
+		return false
+	}
+	s.remaining--
+	if s.remaining == 0 {
+		s.finish()
+	}
+	return true
+}
+
+// Payment returns the payment Next decoded.
+func (s *PaymentStream) Payment() Payment {
+	return s.current
+}
+
+// Err returns the decoding error that ended the stream, if any.
+func (s *PaymentStream) Err() error {
+	return s.err
+}
+
+// Len returns the number of payments not yet decoded.
+func (s *PaymentStream) Len() int {
+	return int(s.remaining)
+}
+
+// Close releases the buffer. It is safe to call more than once.
+func (s *PaymentStream) Close() {
+	if s.released {
+		return
+	}
+	s.released = true
+	s.reader = nil
+	runtime.SetFinalizer(s, nil)
+	s.buffer.free()
+}
+
+// decode runs read, turning a panic of the reader into the stream's error.
+func (s *PaymentStream) decode(read func()) bool {
+	if s.err = recoverRead(read); s.err != nil {
+		s.Close()
+		return false
+	}
+	return true
+}
+
+// recoverRead runs read, recovering the panics of the bindings like the
+// methods of Services, so that a failed read is a *DecodeError.
+func recoverRead(read func()) (err error) {
+	defer recoverCall(&err)
+	read()
+	return nil
+}
+
+func (s *PaymentStream) finish() {
+	if s.reader.Len() > 0 {
+		leftover, _ := io.ReadAll(s.reader)
+		s.err = &DecodeError{Leftover: leftover}
+	}
+	s.Close()
+}
@@ -0,0 +1,120 @@
+package breez_sdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RatesUpdate is delivered by RatesSubscription after every poll.
+type RatesUpdate struct {
+	// Rates are the last fetched rates of the subscribed currencies, by coin.
+	Rates map[string]float64
+	// FetchedAt is when Rates were fetched, zero if they never were.
+	FetchedAt time.Time
+	// Err is the error of the last poll, in which case Rates are the cached
+	// ones and Stale is set.
+	Err   error
+	Stale bool
+}
+
+// RatesSubscription polls FetchFiatRates every interval, starting right away,
+// and delivers the rates of currencies, or all rates if currencies is empty.
+// When a poll fails, the last fetched rates are delivered again marked stale.
+// Only the latest update is kept for a slow reader. The channel is closed once
+// ctx is done or the service is closed. interval must be positive.
+func (_self *BlockingBreezServices) RatesSubscription(ctx context.Context, interval time.Duration, currencies []string) (<-chan RatesUpdate, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid rates interval %v", interval)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	sub := &ratesSubscription{
+		fetch:      _self.Guarded().FetchFiatRates,
+		clock:      _self.Clock(),
+		interval:   interval,
+		currencies: currencies,
+		updates:    make(chan RatesUpdate, 1),
+	}
+	unregister := _self.RegisterShutdownHook(func() {
+		cancel()
+		sub.stop()
+	})
+
+	sub.lock.Lock()
+	sub.timer = sub.clock.AfterFunc(0, sub.poll)
+	sub.lock.Unlock()
+	go func() {
+		<-ctx.Done()
+		unregister()
+		sub.stop()
+	}()
+	return sub.updates, nil
+}
+
+type ratesSubscription struct {
+	fetch      func() ([]Rate, error)
+	clock      Clock
+	interval   time.Duration
+	currencies []string
+	lock       sync.Mutex
+	updates    chan RatesUpdate
+	cached     RatesUpdate
+	timer      Timer
+	stopped    bool
+}
+
+func (s *ratesSubscription) poll() {
+	// Holding the lock during the fetch makes stop, and so Close, wait for it.
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.stopped {
+		return
+	}
+	rates, err := s.fetch()
+	if err == nil {
+		s.cached = RatesUpdate{Rates: s.filter(rates), FetchedAt: s.clock.Now()}
+	}
+	update := s.cached
+	if err != nil {
+		update.Err = err
+		update.Stale = true
+	}
+
+	select {
+	case <-s.updates:
+	default:
+	}
+	s.updates <- update
+	s.timer = s.clock.AfterFunc(s.interval, s.poll)
+}
+
+func (s *ratesSubscription) filter(rates []Rate) map[string]float64 {
+	filtered := map[string]float64{}
+	for _, rate := range rates {
+		filtered[rate.Coin] = rate.Value
+	}
+	if len(s.currencies) == 0 {
+		return filtered
+	}
+	subscribed := map[string]float64{}
+	for _, coin := range s.currencies {
+		if value, ok := filtered[coin]; ok {
+			subscribed[coin] = value
+		}
+	}
+	return subscribed
+}
+
+func (s *ratesSubscription) stop() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.stopped {
+		return
+	}
+	s.stopped = true
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	close(s.updates)
+}
@@ -0,0 +1,60 @@
+package breez_sdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRatesSubscriptionPolls(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	rates := []Rate{{Coin: "USD", Value: 60_000}, {Coin: "EUR", Value: 55_000}}
+	var fetchErr error
+	sub := &ratesSubscription{
+		fetch:      func() ([]Rate, error) { return rates, fetchErr },
+		clock:      clock,
+		interval:   time.Minute,
+		currencies: []string{"USD"},
+		updates:    make(chan RatesUpdate, 1),
+	}
+	sub.timer = clock.AfterFunc(0, sub.poll)
+
+	clock.Advance(0)
+	update := <-sub.updates
+	if update.Stale || update.Rates["USD"] != 60_000 || len(update.Rates) != 1 || !update.FetchedAt.Equal(start) {
+		t.Fatalf("first update = %+v", update)
+	}
+
+	fetchErr = errors.New("unreachable")
+	clock.Advance(time.Minute)
+	update = <-sub.updates
+	if !update.Stale || update.Err == nil || update.Rates["USD"] != 60_000 || !update.FetchedAt.Equal(start) {
+		t.Fatalf("failed poll update = %+v", update)
+	}
+
+	// A slow reader only gets the latest update.
+	fetchErr = nil
+	rates = []Rate{{Coin: "USD", Value: 61_000}}
+	clock.Advance(time.Minute)
+	rates = []Rate{{Coin: "USD", Value: 62_000}}
+	clock.Advance(time.Minute)
+	update = <-sub.updates
+	if update.Stale || update.Rates["USD"] != 62_000 || !update.FetchedAt.Equal(start.Add(3*time.Minute)) {
+		t.Fatalf("latest update = %+v", update)
+	}
+
+	sub.stop()
+	clock.Advance(time.Hour)
+	if _, ok := <-sub.updates; ok {
+		t.Error("update after stop")
+	}
+}
+
+func TestRatesSubscriptionRejectsInterval(t *testing.T) {
+	service := newTestService(NewManualClock(time.Time{}))
+	if _, err := service.RatesSubscription(context.Background(), 0, nil); err == nil {
+		t.Error("zero interval accepted")
+	}
+}
@@ -0,0 +1,57 @@
+package breez_sdk
+
+import "fmt"
+
+// ReceivePaymentOptions control the Go-side checks ReceivePaymentWithOptions
+// runs around ReceivePayment.
+type ReceivePaymentOptions struct {
+	// AllowChannelOpen permits invoices that can only be paid by opening a new
+	// channel, which deducts an opening fee from the received amount.
+	AllowChannelOpen bool
+	// Metadata is kept in the local invoice store with the request and written
+	// to the payment's metadata once the invoice is paid, whether or not there
+	// are OnInvoiceSettled callbacks. It is lost if the service is closed
+	// before the invoice is paid.
+	Metadata *string
+}
+
+// ChannelOpenRequiredError is returned when an invoice was refused because
+// receiving it would open a channel.
+type ChannelOpenRequiredError struct {
+	AmountMsat        uint64
+	MaxReceivableMsat uint64
+}
+
+func (err *ChannelOpenRequiredError) Error() string {
+	return fmt.Sprintf("receiving %d msat requires a channel opening, at most %d msat can be received without one", err.AmountMsat, err.MaxReceivableMsat)
+}
+
+// ReceivePaymentWithOptions is ReceivePayment with the checks in opts applied.
+// Unless opts.AllowChannelOpen is set, an amount above what the existing
+// channels can receive is refused with a *ChannelOpenRequiredError before an
+// invoice is created.
+func (_self *BlockingBreezServices) ReceivePaymentWithOptions(req ReceivePaymentRequest, opts ReceivePaymentOptions) (ReceivePaymentResponse, error) {
+	if !opts.AllowChannelOpen && req.AmountMsat > 0 {
+		nodeState, err := _self.Guarded().NodeInfo()
+		if err != nil {
+			return ReceivePaymentResponse{}, err
+		}
+		if req.AmountMsat > nodeState.MaxReceivableSinglePaymentAmountMsat {
+			return ReceivePaymentResponse{}, &ChannelOpenRequiredError{
+				AmountMsat:        req.AmountMsat,
+				MaxReceivableMsat: nodeState.MaxReceivableSinglePaymentAmountMsat,
+			}
+		}
+	}
+
+	res, err := _self.Guarded().ReceivePayment(req)
+	if err != nil {
+		return res, err
+	}
+	// The liquidity may have changed between the check and the invoice creation.
+	if !opts.AllowChannelOpen && res.OpeningFeeParams != nil {
+		return ReceivePaymentResponse{}, &ChannelOpenRequiredError{AmountMsat: req.AmountMsat}
+	}
+	_self.storeInvoice(req, res, opts.Metadata)
+	return res, nil
+}
@@ -0,0 +1,63 @@
+package breez_sdk
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// receiveBatchConcurrency is the number of invoices ReceivePayments creates at
+// once.
+const receiveBatchConcurrency = 4
+
+// ReceivePaymentBatchError is returned by ReceivePayments when some of the
+// invoices could not be created. The others were.
+type ReceivePaymentBatchError struct {
+	// Failed holds the error of every failed request, by its index in the
+	// batch.
+	Failed map[int]error
+}
+
+func (err *ReceivePaymentBatchError) Error() string {
+	indexes := make([]int, 0, len(err.Failed))
+	for index := range err.Failed {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	return fmt.Sprintf("creating invoices failed for %d requests, first #%d: %v", len(indexes), indexes[0], err.Failed[indexes[0]])
+}
+
+// ReceivePayments creates an invoice for every request of batch, such as a
+// stock of fixed-amount invoices for a point of sale. The library creates
+// invoices one call at a time, so they are created a few at once rather than
+// in a single call, and the batch is not atomic: the responses are returned
+// at the index of their request, zero for the failed ones, which are reported
+// in a *ReceivePaymentBatchError returned alongside.
+func (_self *BlockingBreezServices) ReceivePayments(batch []ReceivePaymentRequest) ([]ReceivePaymentResponse, error) {
+	responses := make([]ReceivePaymentResponse, len(batch))
+	errs := make([]error, len(batch))
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, receiveBatchConcurrency)
+	for i, req := range batch {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, req ReceivePaymentRequest) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			responses[i], errs[i] = _self.Guarded().ReceivePayment(req)
+		}(i, req)
+	}
+	wg.Wait()
+
+	failed := map[int]error{}
+	for i, err := range errs {
+		if err != nil {
+			failed[i] = err
+		}
+	}
+	if len(failed) > 0 {
+		return responses, &ReceivePaymentBatchError{Failed: failed}
+	}
+	return responses, nil
+}
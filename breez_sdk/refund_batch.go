@@ -0,0 +1,89 @@
+package breez_sdk
+
+import (
+	"fmt"
+	"sort"
+)
+
+// BatchRefundRequest refunds every refundable swap to one address.
+type BatchRefundRequest struct {
+	ToAddress string
+	// SatPerVbyte is the fee rate of the refunds, the recommended hour fee if
+	// zero.
+	SatPerVbyte uint32
+}
+
+// BatchRefundResponse holds the refund transaction ids, by swap address.
+type BatchRefundResponse struct {
+	RefundTxIds map[string]string
+	// TotalFeeSat is the sum of the fees of the refund transactions, as
+	// estimated by PrepareRefund.
+	TotalFeeSat uint64
+}
+
+// BatchRefundError is returned by RefundAll when some of the refunds failed.
+// The other swaps were refunded.
+type BatchRefundError struct {
+	// Failed holds the error of every failed refund, by swap address.
+	Failed map[string]error
+}
+
+func (err *BatchRefundError) Error() string {
+	addresses := make([]string, 0, len(err.Failed))
+	for address := range err.Failed {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	return fmt.Sprintf("refund failed for %d swaps, first %s: %v", len(addresses), addresses[0], err.Failed[addresses[0]])
+}
+
+// RefundAll refunds every swap returned by ListRefundables to req.ToAddress at
+// one fee rate. The library builds one refund transaction per swap, as each
+// spends a different swap script, so the refunds are not atomic: every swap
+// is attempted, the refunded ones are in the response and the failed ones in
+// a *BatchRefundError returned alongside it.
+func (_self *BlockingBreezServices) RefundAll(req BatchRefundRequest) (BatchRefundResponse, error) {
+	response := BatchRefundResponse{RefundTxIds: map[string]string{}}
+	swaps, err := _self.Guarded().ListRefundables()
+	if err != nil {
+		return response, err
+	}
+	if len(swaps) == 0 {
+		return response, nil
+	}
+	if req.SatPerVbyte == 0 {
+		fees, err := _self.Guarded().RecommendedFees()
+		if err != nil {
+			return response, err
+		}
+		req.SatPerVbyte = uint32(fees.HourFee)
+	}
+
+	failed := map[string]error{}
+	for _, swap := range swaps {
+		prepared, err := _self.Guarded().PrepareRefund(PrepareRefundRequest{
+			SwapAddress: swap.BitcoinAddress,
+			ToAddress:   req.ToAddress,
+			SatPerVbyte: req.SatPerVbyte,
+		})
+		if err != nil {
+			failed[swap.BitcoinAddress] = err
+			continue
+		}
+		refund, err := _self.Guarded().Refund(RefundRequest{
+			SwapAddress: swap.BitcoinAddress,
+			ToAddress:   req.ToAddress,
+			SatPerVbyte: req.SatPerVbyte,
+		})
+		if err != nil {
+			failed[swap.BitcoinAddress] = err
+			continue
+		}
+		response.RefundTxIds[swap.BitcoinAddress] = refund.RefundTxId
+		response.TotalFeeSat += prepared.RefundTxFeeSat
+	}
+	if len(failed) > 0 {
+		return response, &BatchRefundError{Failed: failed}
+	}
+	return response, nil
+}
@@ -0,0 +1,87 @@
+package breez_sdk
+
+import "fmt"
+
+var (
+	// ErrNotOwnInvoice is returned by ReissueInvoice for an invoice of another
+	// node.
+	ErrNotOwnInvoice = fmt.Errorf("invoice was not issued by this node")
+	// ErrInvoicePaid is returned by ReissueInvoice for an invoice that was
+	// already paid.
+	ErrInvoicePaid = fmt.Errorf("invoice already paid")
+)
+
+// ReissueInvoice creates a fresh invoice replacing oldBolt11, typically after
+// it expired, valid for newExpiry seconds. It keeps the amount and the
+// description, using the stored full description for description hash
+// invoices. If the old invoice was created by ReceivePaymentWithOptions on
+// this service, it also keeps its preimage, CLTV delta and metadata.
+// Otherwise the library picks a new preimage. Opening fee params are never
+// carried over. They are fetched anew in case the old ones expired.
+func (_self *BlockingBreezServices) ReissueInvoice(oldBolt11 string, newExpiry uint32) (ReceivePaymentResponse, error) {
+	state := _self.state()
+	invoice, err := ParseInvoice(oldBolt11)
+	if err != nil {
+		return ReceivePaymentResponse{}, err
+	}
+	node, err := _self.Guarded().NodeInfo()
+	if err != nil {
+		return ReceivePaymentResponse{}, err
+	}
+	if invoice.PayeePubkey != node.Id {
+		return ReceivePaymentResponse{}, ErrNotOwnInvoice
+	}
+	payment, err := _self.Guarded().PaymentByHash(invoice.PaymentHash)
+	if err != nil {
+		return ReceivePaymentResponse{}, err
+	}
+	if payment != nil && payment.Status == PaymentStatusComplete {
+		return ReceivePaymentResponse{}, ErrInvoicePaid
+	}
+
+	state.lock.Lock()
+	stored, ok := state.invoices[invoice.PaymentHash]
+	state.lock.Unlock()
+
+	var req ReceivePaymentRequest
+	var metadata *string
+	if ok {
+		req = stored.request
+		metadata = stored.metadata
+	} else {
+		if invoice.AmountMsat != nil {
+			req.AmountMsat = *invoice.AmountMsat
+		}
+		if invoice.Description != nil {
+			req.Description = *invoice.Description
+		}
+	}
+	req.OpeningFeeParams = nil
+	req.Expiry = &newExpiry
+
+	var res ReceivePaymentResponse
+	if invoice.DescriptionHash != nil {
+		description, found, err := _self.FullDescription(*invoice.DescriptionHash)
+		if err != nil {
+			return ReceivePaymentResponse{}, err
+		}
+		if !found {
+			return ReceivePaymentResponse{}, fmt.Errorf("no description stored for hash %s", *invoice.DescriptionHash)
+		}
+		req.Description = description
+		res, err = _self.ReceivePaymentWithDescriptionHash(req)
+		if err != nil {
+			return ReceivePaymentResponse{}, err
+		}
+	} else {
+		req.UseDescriptionHash = nil
+		res, err = _self.Guarded().ReceivePayment(req)
+		if err != nil {
+			return ReceivePaymentResponse{}, err
+		}
+	}
+	if ok {
+		_self.storeInvoice(req, res, metadata)
+	}
+	return res, nil
+}
@@ -0,0 +1,116 @@
+package breez_sdk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// staticBackupFileVersion is the version of the file format written by
+// WriteStaticBackup.
+const staticBackupFileVersion = 1
+
+// ErrInvalidStaticBackup is wrapped by the errors of ReadStaticBackup.
+var ErrInvalidStaticBackup = fmt.Errorf("invalid static channel backup")
+
+// StaticBackupFile is a static channel backup as written by
+// WriteStaticBackup: the entries returned by StaticBackup, one per channel,
+// with a checksum detecting truncated or altered files.
+type StaticBackupFile struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Scb       []string  `json:"scb"`
+	// Sha256 is the hash of the entries, each followed by a newline.
+	Sha256 string `json:"sha256"`
+}
+
+func staticBackupChecksum(entries []string) string {
+	hash := sha256.New()
+	for _, entry := range entries {
+		io.WriteString(hash, entry)
+		io.WriteString(hash, "\n")
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// ExportStaticBackup reads the static channel backup of the node in workingDir
+// with StaticBackup and writes it to path, replacing the file atomically. It
+// returns the number of channels backed up. The file is dated by clock; pass
+// SystemClock unless testing.
+func ExportStaticBackup(clock Clock, workingDir string, path string) (int, error) {
+	res, err := StaticBackup(StaticBackupRequest{WorkingDir: workingDir})
+	if err != nil {
+		return 0, err
+	}
+	var entries []string
+	if res.Backup != nil {
+		entries = *res.Backup
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+	if err := WriteStaticBackup(clock, tmp, entries); err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+	return len(entries), os.Rename(tmp.Name(), path)
+}
+
+// WriteStaticBackup writes entries, as returned by StaticBackup, to w as a
+// StaticBackupFile dated by clock.
+func WriteStaticBackup(clock Clock, w io.Writer, entries []string) error {
+	if entries == nil {
+		entries = []string{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(StaticBackupFile{
+		Version:   staticBackupFileVersion,
+		CreatedAt: clock.Now().UTC(),
+		Scb:       entries,
+		Sha256:    staticBackupChecksum(entries),
+	})
+}
+
+// ReadStaticBackup reads a file written by WriteStaticBackup and verifies its
+// version, that every entry is hex encoded and its checksum.
+func ReadStaticBackup(r io.Reader) (StaticBackupFile, error) {
+	var file StaticBackupFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return StaticBackupFile{}, fmt.Errorf("%w: %v", ErrInvalidStaticBackup, err)
+	}
+	if file.Version != staticBackupFileVersion {
+		return StaticBackupFile{}, fmt.Errorf("%w: unsupported version %d", ErrInvalidStaticBackup, file.Version)
+	}
+	for i, entry := range file.Scb {
+		if _, err := hex.DecodeString(strings.TrimSpace(entry)); err != nil || entry == "" {
+			return StaticBackupFile{}, fmt.Errorf("%w: entry %d is not hex encoded", ErrInvalidStaticBackup, i)
+		}
+	}
+	if !strings.EqualFold(file.Sha256, staticBackupChecksum(file.Scb)) {
+		return StaticBackupFile{}, fmt.Errorf("%w: checksum mismatch", ErrInvalidStaticBackup)
+	}
+	return file, nil
+}
+
+// VerifyStaticBackupFile is ReadStaticBackup on the file at path.
+func VerifyStaticBackupFile(path string) (StaticBackupFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return StaticBackupFile{}, err
+	}
+	defer f.Close()
+	return ReadStaticBackup(f)
+}
@@ -0,0 +1,188 @@
+package breez_sdk
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Search fields of PaymentMatch, in decreasing weight.
+const (
+	SearchFieldDescription     = "description"
+	SearchFieldLnAddress       = "ln_address"
+	SearchFieldLabel           = "label"
+	SearchFieldLnurlPayComment = "lnurl_pay_comment"
+	SearchFieldLnurlMetadata   = "lnurl_metadata"
+	SearchFieldMetadata        = "metadata"
+)
+
+var searchFieldWeights = map[string]float64{
+	SearchFieldDescription:     3,
+	SearchFieldLnAddress:       3,
+	SearchFieldLabel:           2,
+	SearchFieldLnurlPayComment: 2,
+	SearchFieldLnurlMetadata:   1.5,
+	SearchFieldMetadata:        1,
+}
+
+// SearchOptions narrows SearchPayments.
+type SearchOptions struct {
+	// Request selects the payments searched; its Offset and Limit apply to
+	// the payments searched, not to the results.
+	Request ListPaymentsRequest
+	// Limit caps the number of results, if not zero.
+	Limit int
+}
+
+// PaymentMatch is a result of SearchPayments.
+type PaymentMatch struct {
+	Payment Payment
+	// Score ranks the match: higher is better.
+	Score float64
+	// Fields are the search fields any word of the query matched, by
+	// decreasing weight, see SearchFieldDescription.
+	Fields []string
+}
+
+// SearchPayments returns the payments matching every word of query, ignoring
+// case, best match first and newer first among equal scores. Words are matched
+// against the description, lightning address, label, LNURL-pay comment and
+// description, and the string values of the metadata JSON. A word scores the
+// weight of the best field it is found in, and half of it when it is only
+// part of a longer word in that field.
+//
+// The search lists the payments through the SDK on every call; for large
+// histories, mirror them into a database, for example with the paymentcache
+// package of the contrib module.
+func (_self *BlockingBreezServices) SearchPayments(ctx context.Context, query string, opts SearchOptions) ([]PaymentMatch, error) {
+	words := strings.Fields(strings.ToLower(query))
+	matches := []PaymentMatch{}
+	err := _self.ForEachPayment(ctx, opts.Request, func(payment Payment) error {
+		if match, ok := matchPayment(payment, words); ok {
+			matches = append(matches, match)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Payment.PaymentTime > matches[j].Payment.PaymentTime
+	})
+	if opts.Limit > 0 && len(matches) > opts.Limit {
+		matches = matches[:opts.Limit]
+	}
+	return matches, nil
+}
+
+func matchPayment(payment Payment, words []string) (PaymentMatch, bool) {
+	fields := searchFields(payment)
+	match := PaymentMatch{Payment: payment}
+	matched := map[string]bool{}
+	for _, word := range words {
+		best := 0.0
+		for field, text := range fields {
+			score := matchWord(strings.ToLower(text), word) * searchFieldWeights[field]
+			if score > 0 {
+				matched[field] = true
+			}
+			if score > best {
+				best = score
+			}
+		}
+		if best == 0 {
+			return PaymentMatch{}, false
+		}
+		match.Score += best
+	}
+	for field := range matched {
+		match.Fields = append(match.Fields, field)
+	}
+	sort.Slice(match.Fields, func(i, j int) bool {
+		wi, wj := searchFieldWeights[match.Fields[i]], searchFieldWeights[match.Fields[j]]
+		return wi > wj || (wi == wj && match.Fields[i] < match.Fields[j])
+	})
+	return match, true
+}
+
+// matchWord scores 1 for word appearing as a whole word in text, 0.5 as part
+// of a word and 0 otherwise.
+func matchWord(text, word string) float64 {
+	score := 0.0
+	for start := 0; ; {
+		i := strings.Index(text[start:], word)
+		if i < 0 {
+			return score
+		}
+		i += start
+		end := i + len(word)
+		if isWordBoundary(text, i-1) && isWordBoundary(text, end) {
+			return 1
+		}
+		score = 0.5
+		start = i + 1
+	}
+}
+
+func isWordBoundary(text string, i int) bool {
+	if i < 0 || i >= len(text) {
+		return true
+	}
+	r := rune(text[i])
+	return r < 0x80 && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+func searchFields(payment Payment) map[string]string {
+	fields := map[string]string{}
+	if payment.Description != nil {
+		fields[SearchFieldDescription] = *payment.Description
+	}
+	if payment.Metadata != nil {
+		var value interface{}
+		if err := json.Unmarshal([]byte(*payment.Metadata), &value); err == nil {
+			fields[SearchFieldMetadata] = strings.Join(jsonStrings(value, nil), "\n")
+		}
+	}
+	if details, ok := payment.Details.(PaymentDetailsLn); ok {
+		fields[SearchFieldLabel] = details.Data.Label
+		if details.Data.LnAddress != nil {
+			fields[SearchFieldLnAddress] = *details.Data.LnAddress
+		}
+		if details.Data.LnurlPayComment != nil {
+			fields[SearchFieldLnurlPayComment] = *details.Data.LnurlPayComment
+		}
+		if details.Data.LnurlMetadata != nil {
+			if metadata, err := parseLnUrlMetadata(*details.Data.LnurlMetadata); err == nil {
+				text := metadata.Description
+				if metadata.LongDescription != nil {
+					text += "\n" + *metadata.LongDescription
+				}
+				fields[SearchFieldLnurlMetadata] = text
+			}
+		}
+	}
+	return fields
+}
+
+// jsonStrings appends the string values in value to strs.
+func jsonStrings(value interface{}, strs []string) []string {
+	switch value := value.(type) {
+	case string:
+		strs = append(strs, value)
+	case []interface{}:
+		for _, item := range value {
+			strs = jsonStrings(item, strs)
+		}
+	case map[string]interface{}:
+		for _, item := range value {
+			strs = jsonStrings(item, strs)
+		}
+	}
+	return strs
+}
@@ -0,0 +1,168 @@
+package breez_sdk
+
+// #include "breez_sdk.h"
+import "C"
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"runtime"
+	"strings"
+	"unsafe"
+)
+
+// ErrUnsupportedPassphrase is returned by MnemonicToSeedWithPassphrase for a
+// passphrase that is not ASCII, which would need Unicode normalization.
+var ErrUnsupportedPassphrase = fmt.Errorf("passphrase must be ASCII")
+
+// SecureSeed holds a seed that is wiped with Zeroize once it is no longer
+// needed. Unlike a plain []uint8 it is never copied by the helpers taking it,
+// and it does not print its contents.
+type SecureSeed struct {
+	seed []byte
+}
+
+// NewSecureSeed takes ownership of seed, which is wiped by Zeroize.
+func NewSecureSeed(seed []byte) *SecureSeed {
+	return &SecureSeed{seed: seed}
+}
+
+// Bytes returns the seed without copying it. It is nil after Zeroize.
+func (s *SecureSeed) Bytes() []byte {
+	return s.seed
+}
+
+// Zeroize overwrites the seed with zeros and drops it.
+func (s *SecureSeed) Zeroize() {
+	wipe(s.seed)
+	s.seed = nil
+}
+
+func (s *SecureSeed) String() string {
+	return "SecureSeed(redacted)"
+}
+
+// GoString keeps %#v from printing the seed.
+func (s *SecureSeed) GoString() string {
+	return s.String()
+}
+
+// ConnectWithSeed connects like ConnectService with req.Seed set to seed. The
+// seed is not copied before it is lowered, and the lowered request is wiped
+// once handed to the library; seed itself is left to the caller to Zeroize.
+func ConnectWithSeed(req ConnectRequest, seed *SecureSeed, listener EventListener) (*BlockingBreezServices, error) {
+	req.Seed = seed.Bytes()
+	return connect(req, listener)
+}
+
+// connectWiped is the generated Connect, except that the lowered request,
+// which holds the seed, is wiped from Go memory after it is copied to C.
+func connectWiped(req ConnectRequest, listener EventListener) (*BlockingBreezServices, error) {
+	lowered := lowerWiped[ConnectRequest](FfiConverterTypeConnectRequestINSTANCE, req)
+	pointer, err := rustCallWithError(FfiConverterTypeConnectError{}, func(status *C.RustCallStatus) unsafe.Pointer {
+		return C.breez_sdk_a35c_connect(lowered, FfiConverterTypeEventListenerINSTANCE.lower(listener), status)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return FfiConverterBlockingBreezServicesINSTANCE.lift(pointer), nil
+}
+
+// lowerWiped lowers value like lowerIntoRustBuffer, writing it into a buffer
+// of its exact size so that it is not left behind by the buffer growing, and
+// wiping that buffer once copied to C.
+func lowerWiped[GoType any](writer bufWriter[GoType], value GoType) C.RustBuffer {
+	var size byteCounter
+	writer.write(&size, value)
+	buffer := bytes.NewBuffer(make([]byte, 0, int(size)))
+	writer.write(buffer, value)
+
+	written := buffer.Bytes()
+	defer wipe(written)
+	return C.RustBuffer{
+		capacity: C.int(len(written)),
+		len:      C.int(len(written)),
+		data:     (*C.uchar)(C.CBytes(written)),
+	}
+}
+
+// byteCounter is an io.Writer counting the bytes written to it.
+type byteCounter int
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
+
+// MnemonicToSecureSeed is MnemonicToSeedWithPassphrase returning a SecureSeed.
+func MnemonicToSecureSeed(phrase string, passphrase string) (*SecureSeed, error) {
+	seed, err := MnemonicToSeedWithPassphrase(phrase, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return NewSecureSeed(seed), nil
+}
+
+// MnemonicToSeedWithPassphrase derives the BIP-39 seed of a mnemonic in any of
+// the BIP-39 languages, protected by an optional passphrase. The mnemonic is
+// checked by ValidateMnemonic first, so failures are its typed errors. English
+// mnemonics without a passphrase are handed to MnemonicToSeed; the others are
+// derived on the Go side, where only ASCII passphrases are supported, see
+// ErrUnsupportedPassphrase.
+func MnemonicToSeedWithPassphrase(phrase string, passphrase string) ([]uint8, error) {
+	language, words, err := parseMnemonic(phrase)
+	if err != nil {
+		return nil, err
+	}
+	if language == MnemonicLanguageEnglish && passphrase == "" {
+		return MnemonicToSeed(phrase)
+	}
+	if !isASCII(passphrase) {
+		return nil, ErrUnsupportedPassphrase
+	}
+	return bip39Seed([]byte(strings.Join(words, " ")), []byte("mnemonic"+passphrase)), nil
+}
+
+// bip39Seed is PBKDF2-HMAC-SHA512 with 2048 iterations and a 64 byte key,
+// which is a single block of the hash.
+func bip39Seed(password []byte, salt []byte) []byte {
+	mac := hmac.New(sha512.New, password)
+	block := make([]byte, 4)
+	binary.BigEndian.PutUint32(block, 1)
+	mac.Write(salt)
+	mac.Write(block)
+	u := mac.Sum(nil)
+	seed := append([]byte(nil), u...)
+	for i := 1; i < 2048; i++ {
+		mac.Reset()
+		mac.Write(u)
+		u = mac.Sum(u[:0])
+		for j := range seed {
+			seed[j] ^= u[j]
+		}
+	}
+	wipe(u)
+	wipe(password)
+	wipe(salt)
+	return seed
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// wipe overwrites b with zeros.
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+	runtime.KeepAlive(b)
+}
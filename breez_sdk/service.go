@@ -0,0 +1,202 @@
+package breez_sdk
+
+import "sync"
+
+// BlockingBreezServicesInterface is the method set of BlockingBreezServices
+// generated from the library, for decorators and test doubles. It is kept
+// by hand, outside the generated bindings, and must follow their methods.
+type BlockingBreezServicesInterface interface {
+	Disconnect() error
+	ConfigureNode(req ConfigureNodeRequest) error
+	SendPayment(req SendPaymentRequest) (SendPaymentResponse, error)
+	SendSpontaneousPayment(req SendSpontaneousPaymentRequest) (SendPaymentResponse, error)
+	ReceivePayment(req ReceivePaymentRequest) (ReceivePaymentResponse, error)
+	PayLnurl(req LnUrlPayRequest) (LnUrlPayResult, error)
+	WithdrawLnurl(request LnUrlWithdrawRequest) (LnUrlWithdrawResult, error)
+	LnurlAuth(reqData LnUrlAuthRequestData) (LnUrlCallbackStatus, error)
+	ReportIssue(req ReportIssueRequest) error
+	NodeCredentials() (*NodeCredentials, error)
+	NodeInfo() (NodeState, error)
+	SignMessage(req SignMessageRequest) (SignMessageResponse, error)
+	CheckMessage(req CheckMessageRequest) (CheckMessageResponse, error)
+	BackupStatus() (BackupStatus, error)
+	Backup() error
+	ListPayments(req ListPaymentsRequest) ([]Payment, error)
+	PaymentByHash(hash string) (*Payment, error)
+	SetPaymentMetadata(hash string, metadata string) error
+	RedeemOnchainFunds(req RedeemOnchainFundsRequest) (RedeemOnchainFundsResponse, error)
+	FetchFiatRates() ([]Rate, error)
+	ListFiatCurrencies() ([]FiatCurrency, error)
+	ListLsps() ([]LspInformation, error)
+	ConnectLsp(lspId string) error
+	FetchLspInfo(lspId string) (*LspInformation, error)
+	OpenChannelFee(req OpenChannelFeeRequest) (OpenChannelFeeResponse, error)
+	LspId() (*string, error)
+	LspInfo() (LspInformation, error)
+	CloseLspChannels() error
+	RegisterWebhook(webhookUrl string) error
+	UnregisterWebhook(webhookUrl string) error
+	ReceiveOnchain(req ReceiveOnchainRequest) (SwapInfo, error)
+	InProgressSwap() (*SwapInfo, error)
+	RescanSwaps() error
+	RedeemSwap(swapAddress string) error
+	ListRefundables() ([]SwapInfo, error)
+	PrepareRefund(req PrepareRefundRequest) (PrepareRefundResponse, error)
+	Refund(req RefundRequest) (RefundResponse, error)
+	ListSwaps(req ListSwapsRequest) ([]SwapInfo, error)
+	FetchReverseSwapFees(req ReverseSwapFeesRequest) (ReverseSwapPairInfo, error)
+	OnchainPaymentLimits() (OnchainPaymentLimitsResponse, error)
+	PrepareOnchainPayment(req PrepareOnchainPaymentRequest) (PrepareOnchainPaymentResponse, error)
+	InProgressOnchainPayments() ([]ReverseSwapInfo, error)
+	ClaimReverseSwap(lockupAddress string) error
+	PayOnchain(req PayOnchainRequest) (PayOnchainResponse, error)
+	ExecuteDevCommand(command string) (string, error)
+	GenerateDiagnosticData() (string, error)
+	Sync() error
+	RecommendedFees() (RecommendedFees, error)
+	BuyBitcoin(req BuyBitcoinRequest) (BuyBitcoinResponse, error)
+	PrepareRedeemOnchainFunds(req PrepareRedeemOnchainFundsRequest) (PrepareRedeemOnchainFundsResponse, error)
+}
+
+var _ BlockingBreezServicesInterface = (*BlockingBreezServices)(nil)
+
+// serviceStates holds the serviceState of every BlockingBreezServices that has
+// one, keyed by the service. The bindings are generated, so the state is kept
+// here rather than in the struct. An entry keeps its service reachable until
+// Close or Shutdown releases it.
+var serviceStates sync.Map
+
+// state returns the Go-side state of the service, creating it on first use. A
+// service destroyed after its state was released reads as closed.
+func (_self *BlockingBreezServices) state() *serviceState {
+	if state, ok := serviceStates.Load(_self); ok {
+		return state.(*serviceState)
+	}
+	if _self.ffiObject.destroyed.Load() {
+		return &serviceState{closed: true}
+	}
+	state, _ := serviceStates.LoadOrStore(_self, &serviceState{})
+	return state.(*serviceState)
+}
+
+// release destroys the FFI object and drops the Go-side state of the service.
+func (_self *BlockingBreezServices) release() {
+	_self.Destroy()
+	serviceStates.Delete(_self)
+}
+
+// serviceState holds the Go-side state of a BlockingBreezServices instance.
+type serviceState struct {
+	lock          sync.Mutex
+	closed        bool
+	shutdownHooks []shutdownHook
+	nextHookId    uint64
+	events        *eventHub
+	settlement    *settlementTracker
+	clock         Clock
+	invoices      map[string]storedInvoice
+	settler       *invoiceSettler
+	metadata      metadataLocks
+	descriptions  DescriptionStore
+	statementDir  string
+	lease         *leaseKeeper
+}
+
+// shutdownHook is a hook registered with RegisterShutdownHook.
+type shutdownHook struct {
+	id uint64
+	fn func()
+}
+
+// RegisterShutdownHook registers fn to be run by Close. Hooks run in reverse
+// registration order, before the node is disconnected, so that subsystems
+// started on top of the service stop while it is still usable. A hook
+// registered after Close runs immediately. The returned function unregisters
+// fn, for subsystems stopped before Close.
+func (_self *BlockingBreezServices) RegisterShutdownHook(fn func()) (unregister func()) {
+	state := _self.state()
+	state.lock.Lock()
+	if state.closed {
+		state.lock.Unlock()
+		fn()
+		return func() {}
+	}
+	id := state.addShutdownHookLocked(fn)
+	state.lock.Unlock()
+
+	return func() {
+		state.lock.Lock()
+		defer state.lock.Unlock()
+		for i, hook := range state.shutdownHooks {
+			if hook.id == id {
+				state.shutdownHooks = append(state.shutdownHooks[:i], state.shutdownHooks[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (s *serviceState) addShutdownHookLocked(fn func()) uint64 {
+	s.nextHookId++
+	s.shutdownHooks = append(s.shutdownHooks, shutdownHook{id: s.nextHookId, fn: fn})
+	return s.nextHookId
+}
+
+// stopOnClose registers stop as a shutdown hook and returns a function that
+// unregisters it and runs stop. stop runs at most once either way.
+func (_self *BlockingBreezServices) stopOnClose(stop func()) func() {
+	var once sync.Once
+	run := func() { once.Do(stop) }
+	unregister := _self.RegisterShutdownHook(run)
+	return func() {
+		unregister()
+		run()
+	}
+}
+
+// Close runs the registered shutdown hooks, disconnects the node and releases
+// the underlying FFI object along with the Go-side state of the service. From
+// the start of Close the methods of Services return ErrServiceClosed. Calling
+// Close more than once is a no-op.
+func (_self *BlockingBreezServices) Close() error {
+	if !_self.runShutdownHooks() {
+		return nil
+	}
+	err := _self.disconnect()
+	_self.release()
+	return err
+}
+
+// disconnect disconnects the node after the service was marked closed.
+func (_self *BlockingBreezServices) disconnect() (err error) {
+	defer recoverCall(&err)
+	return _self.Disconnect()
+}
+
+// closed reports whether Close or Shutdown has started.
+func (_self *BlockingBreezServices) closed() bool {
+	state := _self.state()
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	return state.closed
+}
+
+// runShutdownHooks marks the service closed and runs its shutdown hooks. It
+// returns false if the service was already closed.
+func (_self *BlockingBreezServices) runShutdownHooks() bool {
+	state := _self.state()
+	state.lock.Lock()
+	if state.closed {
+		state.lock.Unlock()
+		return false
+	}
+	state.closed = true
+	hooks := state.shutdownHooks
+	state.shutdownHooks = nil
+	state.lock.Unlock()
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i].fn()
+	}
+	return true
+}
@@ -0,0 +1,214 @@
+package breez_sdk
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LatencyPercentiles summarizes a set of latency samples.
+type LatencyPercentiles struct {
+	Count int
+	Min   time.Duration
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+// DailySettlementStats holds the settlement latencies observed during one UTC day.
+type DailySettlementStats struct {
+	Day time.Time
+	// Time from invoice creation to the InvoicePaid event.
+	Receive LatencyPercentiles
+	// Time from the start of an outgoing payment to its PaymentSucceed event.
+	Send LatencyPercentiles
+}
+
+// TrackSettlementLatency starts recording settlement latencies from the event
+// stream, keeping the samples of the last retentionDays days. Latencies have
+// second precision, as that is the precision of invoice and payment times.
+// Calling it again only updates the retention.
+func (_self *BlockingBreezServices) TrackSettlementLatency(retentionDays int) {
+	state := _self.state()
+	if retentionDays < 1 {
+		retentionDays = 1
+	}
+
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	if state.settlement != nil {
+		state.settlement.setRetention(retentionDays)
+		return
+	}
+
+	clock := SystemClock
+	if state.clock != nil {
+		clock = state.clock
+	}
+	tracker := &settlementTracker{
+		clock:         clock,
+		retentionDays: retentionDays,
+		days:          map[time.Time]*settlementDay{},
+	}
+	state.settlement = tracker
+	unsubscribe := _self.subscribeEvents(tracker.onEvent)
+	state.addShutdownHookLocked(unsubscribe)
+}
+
+// SettlementStats returns the per day settlement latency statistics, oldest day
+// first. It is empty unless TrackSettlementLatency was called.
+func (_self *BlockingBreezServices) SettlementStats() []DailySettlementStats {
+	state := _self.state()
+	state.lock.Lock()
+	tracker := state.settlement
+	state.lock.Unlock()
+	if tracker == nil {
+		return nil
+	}
+	return tracker.stats()
+}
+
+type settlementDay struct {
+	receive []time.Duration
+	send    []time.Duration
+}
+
+type settlementTracker struct {
+	clock         Clock
+	lock          sync.Mutex
+	retentionDays int
+	days          map[time.Time]*settlementDay
+}
+
+func (t *settlementTracker) onEvent(e BreezEvent) {
+	now := t.clock.Now()
+	switch event := e.(type) {
+	case BreezEventInvoicePaid:
+		createdAt, err := bolt11Timestamp(event.Details.Bolt11)
+		if err != nil {
+			return
+		}
+		t.record(now, now.Sub(createdAt), false)
+	case BreezEventPaymentSucceed:
+		if event.Details.PaymentType != PaymentTypeSent {
+			return
+		}
+		t.record(now, now.Sub(time.Unix(event.Details.PaymentTime, 0)), true)
+	}
+}
+
+func (t *settlementTracker) record(at time.Time, latency time.Duration, send bool) {
+	if latency < 0 {
+		latency = 0
+	}
+	day := utcDay(at)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	samples, ok := t.days[day]
+	if !ok {
+		samples = &settlementDay{}
+		t.days[day] = samples
+		t.prune(day)
+	}
+	if send {
+		samples.send = append(samples.send, latency)
+	} else {
+		samples.receive = append(samples.receive, latency)
+	}
+}
+
+func (t *settlementTracker) setRetention(retentionDays int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.retentionDays = retentionDays
+	t.prune(utcDay(t.clock.Now()))
+}
+
+// prune drops the days that fell out of the retention window ending at today.
+func (t *settlementTracker) prune(today time.Time) {
+	oldest := today.AddDate(0, 0, -(t.retentionDays - 1))
+	for day := range t.days {
+		if day.Before(oldest) {
+			delete(t.days, day)
+		}
+	}
+}
+
+func (t *settlementTracker) stats() []DailySettlementStats {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	stats := make([]DailySettlementStats, 0, len(t.days))
+	for day, samples := range t.days {
+		stats = append(stats, DailySettlementStats{
+			Day:     day,
+			Receive: latencyPercentiles(samples.receive),
+			Send:    latencyPercentiles(samples.send),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Day.Before(stats[j].Day)
+	})
+	return stats
+}
+
+func latencyPercentiles(samples []time.Duration) LatencyPercentiles {
+	if len(samples) == 0 {
+		return LatencyPercentiles{}
+	}
+	sorted := make([]time.Duration, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	// Nearest-rank percentile.
+	rank := func(p int) time.Duration {
+		index := (p*len(sorted)+99)/100 - 1
+		if index < 0 {
+			index = 0
+		}
+		return sorted[index]
+	}
+	return LatencyPercentiles{
+		Count: len(sorted),
+		Min:   sorted[0],
+		P50:   rank(50),
+		P90:   rank(90),
+		P99:   rank(99),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+func utcDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// bech32Charset maps the characters of bech32 data to their 5-bit values.
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// bolt11Timestamp reads the creation time of an invoice the library already
+// validated, the 35-bit timestamp at the start of its data part. Unlike
+// ParseInvoice it does not call the library, so it can run on the event thread.
+func bolt11Timestamp(bolt11 string) (time.Time, error) {
+	bolt11 = strings.ToLower(bolt11)
+	separator := strings.LastIndexByte(bolt11, '1')
+	// The timestamp takes 7 characters and the checksum the last 6.
+	if separator < 0 || len(bolt11)-separator-1 < 7+6 {
+		return time.Time{}, fmt.Errorf("invalid invoice")
+	}
+	var timestamp int64
+	for _, c := range bolt11[separator+1 : separator+1+7] {
+		value := strings.IndexRune(bech32Charset, c)
+		if value < 0 {
+			return time.Time{}, fmt.Errorf("invalid invoice")
+		}
+		timestamp = timestamp<<5 | int64(value)
+	}
+	return time.Unix(timestamp, 0), nil
+}
@@ -0,0 +1,56 @@
+package breez_sdk
+
+import (
+	"testing"
+	"time"
+)
+
+// testBolt11 is the first example invoice of BOLT 11, created at 1496314658.
+const testBolt11 = "lnbc1pvjluezsp5zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zyg3zygspp5qqqsyqcyq5rqwzqfqqqsyqcyq5rqwzqfqqqsyqcyq5rqwzqfqypqdpl2pkx2ctnv5sxxmmwwd5kgetjypeh2ursdae8g6twvus8g6rfwvs8qun0dfjkxaq9qrsgq357wnc5r2ueh7ck6q93dj32dlqnls087fxdwk8qakdyafkq3yap9us6v52vjjsrvywa6rt52cm9r9zqt8r2t7mlcwspyetp5h2tztugp9lfyql"
+
+func TestBolt11Timestamp(t *testing.T) {
+	createdAt, err := bolt11Timestamp(testBolt11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if createdAt.Unix() != 1496314658 {
+		t.Errorf("timestamp = %d, want 1496314658", createdAt.Unix())
+	}
+	if _, err := bolt11Timestamp("lnbc1qqq"); err == nil {
+		t.Error("truncated invoice parsed")
+	}
+}
+
+func TestSettlementLatency(t *testing.T) {
+	created := time.Unix(1496314658, 0).UTC()
+	clock := NewManualClock(created.Add(90 * time.Second))
+	service := newTestService(clock)
+	service.TrackSettlementLatency(2)
+
+	service.state().events.OnEvent(BreezEventInvoicePaid{Details: InvoicePaidDetails{Bolt11: testBolt11}})
+	service.state().events.OnEvent(BreezEventPaymentSucceed{Details: Payment{
+		PaymentType: PaymentTypeSent,
+		PaymentTime: clock.Now().Add(-5 * time.Second).Unix(),
+	}})
+	service.state().events.OnEvent(BreezEventPaymentSucceed{Details: Payment{
+		PaymentType: PaymentTypeReceived,
+		PaymentTime: clock.Now().Unix(),
+	}})
+
+	stats := service.SettlementStats()
+	if len(stats) != 1 {
+		t.Fatalf("got %d days, want 1", len(stats))
+	}
+	if stats[0].Receive.Count != 1 || stats[0].Receive.P50 != 90*time.Second {
+		t.Errorf("receive = %+v", stats[0].Receive)
+	}
+	if stats[0].Send.Count != 1 || stats[0].Send.P50 != 5*time.Second {
+		t.Errorf("send = %+v", stats[0].Send)
+	}
+
+	clock.Advance(48 * time.Hour)
+	service.TrackSettlementLatency(2)
+	if stats := service.SettlementStats(); len(stats) != 0 {
+		t.Errorf("days kept past the retention: %+v", stats)
+	}
+}
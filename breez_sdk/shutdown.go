@@ -0,0 +1,109 @@
+package breez_sdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// shutdownPollInterval is how often Shutdown checks whether the node quiesced.
+const shutdownPollInterval = 100 * time.Millisecond
+
+// Shutdown closes the service gracefully. Like Close, it first runs the
+// shutdown hooks, stopping the Go-side subsystems started on the service.
+// From then on the methods of Services return ErrServiceClosed. It then waits
+// until no other call into the library is in flight and no outgoing payment is
+// pending, backs up the node if its last changes are not backed up yet,
+// disconnects it and releases the FFI object.
+//
+// If ctx is done before the node quiesced, Shutdown stops waiting, still backs
+// up and disconnects the node, and returns an error wrapping ctx.Err(). Calling
+// Shutdown or Close again is a no-op.
+func (_self *BlockingBreezServices) Shutdown(ctx context.Context) error {
+	return _self.shutdown(ctx, _self)
+}
+
+// shutdownNode is what Shutdown does on the node once the service is closed.
+// It is the service itself outside tests.
+type shutdownNode interface {
+	quiescent() (bool, error)
+	flushBackup() error
+	disconnect() error
+	release()
+}
+
+func (_self *BlockingBreezServices) shutdown(ctx context.Context, node shutdownNode) error {
+	if !_self.runShutdownHooks() {
+		return nil
+	}
+
+	drainErr := _self.waitQuiescent(ctx, node)
+	backupErr := node.flushBackup()
+	err := node.disconnect()
+	node.release()
+
+	switch {
+	case drainErr != nil:
+		return fmt.Errorf("shutdown: %w", drainErr)
+	case backupErr != nil:
+		return fmt.Errorf("shutdown: backup: %w", backupErr)
+	}
+	return err
+}
+
+// waitQuiescent polls until the node is quiescent or ctx is done.
+func (_self *BlockingBreezServices) waitQuiescent(ctx context.Context, node shutdownNode) error {
+	clock := _self.Clock()
+	for {
+		quiescent, err := node.quiescent()
+		if err != nil {
+			return err
+		}
+		if quiescent {
+			return nil
+		}
+		tick := make(chan struct{})
+		timer := clock.AfterFunc(shutdownPollInterval, func() { close(tick) })
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-tick:
+		}
+	}
+}
+
+// quiescent reports whether no call into the library is in flight and none of
+// the latest outgoing payments is pending.
+func (_self *BlockingBreezServices) quiescent() (_ bool, err error) {
+	defer recoverCall(&err)
+	if _self.ffiObject.callCounter.Load() > 0 {
+		return false, nil
+	}
+	limit := uint32(defaultPaymentsPageSize)
+	payments, err := _self.ListPayments(ListPaymentsRequest{
+		Filters: &[]PaymentTypeFilter{PaymentTypeFilterSent},
+		Limit:   &limit,
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, payment := range payments {
+		if payment.Status == PaymentStatusPending {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (_self *BlockingBreezServices) flushBackup() (err error) {
+	defer recoverCall(&err)
+	status, err := _self.BackupStatus()
+	if err != nil {
+		return err
+	}
+	if status.BackedUp {
+		return nil
+	}
+	return _self.Backup()
+}
@@ -0,0 +1,44 @@
+package breez_sdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// testShutdownNode stands in for the library steps of Shutdown.
+type testShutdownNode struct {
+	onQuiescent func()
+	released    bool
+}
+
+func (n *testShutdownNode) quiescent() (bool, error) {
+	n.onQuiescent()
+	return true, nil
+}
+
+func (n *testShutdownNode) flushBackup() error { return nil }
+func (n *testShutdownNode) disconnect() error  { return nil }
+func (n *testShutdownNode) release()           { n.released = true }
+
+func TestShutdownRefusesNewCalls(t *testing.T) {
+	service := newTestService(SystemClock)
+	services := service.Guarded()
+
+	var duringErr error
+	node := &testShutdownNode{onQuiescent: func() {
+		_, duringErr = services.NodeInfo()
+	}}
+	if err := service.shutdown(context.Background(), node); err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(duringErr, ErrServiceClosed) {
+		t.Errorf("call during Shutdown: err = %v, want ErrServiceClosed", duringErr)
+	}
+	if !node.released {
+		t.Error("Shutdown did not release the node")
+	}
+	if err := services.Sync(); !errors.Is(err, ErrServiceClosed) {
+		t.Errorf("call after Shutdown: err = %v, want ErrServiceClosed", err)
+	}
+}
@@ -0,0 +1,185 @@
+package breez_sdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// PeriodStatement summarizes the completed payments of an accounting period.
+// It is signed by the node, so any change to it is detected by
+// VerifyPeriodStatement.
+type PeriodStatement struct {
+	NodeId   string    `json:"node_id"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	ClosedAt time.Time `json:"closed_at"`
+
+	PaymentCount      int    `json:"payment_count"`
+	ReceivedMsat      uint64 `json:"received_msat"`
+	SentMsat          uint64 `json:"sent_msat"`
+	FeesMsat          uint64 `json:"fees_msat"`
+	ClosedChannelMsat uint64 `json:"closed_channel_msat"`
+	// PaymentsSha256 is the hash of the ids of the payments in the period, in
+	// the order ListPayments returns them.
+	PaymentsSha256 string `json:"payments_sha256"`
+
+	// The balances are the ones at ClosedAt; the node cannot report past
+	// balances.
+	ChannelsBalanceMsat uint64 `json:"channels_balance_msat"`
+	OnchainBalanceMsat  uint64 `json:"onchain_balance_msat"`
+
+	Signature string `json:"signature"`
+}
+
+// ErrPeriodClosed is returned by ClosePeriod when a statement for the period is
+// already stored.
+var ErrPeriodClosed = fmt.Errorf("period already closed")
+
+// message is the signed content of the statement: its JSON encoding without
+// the signature.
+func (s PeriodStatement) message() (string, error) {
+	s.Signature = ""
+	encoded, err := json.Marshal(s)
+	return string(encoded), err
+}
+
+// SetStatementDir sets the directory ClosePeriod stores statements in. Without
+// one, statements are only returned.
+func (_self *BlockingBreezServices) SetStatementDir(dir string) {
+	state := _self.state()
+	state.lock.Lock()
+	state.statementDir = dir
+	state.lock.Unlock()
+}
+
+// ClosePeriod summarizes the completed payments between start, inclusive, and
+// end, exclusive, and signs the statement with the node key. If a statement
+// directory is set, the statement is stored there and a period can only be
+// closed once.
+func (_self *BlockingBreezServices) ClosePeriod(ctx context.Context, start, end time.Time) (PeriodStatement, error) {
+	state := _self.state()
+	state.lock.Lock()
+	dir := state.statementDir
+	state.lock.Unlock()
+	var path string
+	if dir != "" {
+		path = filepath.Join(dir, fmt.Sprintf("statement-%d-%d.json", start.Unix(), end.Unix()))
+		if _, err := os.Stat(path); err == nil {
+			return PeriodStatement{}, ErrPeriodClosed
+		}
+	}
+
+	nodeState, err := _self.Guarded().NodeInfo()
+	if err != nil {
+		return PeriodStatement{}, err
+	}
+	statement := PeriodStatement{
+		NodeId:              nodeState.Id,
+		Start:               start.UTC(),
+		End:                 end.UTC(),
+		ClosedAt:            _self.Clock().Now().UTC(),
+		ChannelsBalanceMsat: nodeState.ChannelsBalanceMsat,
+		OnchainBalanceMsat:  nodeState.OnchainBalanceMsat,
+	}
+
+	from, to := start.Unix(), end.Unix()
+	hash := sha256.New()
+	err = _self.ForEachPayment(ctx, ListPaymentsRequest{FromTimestamp: &from, ToTimestamp: &to}, func(payment Payment) error {
+		if payment.Status != PaymentStatusComplete || payment.PaymentTime >= to {
+			return nil
+		}
+		statement.PaymentCount++
+		statement.FeesMsat += payment.FeeMsat
+		switch payment.PaymentType {
+		case PaymentTypeReceived:
+			statement.ReceivedMsat += payment.AmountMsat
+		case PaymentTypeSent:
+			statement.SentMsat += payment.AmountMsat
+		case PaymentTypeClosedChannel:
+			statement.ClosedChannelMsat += payment.AmountMsat
+		}
+		hash.Write([]byte(payment.Id))
+		hash.Write([]byte{'\n'})
+		return nil
+	})
+	if err != nil {
+		return PeriodStatement{}, err
+	}
+	statement.PaymentsSha256 = hex.EncodeToString(hash.Sum(nil))
+
+	message, err := statement.message()
+	if err != nil {
+		return PeriodStatement{}, err
+	}
+	signed, err := _self.Guarded().SignMessage(SignMessageRequest{Message: message})
+	if err != nil {
+		return PeriodStatement{}, err
+	}
+	statement.Signature = signed.Signature
+
+	if path != "" {
+		if err := writeStatement(path, statement); err != nil {
+			return PeriodStatement{}, err
+		}
+	}
+	return statement, nil
+}
+
+func writeStatement(path string, statement PeriodStatement) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	encoded, err := json.MarshalIndent(statement, "", "  ")
+	if err != nil {
+		return err
+	}
+	// O_EXCL keeps a concurrent close of the same period from overwriting it.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o400)
+	if errors.Is(err, os.ErrExist) {
+		return ErrPeriodClosed
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(encoded); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadPeriodStatement reads a statement stored by ClosePeriod.
+func LoadPeriodStatement(path string) (PeriodStatement, error) {
+	encoded, err := os.ReadFile(path)
+	if err != nil {
+		return PeriodStatement{}, err
+	}
+	var statement PeriodStatement
+	err = json.Unmarshal(encoded, &statement)
+	return statement, err
+}
+
+// VerifyPeriodStatement checks that statement is unchanged since it was signed
+// by the node in it.
+func (_self *BlockingBreezServices) VerifyPeriodStatement(statement PeriodStatement) (bool, error) {
+	message, err := statement.message()
+	if err != nil {
+		return false, err
+	}
+	res, err := _self.Guarded().CheckMessage(CheckMessageRequest{
+		Message:   message,
+		Pubkey:    statement.NodeId,
+		Signature: statement.Signature,
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.IsValid, nil
+}
@@ -0,0 +1,205 @@
+package breez_sdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// SwapStage is the progress of a swap, finer grained than its SwapStatus.
+type SwapStage int
+
+const (
+	// SwapStageWaitingDeposit: no transaction to the swap address seen yet.
+	SwapStageWaitingDeposit SwapStage = iota
+	// SwapStageMempool: a deposit is in the mempool.
+	SwapStageMempool
+	// SwapStageConfirmed: the deposit is confirmed, see
+	// SwapProgress.Confirmations, and waits for the swapper.
+	SwapStageConfirmed
+	// SwapStageRedeeming: the swap can be redeemed and the node is getting
+	// the funds paid over Lightning.
+	SwapStageRedeeming
+	// SwapStageRedeemed: the funds were received over Lightning.
+	SwapStageRedeemed
+	// SwapStageRefundable: the swap failed and the deposit can be refunded.
+	SwapStageRefundable
+	// SwapStageRefundBroadcast: a refund transaction was broadcast.
+	SwapStageRefundBroadcast
+)
+
+var swapStageNames = map[SwapStage]string{
+	SwapStageWaitingDeposit:  "waiting_deposit",
+	SwapStageMempool:         "mempool",
+	SwapStageConfirmed:       "confirmed",
+	SwapStageRedeeming:       "redeeming",
+	SwapStageRedeemed:        "redeemed",
+	SwapStageRefundable:      "refundable",
+	SwapStageRefundBroadcast: "refund_broadcast",
+}
+
+func (s SwapStage) String() string {
+	if name, ok := swapStageNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("SwapStage(%d)", int(s))
+}
+
+// SwapProgress is a state of a swap delivered by SubscribeSwap.
+type SwapProgress struct {
+	Stage SwapStage
+	// Confirmations of the deposit, 0 while it is unconfirmed.
+	Confirmations uint32
+	Swap          SwapInfo
+}
+
+// swapProgressOf derives the progress of swap at blockHeight.
+func swapProgressOf(swap SwapInfo, blockHeight uint32) SwapProgress {
+	progress := SwapProgress{Swap: swap}
+	if swap.ConfirmedAt != nil && blockHeight >= *swap.ConfirmedAt {
+		progress.Confirmations = blockHeight - *swap.ConfirmedAt + 1
+	}
+	switch {
+	case len(swap.RefundTxIds) > 0:
+		progress.Stage = SwapStageRefundBroadcast
+	case swap.PaidMsat > 0 || swap.Status == SwapStatusRedeemed || swap.Status == SwapStatusCompleted:
+		progress.Stage = SwapStageRedeemed
+	case swap.Status == SwapStatusRefundable:
+		progress.Stage = SwapStageRefundable
+	case swap.Status == SwapStatusRedeemable:
+		progress.Stage = SwapStageRedeeming
+	case len(swap.ConfirmedTxIds) > 0 || swap.ConfirmedSats > 0:
+		progress.Stage = SwapStageConfirmed
+	case len(swap.UnconfirmedTxIds) > 0 || swap.UnconfirmedSats > 0:
+		progress.Stage = SwapStageMempool
+	default:
+		progress.Stage = SwapStageWaitingDeposit
+	}
+	return progress
+}
+
+// SubscribeSwap delivers the progress of the swap to bitcoinAddress, as
+// returned by ReceiveOnchain. The channel first receives the current state,
+// then every change of stage or confirmation count, and is closed once the
+// swap is redeemed or its refund broadcast. Only the latest state is kept for
+// a slow reader. cancel stops the subscription and closes the channel.
+//
+// The stages are derived on the Go side from SwapUpdated and NewBlock events,
+// so a deposit seen in the mempool is only reported once the library updates
+// the swap.
+func (_self *BlockingBreezServices) SubscribeSwap(bitcoinAddress string) (<-chan SwapProgress, context.CancelFunc) {
+	watch := &swapWatch{
+		service: _self,
+		address: bitcoinAddress,
+		updates: make(chan SwapProgress, 1),
+	}
+	unsubscribe := _self.subscribeEvents(watch.onEvent)
+	watch.lock.Lock()
+	watch.unsubscribe = unsubscribe
+	closed := watch.closed
+	watch.lock.Unlock()
+	if closed {
+		unsubscribe()
+		return watch.updates, func() {}
+	}
+
+	if state, err := _self.Guarded().NodeInfo(); err == nil {
+		watch.setBlockHeight(state.BlockHeight)
+	}
+	watch.refresh()
+	return watch.updates, watch.cancel
+}
+
+type swapWatch struct {
+	service     *BlockingBreezServices
+	address     string
+	lock        sync.Mutex
+	updates     chan SwapProgress
+	blockHeight uint32
+	swap        *SwapInfo
+	delivered   bool
+	last        SwapProgress
+	closed      bool
+	unsubscribe func()
+}
+
+func (w *swapWatch) onEvent(e BreezEvent) {
+	switch e := e.(type) {
+	case BreezEventSwapUpdated:
+		if e.Details.BitcoinAddress == w.address {
+			w.deliver(e.Details)
+		}
+	case BreezEventNewBlock:
+		w.setBlockHeight(e.Block)
+		w.lock.Lock()
+		swap := w.swap
+		w.lock.Unlock()
+		if swap != nil {
+			w.deliver(*swap)
+		}
+	}
+}
+
+func (w *swapWatch) setBlockHeight(height uint32) {
+	w.lock.Lock()
+	if height > w.blockHeight {
+		w.blockHeight = height
+	}
+	w.lock.Unlock()
+}
+
+// refresh delivers the stored state of the swap.
+func (w *swapWatch) refresh() {
+	swaps, err := w.service.Guarded().ListSwaps(ListSwapsRequest{})
+	if err != nil {
+		return
+	}
+	for _, swap := range swaps {
+		if swap.BitcoinAddress == w.address {
+			w.deliver(swap)
+			return
+		}
+	}
+}
+
+func (w *swapWatch) deliver(swap SwapInfo) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.closed {
+		return
+	}
+	w.swap = &swap
+	progress := swapProgressOf(swap, w.blockHeight)
+	if w.delivered && progress.Stage == w.last.Stage && progress.Confirmations == w.last.Confirmations {
+		return
+	}
+	w.delivered = true
+	w.last = progress
+
+	// Replace a state the reader has not picked up yet.
+	select {
+	case <-w.updates:
+	default:
+	}
+	w.updates <- progress
+
+	if progress.Stage == SwapStageRedeemed || progress.Stage == SwapStageRefundBroadcast {
+		w.closeLocked()
+	}
+}
+
+func (w *swapWatch) cancel() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if !w.closed {
+		w.closeLocked()
+	}
+}
+
+func (w *swapWatch) closeLocked() {
+	w.closed = true
+	close(w.updates)
+	if w.unsubscribe != nil {
+		w.unsubscribe()
+	}
+}
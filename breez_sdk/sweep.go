@@ -0,0 +1,82 @@
+package breez_sdk
+
+import "fmt"
+
+// sweepInputVbytes is the size a P2WPKH input adds to a transaction, used to
+// tell which outputs cost more to spend than they are worth.
+const sweepInputVbytes = 68
+
+// SweepRequest sends all onchain funds of the node to one address.
+type SweepRequest struct {
+	ToAddress   string
+	SatPerVbyte uint32
+	// DustThresholdSat marks outputs below it as dust in the preview, on top
+	// of those worth less than the fee of spending them.
+	DustThresholdSat uint64
+}
+
+// SweepPreview is what a sweep will do, as returned by PrepareSweep.
+type SweepPreview struct {
+	// TotalSat is the value of the spendable outputs.
+	TotalSat uint64
+	TxFeeSat uint64
+	TxWeight uint64
+	// AmountSat is what arrives at the address: TotalSat minus TxFeeSat.
+	AmountSat uint64
+	// Dust are the outputs below the threshold or worth less than the fee of
+	// spending them. The library sweeps every output, so they are still
+	// spent; DustSat is their total value.
+	Dust    []UnspentTransactionOutput
+	DustSat uint64
+}
+
+// PrepareSweep previews sending all onchain funds, as RedeemOnchainFunds
+// does, reporting the exact amount that will arrive and the dust outputs.
+func (_self *BlockingBreezServices) PrepareSweep(req SweepRequest) (SweepPreview, error) {
+	state, err := _self.Guarded().NodeInfo()
+	if err != nil {
+		return SweepPreview{}, err
+	}
+	prepared, err := _self.Guarded().PrepareRedeemOnchainFunds(PrepareRedeemOnchainFundsRequest{
+		ToAddress:   req.ToAddress,
+		SatPerVbyte: req.SatPerVbyte,
+	})
+	if err != nil {
+		return SweepPreview{}, err
+	}
+
+	preview := SweepPreview{TxFeeSat: prepared.TxFeeSat, TxWeight: prepared.TxWeight}
+	inputFeeSat := uint64(req.SatPerVbyte) * sweepInputVbytes
+	for _, utxo := range state.Utxos {
+		if utxo.Reserved {
+			continue
+		}
+		valueSat := utxo.AmountMillisatoshi / 1000
+		preview.TotalSat += valueSat
+		if valueSat < req.DustThresholdSat || valueSat <= inputFeeSat {
+			preview.Dust = append(preview.Dust, utxo)
+			preview.DustSat += valueSat
+		}
+	}
+	if preview.TotalSat > preview.TxFeeSat {
+		preview.AmountSat = preview.TotalSat - preview.TxFeeSat
+	}
+	return preview, nil
+}
+
+// Sweep sends all onchain funds to req.ToAddress after checking with
+// PrepareSweep that something arrives, and returns the preview it acted on.
+func (_self *BlockingBreezServices) Sweep(req SweepRequest) (SweepPreview, RedeemOnchainFundsResponse, error) {
+	preview, err := _self.PrepareSweep(req)
+	if err != nil {
+		return preview, RedeemOnchainFundsResponse{}, err
+	}
+	if preview.AmountSat == 0 {
+		return preview, RedeemOnchainFundsResponse{}, fmt.Errorf("sweep: fee of %d sat exceeds the %d sat of onchain funds", preview.TxFeeSat, preview.TotalSat)
+	}
+	response, err := _self.Guarded().RedeemOnchainFunds(RedeemOnchainFundsRequest{
+		ToAddress:   req.ToAddress,
+		SatPerVbyte: req.SatPerVbyte,
+	})
+	return preview, response, err
+}
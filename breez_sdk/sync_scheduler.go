@@ -0,0 +1,215 @@
+package breez_sdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SyncReason is why a SyncScheduler synced.
+type SyncReason int
+
+const (
+	SyncReasonInterval SyncReason = iota
+	SyncReasonNetworkChange
+	SyncReasonResume
+	SyncReasonManual
+)
+
+var syncReasonNames = map[SyncReason]string{
+	SyncReasonInterval:      "interval",
+	SyncReasonNetworkChange: "network_change",
+	SyncReasonResume:        "resume",
+	SyncReasonManual:        "manual",
+}
+
+func (r SyncReason) String() string {
+	if name, ok := syncReasonNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("SyncReason(%d)", int(r))
+}
+
+// SyncPolicy configures a SyncScheduler.
+type SyncPolicy struct {
+	// Interval is the time from the end of one sync to the next scheduled
+	// one. Zero only syncs when triggered.
+	Interval time.Duration
+	// MinInterval is the least time between the end of a sync and a
+	// triggered one, which is deferred until it has passed.
+	MinInterval time.Duration
+	// SyncOnNetworkChange and SyncOnResume make NetworkChanged and Resumed
+	// trigger a sync.
+	SyncOnNetworkChange bool
+	SyncOnResume        bool
+}
+
+// SyncResult is reported by a SyncScheduler after every sync, whether it
+// completed or failed.
+type SyncResult struct {
+	Reason   SyncReason
+	Started  time.Time
+	Duration time.Duration
+	Err      error
+	// Changed are the payments added or updated by the sync, oldest first.
+	// They are not set when Err is.
+	Changed []Payment
+}
+
+// SyncScheduler calls Sync on a service according to a SyncPolicy.
+type SyncScheduler struct {
+	service  *BlockingBreezServices
+	policy   SyncPolicy
+	clock    Clock
+	fn       func(SyncResult)
+	lock     sync.Mutex
+	syncLock sync.Mutex
+	running  bool
+	closed   bool
+	timer    Timer
+	lastSync time.Time
+	cursor   string
+}
+
+// StartSyncScheduler starts syncing the node by policy, calling fn with the
+// result of every sync, and stops when the service is closed. Calls to fn
+// never overlap. Start it right after Connect, which syncs the node itself,
+// so the first scheduled sync is one Interval later.
+func (_self *BlockingBreezServices) StartSyncScheduler(policy SyncPolicy, fn func(SyncResult)) *SyncScheduler {
+	s := &SyncScheduler{
+		service: _self,
+		policy:  policy,
+		clock:   _self.Clock(),
+		fn:      fn,
+	}
+	s.Start()
+	_self.RegisterShutdownHook(func() {
+		s.lock.Lock()
+		s.closed = true
+		s.lock.Unlock()
+		s.Stop()
+	})
+	return s
+}
+
+// Start resumes scheduled syncs after Stop, the next one being one Interval
+// away. It is a no-op on a running scheduler or after the service is closed.
+func (s *SyncScheduler) Start() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.running || s.closed {
+		return
+	}
+	s.running = true
+	if s.policy.Interval > 0 {
+		s.scheduleLocked(s.policy.Interval, SyncReasonInterval)
+	}
+}
+
+// Stop cancels the pending sync and waits for a running one to complete. It
+// must not be called from fn.
+func (s *SyncScheduler) Stop() {
+	s.lock.Lock()
+	s.running = false
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	s.lock.Unlock()
+
+	s.syncLock.Lock()
+	s.syncLock.Unlock()
+}
+
+// NetworkChanged tells the scheduler that the device's network changed,
+// triggering a sync if the policy says so.
+func (s *SyncScheduler) NetworkChanged() {
+	if s.policy.SyncOnNetworkChange {
+		s.trigger(SyncReasonNetworkChange)
+	}
+}
+
+// Resumed tells the scheduler that the app returned to the foreground,
+// triggering a sync if the policy says so.
+func (s *SyncScheduler) Resumed() {
+	if s.policy.SyncOnResume {
+		s.trigger(SyncReasonResume)
+	}
+}
+
+// SyncNow triggers a sync, subject to MinInterval.
+func (s *SyncScheduler) SyncNow() {
+	s.trigger(SyncReasonManual)
+}
+
+func (s *SyncScheduler) trigger(reason SyncReason) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.running {
+		return
+	}
+	wait := time.Duration(0)
+	if !s.lastSync.IsZero() {
+		wait = s.policy.MinInterval - s.clock.Now().Sub(s.lastSync)
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	s.scheduleLocked(wait, reason)
+}
+
+func (s *SyncScheduler) scheduleLocked(d time.Duration, reason SyncReason) {
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.timer = s.clock.AfterFunc(d, func() { s.run(reason) })
+}
+
+func (s *SyncScheduler) run(reason SyncReason) {
+	s.syncLock.Lock()
+	s.lock.Lock()
+	if !s.running {
+		s.lock.Unlock()
+		s.syncLock.Unlock()
+		return
+	}
+	s.timer = nil
+	cursor := s.cursor
+	s.lock.Unlock()
+
+	ctx := context.Background()
+	result := SyncResult{Reason: reason, Started: s.clock.Now()}
+	if cursor == "" {
+		// The first sync only reports the payments it changed, not the history.
+		var baseline PaymentChanges
+		baseline, result.Err = s.service.ListPaymentsChanges(ctx, "")
+		cursor = baseline.Cursor
+	}
+	if result.Err == nil {
+		result.Err = s.service.Guarded().Sync()
+	}
+	if result.Err == nil {
+		var changes PaymentChanges
+		changes, result.Err = s.service.ListPaymentsChanges(ctx, cursor)
+		if result.Err == nil {
+			result.Changed = changes.Payments
+			cursor = changes.Cursor
+		}
+	}
+	end := s.clock.Now()
+	result.Duration = end.Sub(result.Started)
+
+	s.lock.Lock()
+	s.lastSync = end
+	s.cursor = cursor
+	if s.running && s.timer == nil && s.policy.Interval > 0 {
+		s.scheduleLocked(s.policy.Interval, SyncReasonInterval)
+	}
+	s.lock.Unlock()
+
+	if s.fn != nil {
+		s.fn(result)
+	}
+	s.syncLock.Unlock()
+}
@@ -0,0 +1,100 @@
+package breez_sdk
+
+import (
+	"encoding/json"
+	"unicode/utf8"
+)
+
+// TLV record types of keysend payments, for SendSpontaneousPaymentRequest.ExtraTlvs.
+const (
+	// TlvPodcastMetadata carries the JSON metadata of Podcasting 2.0 boosts
+	// and streamed payments, see PodcastMetadata.
+	TlvPodcastMetadata uint64 = 7629169
+	// TlvKeysendMessage carries a UTF-8 text message.
+	TlvKeysendMessage uint64 = 34349334
+)
+
+// Podcast actions of PodcastMetadata.
+const (
+	PodcastActionBoost  = "boost"
+	PodcastActionStream = "stream"
+	PodcastActionAuto   = "auto"
+)
+
+// PodcastMetadata is the Podcasting 2.0 record sent with keysend payments to
+// podcasts, as specified by bLIP-10. Apps usually set the podcast and episode,
+// the action, their name and the amounts.
+type PodcastMetadata struct {
+	Podcast          string `json:"podcast,omitempty"`
+	FeedId           int64  `json:"feedID,omitempty"`
+	Url              string `json:"url,omitempty"`
+	Guid             string `json:"guid,omitempty"`
+	Episode          string `json:"episode,omitempty"`
+	ItemId           int64  `json:"itemID,omitempty"`
+	EpisodeGuid      string `json:"episode_guid,omitempty"`
+	Timestamp        int64  `json:"ts,omitempty"`
+	Time             string `json:"time,omitempty"`
+	Action           string `json:"action,omitempty"`
+	AppName          string `json:"app_name,omitempty"`
+	AppVersion       string `json:"app_version,omitempty"`
+	ValueMsat        uint64 `json:"value_msat,omitempty"`
+	ValueMsatTotal   uint64 `json:"value_msat_total,omitempty"`
+	Name             string `json:"name,omitempty"`
+	SenderName       string `json:"sender_name,omitempty"`
+	SenderId         string `json:"sender_id,omitempty"`
+	Message          string `json:"message,omitempty"`
+	BoostLink        string `json:"boost_link,omitempty"`
+	ReplyAddress     string `json:"reply_address,omitempty"`
+	ReplyCustomKey   string `json:"reply_custom_key,omitempty"`
+	ReplyCustomValue string `json:"reply_custom_value,omitempty"`
+	RemoteFeedGuid   string `json:"remote_feed_guid,omitempty"`
+	RemoteItemGuid   string `json:"remote_item_guid,omitempty"`
+}
+
+// TlvEntry encodes the metadata as a TlvPodcastMetadata record.
+func (m PodcastMetadata) TlvEntry() (TlvEntry, error) {
+	value, err := json.Marshal(m)
+	if err != nil {
+		return TlvEntry{}, err
+	}
+	return TlvEntry{FieldNumber: TlvPodcastMetadata, Value: value}, nil
+}
+
+// KeysendMessageTlv encodes message as a TlvKeysendMessage record.
+func KeysendMessageTlv(message string) TlvEntry {
+	return TlvEntry{FieldNumber: TlvKeysendMessage, Value: []uint8(message)}
+}
+
+// FindTlv returns the value of the first record of type fieldNumber.
+func FindTlv(entries []TlvEntry, fieldNumber uint64) ([]uint8, bool) {
+	for _, entry := range entries {
+		if entry.FieldNumber == fieldNumber {
+			return entry.Value, true
+		}
+	}
+	return nil, false
+}
+
+// DecodePodcastMetadata decodes the TlvPodcastMetadata record of entries. It
+// returns nil without error if there is none. Unknown fields are ignored.
+func DecodePodcastMetadata(entries []TlvEntry) (*PodcastMetadata, error) {
+	value, ok := FindTlv(entries, TlvPodcastMetadata)
+	if !ok {
+		return nil, nil
+	}
+	var metadata PodcastMetadata
+	if err := json.Unmarshal(value, &metadata); err != nil {
+		return nil, err
+	}
+	return &metadata, nil
+}
+
+// DecodeKeysendMessage returns the TlvKeysendMessage record of entries, and
+// false if there is none or it is not valid UTF-8.
+func DecodeKeysendMessage(entries []TlvEntry) (string, bool) {
+	value, ok := FindTlv(entries, TlvKeysendMessage)
+	if !ok || !utf8.Valid(value) {
+		return "", false
+	}
+	return string(value), true
+}
@@ -0,0 +1,184 @@
+// Package breaker stops calling into the Breez SDK while the Breez services
+// are unreachable, so that callers fail fast instead of each waiting for the
+// library to time out:
+//
+//	svc := breaker.Wrap(sdk, breaker.Config{
+//		OnStateChange: func(change breaker.StateChange) {
+//			log.Printf("breez server %s", change.To)
+//		},
+//	})
+//
+// After FailureThreshold consecutive connectivity failures the circuit opens
+// and calls fail with ErrOpen. Once OpenTimeout has passed, one trial call is
+// let through: its success closes the circuit, its failure opens it again.
+// Disconnect always goes through.
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+)
+
+// ErrOpen is returned by calls made while the circuit is open.
+var ErrOpen = errors.New("breaker: circuit open")
+
+// State is the state of the circuit.
+type State int
+
+const (
+	// StateClosed lets every call through.
+	StateClosed State = iota
+	// StateOpen fails every call with ErrOpen.
+	StateOpen
+	// StateHalfOpen lets one trial call through.
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// StateChange is passed to Config.OnStateChange.
+type StateChange struct {
+	From, To State
+	At       time.Time
+	// Err is the failure that opened the circuit, nil for other changes.
+	Err error
+}
+
+// Config configures the breaker. Zero fields take their defaults.
+type Config struct {
+	// FailureThreshold is the number of consecutive failures opening the
+	// circuit, 5 by default.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before a trial call,
+	// 30 seconds by default.
+	OpenTimeout time.Duration
+	// IsFailure reports whether err counts as a failure of the Breez
+	// services, IsServiceConnectivity by default. Other errors count as
+	// successes.
+	IsFailure func(err error) bool
+	// OnStateChange is called after every change of state, outside of the
+	// breaker's lock.
+	OnStateChange func(StateChange)
+	// Clock defaults to breez_sdk.SystemClock.
+	Clock breez_sdk.Clock
+}
+
+// IsServiceConnectivity reports whether err is the ServiceConnectivity
+// variant of an SDK error.
+func IsServiceConnectivity(err error) bool {
+	variant := errors.Unwrap(err)
+	if variant == nil {
+		return false
+	}
+	return strings.HasSuffix(reflect.Indirect(reflect.ValueOf(variant)).Type().Name(), "ServiceConnectivity")
+}
+
+// Services implements breez_sdk.BlockingBreezServicesInterface on top of
+// another implementation, guarding it with a circuit breaker.
+type Services struct {
+	inner    breez_sdk.BlockingBreezServicesInterface
+	cfg      Config
+	lock     sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// Wrap returns inner guarded by a breaker configured by cfg.
+func Wrap(inner breez_sdk.BlockingBreezServicesInterface, cfg Config) *Services {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	if cfg.IsFailure == nil {
+		cfg.IsFailure = IsServiceConnectivity
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = breez_sdk.SystemClock
+	}
+	return &Services{inner: inner, cfg: cfg}
+}
+
+// Unwrap returns the wrapped service.
+func (s *Services) Unwrap() breez_sdk.BlockingBreezServicesInterface {
+	return s.inner
+}
+
+// State returns the current state of the circuit. An open circuit whose
+// timeout has passed only turns half-open with the next call.
+func (s *Services) State() State {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.state
+}
+
+func (s *Services) do(fn func() error) error {
+	var changes []StateChange
+	defer func() {
+		for _, change := range changes {
+			if s.cfg.OnStateChange != nil {
+				s.cfg.OnStateChange(change)
+			}
+		}
+	}()
+
+	s.lock.Lock()
+	now := s.cfg.Clock.Now()
+	if s.state == StateOpen && now.Sub(s.openedAt) >= s.cfg.OpenTimeout {
+		changes = append(changes, s.setLocked(StateHalfOpen, now, nil))
+	}
+	if s.state == StateOpen || (s.state == StateHalfOpen && s.trial) {
+		s.lock.Unlock()
+		return ErrOpen
+	}
+	trial := s.state == StateHalfOpen
+	s.trial = trial
+	s.lock.Unlock()
+
+	err := fn()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now = s.cfg.Clock.Now()
+	if trial {
+		s.trial = false
+	}
+	if err != nil && s.cfg.IsFailure(err) {
+		s.failures++
+		if trial || (s.state == StateClosed && s.failures >= s.cfg.FailureThreshold) {
+			s.openedAt = now
+			changes = append(changes, s.setLocked(StateOpen, now, err))
+		}
+		return err
+	}
+	s.failures = 0
+	if trial {
+		changes = append(changes, s.setLocked(StateClosed, now, nil))
+	}
+	return err
+}
+
+func (s *Services) setLocked(state State, at time.Time, err error) StateChange {
+	change := StateChange{From: s.state, To: state, At: at, Err: err}
+	s.state = state
+	return change
+}
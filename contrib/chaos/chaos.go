@@ -0,0 +1,127 @@
+// Package chaos wraps a Breez SDK service to inject latency, failures and
+// dropped events, so that retry and compensation logic can be exercised
+// without touching the network.
+//
+//	listener := chaos.WrapListener(myListener, cfg)
+//	sdk, err := breez_sdk.ConnectService(req, listener)
+//	...
+//	svc := chaos.Wrap(sdk, cfg)
+//
+// Injected failures are the ServiceConnectivity variant of the error type the
+// wrapped method returns, e.g. *breez_sdk.SendPaymentError for SendPayment, so
+// they are matched by breez_sdk.IsServiceConnectivityError.
+package chaos
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+)
+
+// Config describes the faults to inject. Rates are probabilities between 0
+// and 1; the zero Config injects nothing.
+type Config struct {
+	// MinLatency and MaxLatency bound the delay added before every call.
+	MinLatency time.Duration
+	MaxLatency time.Duration
+	// ErrorRates holds the failure rate of individual methods, keyed by method
+	// name such as "SendPayment". Other methods fail at DefaultErrorRate.
+	ErrorRates       map[string]float64
+	DefaultErrorRate float64
+	// EventDropRate is the rate at which WrapListener drops events.
+	EventDropRate float64
+	// Seed makes the injected faults reproducible. Zero uses the current time.
+	Seed int64
+}
+
+type source struct {
+	lock sync.Mutex
+	rand *rand.Rand
+}
+
+func newSource(seed int64) *source {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &source{rand: rand.New(rand.NewSource(seed))}
+}
+
+func (s *source) float64() float64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.rand.Float64()
+}
+
+func (s *source) int63n(n int64) int64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.rand.Int63n(n)
+}
+
+// Services implements breez_sdk.BlockingBreezServicesInterface on top of
+// another implementation, injecting the faults of its Config.
+type Services struct {
+	inner  breez_sdk.BlockingBreezServicesInterface
+	config Config
+	source *source
+}
+
+// Wrap returns inner decorated with the faults of config.
+func Wrap(inner breez_sdk.BlockingBreezServicesInterface, config Config) *Services {
+	return &Services{
+		inner:  inner,
+		config: config,
+		source: newSource(config.Seed),
+	}
+}
+
+// Unwrap returns the wrapped service.
+func (s *Services) Unwrap() breez_sdk.BlockingBreezServicesInterface {
+	return s.inner
+}
+
+// fail sleeps for the configured latency and then reports whether the call to
+// method should fail.
+func (s *Services) fail(method string) bool {
+	if latency := s.latency(); latency > 0 {
+		time.Sleep(latency)
+	}
+	rate, ok := s.config.ErrorRates[method]
+	if !ok {
+		rate = s.config.DefaultErrorRate
+	}
+	return rate > 0 && s.source.float64() < rate
+}
+
+func (s *Services) latency() time.Duration {
+	min, max := s.config.MinLatency, s.config.MaxLatency
+	if max <= min {
+		return min
+	}
+	return min + time.Duration(s.source.int63n(int64(max-min)))
+}
+
+type listener struct {
+	inner    breez_sdk.EventListener
+	dropRate float64
+	source   *source
+}
+
+// WrapListener returns an EventListener forwarding events to inner, dropping
+// them at config.EventDropRate.
+func WrapListener(inner breez_sdk.EventListener, config Config) breez_sdk.EventListener {
+	return &listener{
+		inner:    inner,
+		dropRate: config.EventDropRate,
+		source:   newSource(config.Seed),
+	}
+}
+
+func (l *listener) OnEvent(e breez_sdk.BreezEvent) {
+	if l.dropRate > 0 && l.source.float64() < l.dropRate {
+		return
+	}
+	l.inner.OnEvent(e)
+}
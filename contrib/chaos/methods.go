@@ -0,0 +1,355 @@
+package chaos
+
+import "github.com/breez/breez-sdk-go/breez_sdk"
+
+var _ breez_sdk.BlockingBreezServicesInterface = (*Services)(nil)
+
+func (s *Services) Disconnect() error {
+	if s.fail("Disconnect") {
+		return breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.Disconnect()
+}
+
+func (s *Services) ConfigureNode(req breez_sdk.ConfigureNodeRequest) error {
+	if s.fail("ConfigureNode") {
+		return breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.ConfigureNode(req)
+}
+
+func (s *Services) SendPayment(req breez_sdk.SendPaymentRequest) (breez_sdk.SendPaymentResponse, error) {
+	if s.fail("SendPayment") {
+		return breez_sdk.SendPaymentResponse{}, breez_sdk.NewSendPaymentErrorServiceConnectivity()
+	}
+	return s.inner.SendPayment(req)
+}
+
+func (s *Services) SendSpontaneousPayment(req breez_sdk.SendSpontaneousPaymentRequest) (breez_sdk.SendPaymentResponse, error) {
+	if s.fail("SendSpontaneousPayment") {
+		return breez_sdk.SendPaymentResponse{}, breez_sdk.NewSendPaymentErrorServiceConnectivity()
+	}
+	return s.inner.SendSpontaneousPayment(req)
+}
+
+func (s *Services) ReceivePayment(req breez_sdk.ReceivePaymentRequest) (breez_sdk.ReceivePaymentResponse, error) {
+	if s.fail("ReceivePayment") {
+		return breez_sdk.ReceivePaymentResponse{}, breez_sdk.NewReceivePaymentErrorServiceConnectivity()
+	}
+	return s.inner.ReceivePayment(req)
+}
+
+func (s *Services) PayLnurl(req breez_sdk.LnUrlPayRequest) (breez_sdk.LnUrlPayResult, error) {
+	if s.fail("PayLnurl") {
+		return nil, breez_sdk.NewLnUrlPayErrorServiceConnectivity()
+	}
+	return s.inner.PayLnurl(req)
+}
+
+func (s *Services) WithdrawLnurl(request breez_sdk.LnUrlWithdrawRequest) (breez_sdk.LnUrlWithdrawResult, error) {
+	if s.fail("WithdrawLnurl") {
+		return nil, breez_sdk.NewLnUrlWithdrawErrorServiceConnectivity()
+	}
+	return s.inner.WithdrawLnurl(request)
+}
+
+func (s *Services) LnurlAuth(reqData breez_sdk.LnUrlAuthRequestData) (breez_sdk.LnUrlCallbackStatus, error) {
+	if s.fail("LnurlAuth") {
+		return nil, breez_sdk.NewLnUrlAuthErrorServiceConnectivity()
+	}
+	return s.inner.LnurlAuth(reqData)
+}
+
+func (s *Services) ReportIssue(req breez_sdk.ReportIssueRequest) error {
+	if s.fail("ReportIssue") {
+		return breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.ReportIssue(req)
+}
+
+func (s *Services) NodeCredentials() (*breez_sdk.NodeCredentials, error) {
+	if s.fail("NodeCredentials") {
+		return nil, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.NodeCredentials()
+}
+
+func (s *Services) NodeInfo() (breez_sdk.NodeState, error) {
+	if s.fail("NodeInfo") {
+		return breez_sdk.NodeState{}, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.NodeInfo()
+}
+
+func (s *Services) SignMessage(req breez_sdk.SignMessageRequest) (breez_sdk.SignMessageResponse, error) {
+	if s.fail("SignMessage") {
+		return breez_sdk.SignMessageResponse{}, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.SignMessage(req)
+}
+
+func (s *Services) CheckMessage(req breez_sdk.CheckMessageRequest) (breez_sdk.CheckMessageResponse, error) {
+	if s.fail("CheckMessage") {
+		return breez_sdk.CheckMessageResponse{}, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.CheckMessage(req)
+}
+
+func (s *Services) BackupStatus() (breez_sdk.BackupStatus, error) {
+	if s.fail("BackupStatus") {
+		return breez_sdk.BackupStatus{}, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.BackupStatus()
+}
+
+func (s *Services) Backup() error {
+	if s.fail("Backup") {
+		return breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.Backup()
+}
+
+func (s *Services) ListPayments(req breez_sdk.ListPaymentsRequest) ([]breez_sdk.Payment, error) {
+	if s.fail("ListPayments") {
+		return nil, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.ListPayments(req)
+}
+
+func (s *Services) PaymentByHash(hash string) (*breez_sdk.Payment, error) {
+	if s.fail("PaymentByHash") {
+		return nil, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.PaymentByHash(hash)
+}
+
+func (s *Services) SetPaymentMetadata(hash string, metadata string) error {
+	if s.fail("SetPaymentMetadata") {
+		return breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.SetPaymentMetadata(hash, metadata)
+}
+
+func (s *Services) RedeemOnchainFunds(req breez_sdk.RedeemOnchainFundsRequest) (breez_sdk.RedeemOnchainFundsResponse, error) {
+	if s.fail("RedeemOnchainFunds") {
+		return breez_sdk.RedeemOnchainFundsResponse{}, breez_sdk.NewRedeemOnchainErrorServiceConnectivity()
+	}
+	return s.inner.RedeemOnchainFunds(req)
+}
+
+func (s *Services) FetchFiatRates() ([]breez_sdk.Rate, error) {
+	if s.fail("FetchFiatRates") {
+		return nil, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.FetchFiatRates()
+}
+
+func (s *Services) ListFiatCurrencies() ([]breez_sdk.FiatCurrency, error) {
+	if s.fail("ListFiatCurrencies") {
+		return nil, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.ListFiatCurrencies()
+}
+
+func (s *Services) ListLsps() ([]breez_sdk.LspInformation, error) {
+	if s.fail("ListLsps") {
+		return nil, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.ListLsps()
+}
+
+func (s *Services) ConnectLsp(lspId string) error {
+	if s.fail("ConnectLsp") {
+		return breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.ConnectLsp(lspId)
+}
+
+func (s *Services) FetchLspInfo(lspId string) (*breez_sdk.LspInformation, error) {
+	if s.fail("FetchLspInfo") {
+		return nil, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.FetchLspInfo(lspId)
+}
+
+func (s *Services) OpenChannelFee(req breez_sdk.OpenChannelFeeRequest) (breez_sdk.OpenChannelFeeResponse, error) {
+	if s.fail("OpenChannelFee") {
+		return breez_sdk.OpenChannelFeeResponse{}, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.OpenChannelFee(req)
+}
+
+func (s *Services) LspId() (*string, error) {
+	if s.fail("LspId") {
+		return nil, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.LspId()
+}
+
+func (s *Services) LspInfo() (breez_sdk.LspInformation, error) {
+	if s.fail("LspInfo") {
+		return breez_sdk.LspInformation{}, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.LspInfo()
+}
+
+func (s *Services) CloseLspChannels() error {
+	if s.fail("CloseLspChannels") {
+		return breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.CloseLspChannels()
+}
+
+func (s *Services) RegisterWebhook(webhookUrl string) error {
+	if s.fail("RegisterWebhook") {
+		return breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.RegisterWebhook(webhookUrl)
+}
+
+func (s *Services) UnregisterWebhook(webhookUrl string) error {
+	if s.fail("UnregisterWebhook") {
+		return breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.UnregisterWebhook(webhookUrl)
+}
+
+func (s *Services) ReceiveOnchain(req breez_sdk.ReceiveOnchainRequest) (breez_sdk.SwapInfo, error) {
+	if s.fail("ReceiveOnchain") {
+		return breez_sdk.SwapInfo{}, breez_sdk.NewReceiveOnchainErrorServiceConnectivity()
+	}
+	return s.inner.ReceiveOnchain(req)
+}
+
+func (s *Services) InProgressSwap() (*breez_sdk.SwapInfo, error) {
+	if s.fail("InProgressSwap") {
+		return nil, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.InProgressSwap()
+}
+
+func (s *Services) RescanSwaps() error {
+	if s.fail("RescanSwaps") {
+		return breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.RescanSwaps()
+}
+
+func (s *Services) RedeemSwap(swapAddress string) error {
+	if s.fail("RedeemSwap") {
+		return breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.RedeemSwap(swapAddress)
+}
+
+func (s *Services) ListRefundables() ([]breez_sdk.SwapInfo, error) {
+	if s.fail("ListRefundables") {
+		return nil, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.ListRefundables()
+}
+
+func (s *Services) PrepareRefund(req breez_sdk.PrepareRefundRequest) (breez_sdk.PrepareRefundResponse, error) {
+	if s.fail("PrepareRefund") {
+		return breez_sdk.PrepareRefundResponse{}, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.PrepareRefund(req)
+}
+
+func (s *Services) Refund(req breez_sdk.RefundRequest) (breez_sdk.RefundResponse, error) {
+	if s.fail("Refund") {
+		return breez_sdk.RefundResponse{}, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.Refund(req)
+}
+
+func (s *Services) ListSwaps(req breez_sdk.ListSwapsRequest) ([]breez_sdk.SwapInfo, error) {
+	if s.fail("ListSwaps") {
+		return nil, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.ListSwaps(req)
+}
+
+func (s *Services) FetchReverseSwapFees(req breez_sdk.ReverseSwapFeesRequest) (breez_sdk.ReverseSwapPairInfo, error) {
+	if s.fail("FetchReverseSwapFees") {
+		return breez_sdk.ReverseSwapPairInfo{}, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.FetchReverseSwapFees(req)
+}
+
+func (s *Services) OnchainPaymentLimits() (breez_sdk.OnchainPaymentLimitsResponse, error) {
+	if s.fail("OnchainPaymentLimits") {
+		return breez_sdk.OnchainPaymentLimitsResponse{}, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.OnchainPaymentLimits()
+}
+
+func (s *Services) PrepareOnchainPayment(req breez_sdk.PrepareOnchainPaymentRequest) (breez_sdk.PrepareOnchainPaymentResponse, error) {
+	if s.fail("PrepareOnchainPayment") {
+		return breez_sdk.PrepareOnchainPaymentResponse{}, breez_sdk.NewSendOnchainErrorServiceConnectivity()
+	}
+	return s.inner.PrepareOnchainPayment(req)
+}
+
+func (s *Services) InProgressOnchainPayments() ([]breez_sdk.ReverseSwapInfo, error) {
+	if s.fail("InProgressOnchainPayments") {
+		return nil, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.InProgressOnchainPayments()
+}
+
+func (s *Services) ClaimReverseSwap(lockupAddress string) error {
+	if s.fail("ClaimReverseSwap") {
+		return breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.ClaimReverseSwap(lockupAddress)
+}
+
+func (s *Services) PayOnchain(req breez_sdk.PayOnchainRequest) (breez_sdk.PayOnchainResponse, error) {
+	if s.fail("PayOnchain") {
+		return breez_sdk.PayOnchainResponse{}, breez_sdk.NewSendOnchainErrorServiceConnectivity()
+	}
+	return s.inner.PayOnchain(req)
+}
+
+func (s *Services) ExecuteDevCommand(command string) (string, error) {
+	if s.fail("ExecuteDevCommand") {
+		return "", breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.ExecuteDevCommand(command)
+}
+
+func (s *Services) GenerateDiagnosticData() (string, error) {
+	if s.fail("GenerateDiagnosticData") {
+		return "", breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.GenerateDiagnosticData()
+}
+
+func (s *Services) Sync() error {
+	if s.fail("Sync") {
+		return breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.Sync()
+}
+
+func (s *Services) RecommendedFees() (breez_sdk.RecommendedFees, error) {
+	if s.fail("RecommendedFees") {
+		return breez_sdk.RecommendedFees{}, breez_sdk.NewSdkErrorServiceConnectivity()
+	}
+	return s.inner.RecommendedFees()
+}
+
+func (s *Services) BuyBitcoin(req breez_sdk.BuyBitcoinRequest) (breez_sdk.BuyBitcoinResponse, error) {
+	if s.fail("BuyBitcoin") {
+		return breez_sdk.BuyBitcoinResponse{}, breez_sdk.NewReceiveOnchainErrorServiceConnectivity()
+	}
+	return s.inner.BuyBitcoin(req)
+}
+
+func (s *Services) PrepareRedeemOnchainFunds(req breez_sdk.PrepareRedeemOnchainFundsRequest) (breez_sdk.PrepareRedeemOnchainFundsResponse, error) {
+	if s.fail("PrepareRedeemOnchainFunds") {
+		return breez_sdk.PrepareRedeemOnchainFundsResponse{}, breez_sdk.NewRedeemOnchainErrorServiceConnectivity()
+	}
+	return s.inner.PrepareRedeemOnchainFunds(req)
+}
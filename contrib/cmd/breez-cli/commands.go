@@ -0,0 +1,223 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+)
+
+func init() {
+	commands["connect"] = command{usage: "store the mnemonic and connect the node (-mnemonic, -restore-only)"}
+	commands["node-info"] = command{usage: "show the node state", run: nodeInfo}
+	commands["sync"] = command{usage: "sync the node", run: sync}
+	commands["parse"] = command{usage: "parse an invoice, LNURL, address or other input", offline: true, run: parse}
+	commands["receive"] = command{usage: "create an invoice (-amount-sat, -description)", run: receive}
+	commands["send"] = command{usage: "pay a BOLT11 invoice (-amount-sat, -label)", run: send}
+	commands["lnurl-pay"] = command{usage: "pay an LNURL-pay request or lightning address (-amount-sat, -comment)", run: lnurlPay}
+	commands["list-payments"] = command{usage: "list payments (-type, -limit, -offset, -include-failures)", run: listPayments}
+	commands["payment"] = command{usage: "show the payment with the given hash", run: payment}
+	commands["receive-onchain"] = command{usage: "create a swap address to receive onchain funds", run: receiveOnchain}
+	commands["in-progress-swap"] = command{usage: "show the swap in progress", run: inProgressSwap}
+	commands["list-refundables"] = command{usage: "list the swaps that can be refunded", run: listRefundables}
+	commands["refund"] = command{usage: "refund a swap (-swap-address, -to-address, -sat-per-vbyte)", run: refund}
+	commands["lsp-info"] = command{usage: "show the current LSP", run: lspInfo}
+	commands["fiat-rates"] = command{usage: "show the fiat exchange rates", run: fiatRates}
+	commands["backup"] = command{usage: "back up the node", run: backup}
+	commands["diagnostics"] = command{usage: "print diagnostic data for support", run: diagnostics}
+}
+
+func nodeInfo(svc *breez_sdk.Services, flags *flag.FlagSet, args []string) (interface{}, error) {
+	flags.Parse(args)
+	return svc.NodeInfo()
+}
+
+func sync(svc *breez_sdk.Services, flags *flag.FlagSet, args []string) (interface{}, error) {
+	flags.Parse(args)
+	return nil, svc.Sync()
+}
+
+func parse(_ *breez_sdk.Services, flags *flag.FlagSet, args []string) (interface{}, error) {
+	flags.Parse(args)
+	input, err := singleArg(flags, "input")
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := breez_sdk.ParseInput(input)
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		Type  string
+		Input breez_sdk.InputType
+	}{strings.TrimPrefix(fmt.Sprintf("%T", parsed), "breez_sdk.InputType"), parsed}, nil
+}
+
+func receive(svc *breez_sdk.Services, flags *flag.FlagSet, args []string) (interface{}, error) {
+	amountSat := flags.Uint64("amount-sat", 0, "amount to receive")
+	description := flags.String("description", "", "invoice description")
+	flags.Parse(args)
+	return svc.ReceivePayment(breez_sdk.ReceivePaymentRequest{
+		AmountMsat:  *amountSat * 1000,
+		Description: *description,
+	})
+}
+
+func send(svc *breez_sdk.Services, flags *flag.FlagSet, args []string) (interface{}, error) {
+	amountSat := flags.Uint64("amount-sat", 0, "amount for invoices without one")
+	label := flags.String("label", "", "label of the payment")
+	flags.Parse(args)
+	bolt11, err := singleArg(flags, "invoice")
+	if err != nil {
+		return nil, err
+	}
+	req := breez_sdk.SendPaymentRequest{Bolt11: bolt11}
+	if *amountSat != 0 {
+		amountMsat := *amountSat * 1000
+		req.AmountMsat = &amountMsat
+	}
+	if *label != "" {
+		req.Label = label
+	}
+	return svc.SendPayment(req)
+}
+
+func lnurlPay(svc *breez_sdk.Services, flags *flag.FlagSet, args []string) (interface{}, error) {
+	amountSat := flags.Uint64("amount-sat", 0, "amount to pay")
+	comment := flags.String("comment", "", "comment for the recipient")
+	flags.Parse(args)
+	lnurl, err := singleArg(flags, "lnurl")
+	if err != nil {
+		return nil, err
+	}
+	input, err := breez_sdk.ParseInput(lnurl)
+	if err != nil {
+		return nil, err
+	}
+	payRequest, ok := input.(breez_sdk.InputTypeLnUrlPay)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an LNURL-pay request", lnurl)
+	}
+	req := breez_sdk.LnUrlPayRequest{Data: payRequest.Data, AmountMsat: *amountSat * 1000}
+	if *comment != "" {
+		req.Comment = comment
+	}
+	return svc.PayLnurl(req)
+}
+
+func listPayments(svc *breez_sdk.Services, flags *flag.FlagSet, args []string) (interface{}, error) {
+	paymentType := flags.String("type", "", "sent, received or closed-channel")
+	limit := flags.Uint("limit", 0, "maximum number of payments")
+	offset := flags.Uint("offset", 0, "number of payments to skip")
+	includeFailures := flags.Bool("include-failures", false, "include failed payments")
+	flags.Parse(args)
+
+	req := breez_sdk.ListPaymentsRequest{IncludeFailures: includeFailures}
+	switch *paymentType {
+	case "":
+	case "sent":
+		req.Filters = &[]breez_sdk.PaymentTypeFilter{breez_sdk.PaymentTypeFilterSent}
+	case "received":
+		req.Filters = &[]breez_sdk.PaymentTypeFilter{breez_sdk.PaymentTypeFilterReceived}
+	case "closed-channel":
+		req.Filters = &[]breez_sdk.PaymentTypeFilter{breez_sdk.PaymentTypeFilterClosedChannel}
+	default:
+		return nil, fmt.Errorf("unknown payment type %q", *paymentType)
+	}
+	if *limit != 0 {
+		value := uint32(*limit)
+		req.Limit = &value
+	}
+	if *offset != 0 {
+		value := uint32(*offset)
+		req.Offset = &value
+	}
+	return svc.ListPayments(req)
+}
+
+func payment(svc *breez_sdk.Services, flags *flag.FlagSet, args []string) (interface{}, error) {
+	flags.Parse(args)
+	hash, err := singleArg(flags, "payment hash")
+	if err != nil {
+		return nil, err
+	}
+	found, err := svc.PaymentByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no payment with hash %s", hash)
+	}
+	return found, nil
+}
+
+func receiveOnchain(svc *breez_sdk.Services, flags *flag.FlagSet, args []string) (interface{}, error) {
+	flags.Parse(args)
+	return svc.ReceiveOnchain(breez_sdk.ReceiveOnchainRequest{})
+}
+
+func inProgressSwap(svc *breez_sdk.Services, flags *flag.FlagSet, args []string) (interface{}, error) {
+	flags.Parse(args)
+	return svc.InProgressSwap()
+}
+
+func listRefundables(svc *breez_sdk.Services, flags *flag.FlagSet, args []string) (interface{}, error) {
+	flags.Parse(args)
+	return svc.ListRefundables()
+}
+
+func refund(svc *breez_sdk.Services, flags *flag.FlagSet, args []string) (interface{}, error) {
+	swapAddress := flags.String("swap-address", "", "address of the swap to refund")
+	toAddress := flags.String("to-address", "", "address receiving the refund")
+	satPerVbyte := flags.Uint("sat-per-vbyte", 0, "fee rate, the recommended one if zero")
+	flags.Parse(args)
+	if *swapAddress == "" || *toAddress == "" {
+		return nil, errors.New("refund: -swap-address and -to-address are required")
+	}
+	feeRate := uint32(*satPerVbyte)
+	if feeRate == 0 {
+		fees, err := svc.RecommendedFees()
+		if err != nil {
+			return nil, err
+		}
+		feeRate = uint32(fees.HourFee)
+	}
+	return svc.Refund(breez_sdk.RefundRequest{SwapAddress: *swapAddress, ToAddress: *toAddress, SatPerVbyte: feeRate})
+}
+
+func lspInfo(svc *breez_sdk.Services, flags *flag.FlagSet, args []string) (interface{}, error) {
+	flags.Parse(args)
+	return svc.LspInfo()
+}
+
+func fiatRates(svc *breez_sdk.Services, flags *flag.FlagSet, args []string) (interface{}, error) {
+	flags.Parse(args)
+	return svc.FetchFiatRates()
+}
+
+func backup(svc *breez_sdk.Services, flags *flag.FlagSet, args []string) (interface{}, error) {
+	flags.Parse(args)
+	if err := svc.Backup(); err != nil {
+		return nil, err
+	}
+	return svc.BackupStatus()
+}
+
+func diagnostics(svc *breez_sdk.Services, flags *flag.FlagSet, args []string) (interface{}, error) {
+	flags.Parse(args)
+	data, err := svc.GenerateDiagnosticData()
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println(data)
+	return nil, nil
+}
+
+func singleArg(flags *flag.FlagSet, name string) (string, error) {
+	if flags.NArg() != 1 {
+		return "", fmt.Errorf("%s: expected the %s as the only argument", flags.Name(), name)
+	}
+	return flags.Arg(0), nil
+}
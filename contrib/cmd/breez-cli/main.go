@@ -0,0 +1,168 @@
+// Command breez-cli runs a Breez SDK node for one command at a time and prints
+// the result as JSON. It is a debugging tool and a reference for using the
+// bindings.
+//
+//	breez-cli -api-key $BREEZ_API_KEY connect -mnemonic "abandon ..."
+//	breez-cli node-info
+//	breez-cli receive -amount-sat 1000 -description coffee
+//	breez-cli send lnbc...
+//
+// The mnemonic given to connect is stored in the data directory, and later
+// commands connect with it.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+)
+
+// phraseFile holds the mnemonic in the data directory.
+const phraseFile = "phrase"
+
+type globalOptions struct {
+	dataDir    string
+	apiKey     string
+	inviteCode string
+	staging    bool
+}
+
+type command struct {
+	usage string
+	// offline commands run without connecting.
+	offline bool
+	run     func(svc *breez_sdk.Services, flags *flag.FlagSet, args []string) (interface{}, error)
+}
+
+var commands = map[string]command{}
+
+func main() {
+	home, _ := os.UserHomeDir()
+	var opts globalOptions
+	flag.StringVar(&opts.dataDir, "data-dir", filepath.Join(home, ".breez-cli"), "directory of the node data")
+	flag.StringVar(&opts.apiKey, "api-key", os.Getenv("BREEZ_API_KEY"), "Breez API key, defaults to $BREEZ_API_KEY")
+	flag.StringVar(&opts.inviteCode, "invite-code", "", "Greenlight invite code for registering a new node")
+	flag.BoolVar(&opts.staging, "staging", false, "use the staging environment")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	if err := run(opts, flag.Arg(0), flag.Args()[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "breez-cli:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "usage: breez-cli [flags] <command> [command flags] [args]\n\nflags:\n")
+	flag.PrintDefaults()
+	fmt.Fprintf(flag.CommandLine.Output(), "\ncommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(flag.CommandLine.Output(), "  %-20s %s\n", name, commands[name].usage)
+	}
+}
+
+func run(opts globalOptions, name string, args []string) error {
+	cmd, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("unknown command %q", name)
+	}
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+
+	if name == "connect" {
+		return connect(opts, flags, args)
+	}
+	var svc *breez_sdk.Services
+	if !cmd.offline {
+		var err error
+		if svc, err = connectStored(opts, false); err != nil {
+			return err
+		}
+		defer svc.Close()
+	}
+	result, err := cmd.run(svc, flags, args)
+	if err != nil {
+		return err
+	}
+	return printJSON(result)
+}
+
+// connect stores the mnemonic and connects with it once, registering or
+// restoring the node.
+func connect(opts globalOptions, flags *flag.FlagSet, args []string) error {
+	mnemonic := flags.String("mnemonic", "", "BIP39 mnemonic of the node")
+	restoreOnly := flags.Bool("restore-only", false, "fail instead of registering a new node")
+	flags.Parse(args)
+	if *mnemonic == "" {
+		return errors.New("connect: -mnemonic is required")
+	}
+	if _, err := breez_sdk.MnemonicToSeed(*mnemonic); err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	if err := os.MkdirAll(opts.dataDir, 0700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(opts.dataDir, phraseFile), []byte(*mnemonic), 0600); err != nil {
+		return err
+	}
+
+	svc, err := connectStored(opts, *restoreOnly)
+	if err != nil {
+		return err
+	}
+	defer svc.Close()
+	info, err := svc.NodeInfo()
+	if err != nil {
+		return err
+	}
+	return printJSON(info)
+}
+
+func connectStored(opts globalOptions, restoreOnly bool) (*breez_sdk.Services, error) {
+	phrase, err := os.ReadFile(filepath.Join(opts.dataDir, phraseFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errors.New("no node yet, run connect first")
+	} else if err != nil {
+		return nil, err
+	}
+	seed, err := breez_sdk.MnemonicToSeed(strings.TrimSpace(string(phrase)))
+	if err != nil {
+		return nil, err
+	}
+
+	env := breez_sdk.EnvironmentTypeProduction
+	if opts.staging {
+		env = breez_sdk.EnvironmentTypeStaging
+	}
+	nodeConfig := breez_sdk.GreenlightNodeConfig{}
+	if opts.inviteCode != "" {
+		nodeConfig.InviteCode = &opts.inviteCode
+	}
+	config := breez_sdk.DefaultConfig(env, opts.apiKey, breez_sdk.NodeConfigGreenlight{Config: nodeConfig})
+	config.WorkingDir = opts.dataDir
+	return breez_sdk.ConnectService(breez_sdk.ConnectRequest{Config: config, Seed: seed, RestoreOnly: &restoreOnly}, nil)
+}
+
+func printJSON(v interface{}) error {
+	if v == nil {
+		return nil
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
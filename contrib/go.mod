@@ -0,0 +1,5 @@
+module github.com/breez/breez-sdk-go/contrib
+
+go 1.19
+
+require github.com/breez/breez-sdk-go v0.0.0-20261016153141-58913d9825f6
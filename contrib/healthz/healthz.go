@@ -0,0 +1,137 @@
+// Package healthz exposes the state of a Breez SDK node as an http.Handler
+// suitable for liveness and readiness probes.
+package healthz
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+)
+
+// Report is the JSON body served by Handler.
+type Report struct {
+	Healthy             bool     `json:"healthy"`
+	Connected           bool     `json:"connected"`
+	ConnectedPeers      int      `json:"connected_peers"`
+	BlockHeight         uint32   `json:"block_height"`
+	LastSyncAgeSec      *float64 `json:"last_sync_age_sec"`
+	ServiceStatus       string   `json:"service_status,omitempty"`
+	BackedUp            bool     `json:"backed_up"`
+	LastBackupTime      *uint64  `json:"last_backup_time"`
+	PendingSwaps        int      `json:"pending_swaps"`
+	PendingReverseSwaps int      `json:"pending_reverse_swaps"`
+	Problems            []string `json:"problems,omitempty"`
+}
+
+type options struct {
+	apiKey     string
+	maxSyncAge time.Duration
+}
+
+// Option configures Check and Handler.
+type Option func(*options)
+
+// WithApiKey enables the Breez service health check, which needs the API key.
+func WithApiKey(apiKey string) Option {
+	return func(o *options) {
+		o.apiKey = apiKey
+	}
+}
+
+// WithMaxSyncAge reports the node unhealthy when it has not synced for longer
+// than maxSyncAge.
+func WithMaxSyncAge(maxSyncAge time.Duration) Option {
+	return func(o *options) {
+		o.maxSyncAge = maxSyncAge
+	}
+}
+
+// Check collects the health report of svc. The node is healthy when it is
+// connected to at least one peer, its backup status is readable, its last sync
+// is recent enough and the Breez service, if checked, is operational.
+func Check(svc *breez_sdk.BlockingBreezServices, opts ...Option) Report {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	report := Report{Healthy: true}
+	fail := func(problem string) {
+		report.Healthy = false
+		report.Problems = append(report.Problems, problem)
+	}
+
+	nodeState, err := svc.NodeInfo()
+	if err != nil {
+		fail("node info: " + err.Error())
+	} else {
+		report.ConnectedPeers = len(nodeState.ConnectedPeers)
+		report.Connected = report.ConnectedPeers > 0
+		report.BlockHeight = nodeState.BlockHeight
+		if !report.Connected {
+			fail("no connected peers")
+		}
+	}
+
+	if lastSynced, ok := svc.LastSynced(); ok {
+		age := svc.Clock().Now().Sub(lastSynced)
+		ageSec := age.Seconds()
+		report.LastSyncAgeSec = &ageSec
+		if o.maxSyncAge > 0 && age > o.maxSyncAge {
+			fail("last sync is too old")
+		}
+	} else if o.maxSyncAge > 0 {
+		fail("not synced yet")
+	}
+
+	if o.apiKey != "" {
+		res, err := breez_sdk.ServiceHealthCheck(o.apiKey)
+		if err != nil {
+			fail("service health check: " + err.Error())
+		} else {
+			report.ServiceStatus = res.Status.String()
+			if res.Status != breez_sdk.HealthCheckStatusOperational {
+				fail("breez service status: " + report.ServiceStatus)
+			}
+		}
+	}
+
+	backupStatus, err := svc.BackupStatus()
+	if err != nil {
+		fail("backup status: " + err.Error())
+	} else {
+		report.BackedUp = backupStatus.BackedUp
+		report.LastBackupTime = backupStatus.LastBackupTime
+	}
+
+	if swap, err := svc.InProgressSwap(); err != nil {
+		fail("in progress swap: " + err.Error())
+	} else if swap != nil {
+		report.PendingSwaps = 1
+	}
+	if reverseSwaps, err := svc.InProgressOnchainPayments(); err != nil {
+		fail("in progress onchain payments: " + err.Error())
+	} else {
+		report.PendingReverseSwaps = len(reverseSwaps)
+	}
+
+	return report
+}
+
+// Handler serves the report of Check as JSON, with status 200 when the node is
+// healthy and 503 otherwise.
+func Handler(svc *breez_sdk.BlockingBreezServices, opts ...Option) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := Check(svc, opts...)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if report.Healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
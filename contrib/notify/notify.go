@@ -0,0 +1,192 @@
+// Package notify handles the notifications of the Breez notification service
+// in a headless process, the way the Swift and Kotlin notification plugins
+// do on mobile: it waits for received payments, redeems confirmed swaps and
+// answers LNURL-pay requests.
+//
+//	processor := &notify.Processor{Service: sdk, LnurlPayMetadata: `[["text/plain","Pay to Alice"]]`}
+//	http.Handle("/breez", webhook.Handler(secret, func(p webhook.Payload) error {
+//		_, err := processor.Process(ctx, p)
+//		return err
+//	}))
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+	"github.com/breez/breez-sdk-go/contrib/webhook"
+)
+
+// Service is what a Processor needs from the SDK, implemented by
+// *breez_sdk.BlockingBreezServices.
+type Service interface {
+	NodeInfo() (breez_sdk.NodeState, error)
+	ReceivePayment(req breez_sdk.ReceivePaymentRequest) (breez_sdk.ReceivePaymentResponse, error)
+	RedeemSwap(swapAddress string) error
+	WaitForPayment(ctx context.Context, paymentHash string) (breez_sdk.Payment, error)
+}
+
+var _ Service = (*breez_sdk.BlockingBreezServices)(nil)
+
+// DefaultPaymentTimeout bounds how long Process waits for a received payment.
+const DefaultPaymentTimeout = 60 * time.Second
+
+// Processor acts on notifications.
+type Processor struct {
+	Service Service
+	// LnurlPayMetadata is the metadata JSON array of the LNURL-pay requests
+	// answered for lnurlpay_info and lnurlpay_invoice notifications.
+	LnurlPayMetadata string
+	// MinSendableMsat is the minimum LNURL-pay amount, 1000 msat if zero.
+	MinSendableMsat uint64
+	// PaymentTimeout defaults to DefaultPaymentTimeout.
+	PaymentTimeout time.Duration
+	// Client defaults to breez_sdk.HttpClient.
+	Client *http.Client
+}
+
+// Result is the outcome of a processed notification.
+type Result struct {
+	Template string
+	// Payment is the received payment of a payment_received notification.
+	Payment *breez_sdk.Payment
+	// Invoice is the invoice sent for an lnurlpay_invoice notification.
+	Invoice *breez_sdk.LnInvoice
+}
+
+// Process acts on payload:
+//
+//   - payment_received waits until the payment is complete or failed,
+//   - address_txs_confirmed redeems the swap of the address,
+//   - lnurlpay_info replies with the LNURL-pay request of the node,
+//   - lnurlpay_invoice creates an invoice and replies with it.
+func (p *Processor) Process(ctx context.Context, payload webhook.Payload) (Result, error) {
+	result := Result{Template: payload.Template}
+	switch payload.Template {
+	case webhook.TemplatePaymentReceived:
+		data, err := payload.PaymentReceived()
+		if err != nil {
+			return result, err
+		}
+		timeout := p.PaymentTimeout
+		if timeout == 0 {
+			timeout = DefaultPaymentTimeout
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		payment, err := p.Service.WaitForPayment(ctx, data.PaymentHash)
+		if err != nil {
+			return result, err
+		}
+		result.Payment = &payment
+		return result, nil
+
+	case webhook.TemplateAddressTxsConfirmed:
+		data, err := payload.AddressTxsConfirmed()
+		if err != nil {
+			return result, err
+		}
+		return result, p.Service.RedeemSwap(data.Address)
+
+	case webhook.TemplateLnurlPayInfo:
+		data, err := payload.LnurlPayInfo()
+		if err != nil {
+			return result, err
+		}
+		return result, p.replyLnurlPayInfo(ctx, data)
+
+	case webhook.TemplateLnurlPayInvoice:
+		data, err := payload.LnurlPayInvoice()
+		if err != nil {
+			return result, err
+		}
+		invoice, err := p.replyLnurlPayInvoice(ctx, data)
+		result.Invoice = invoice
+		return result, err
+
+	default:
+		return result, fmt.Errorf("unsupported notification %s", payload.Template)
+	}
+}
+
+func (p *Processor) minSendableMsat() uint64 {
+	if p.MinSendableMsat == 0 {
+		return 1000
+	}
+	return p.MinSendableMsat
+}
+
+func (p *Processor) replyLnurlPayInfo(ctx context.Context, data webhook.LnurlPayInfoData) error {
+	nodeState, err := p.Service.NodeInfo()
+	if err != nil {
+		return p.replyError(ctx, data.ReplyUrl, err)
+	}
+	return p.reply(ctx, data.ReplyUrl, map[string]interface{}{
+		"tag":         "payRequest",
+		"callback":    data.CallbackUrl,
+		"minSendable": p.minSendableMsat(),
+		"maxSendable": nodeState.MaxReceivableMsat,
+		"metadata":    p.LnurlPayMetadata,
+	})
+}
+
+func (p *Processor) replyLnurlPayInvoice(ctx context.Context, data webhook.LnurlPayInvoiceData) (*breez_sdk.LnInvoice, error) {
+	if data.Amount < p.minSendableMsat() {
+		err := fmt.Errorf("amount %d msat is below the minimum of %d msat", data.Amount, p.minSendableMsat())
+		return nil, p.replyError(ctx, data.ReplyUrl, err)
+	}
+	useDescriptionHash := true
+	res, err := p.Service.ReceivePayment(breez_sdk.ReceivePaymentRequest{
+		AmountMsat:         data.Amount,
+		Description:        p.LnurlPayMetadata,
+		UseDescriptionHash: &useDescriptionHash,
+	})
+	if err != nil {
+		return nil, p.replyError(ctx, data.ReplyUrl, err)
+	}
+	return &res.LnInvoice, p.reply(ctx, data.ReplyUrl, map[string]interface{}{
+		"pr":     res.LnInvoice.Bolt11,
+		"routes": []interface{}{},
+	})
+}
+
+// replyError sends the LNURL error response for err, and returns err.
+func (p *Processor) replyError(ctx context.Context, replyUrl string, err error) error {
+	if replyErr := p.reply(ctx, replyUrl, map[string]interface{}{
+		"status": "ERROR",
+		"reason": err.Error(),
+	}); replyErr != nil {
+		return fmt.Errorf("%w (replying: %v)", err, replyErr)
+	}
+	return err
+}
+
+func (p *Processor) reply(ctx context.Context, replyUrl string, body interface{}) error {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, replyUrl, bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := p.Client
+	if client == nil {
+		client = breez_sdk.HttpClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("replying to %s: %s", replyUrl, res.Status)
+	}
+	return nil
+}
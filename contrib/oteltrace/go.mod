@@ -0,0 +1,9 @@
+module github.com/breez/breez-sdk-go/contrib/oteltrace
+
+go 1.19
+
+require (
+	github.com/breez/breez-sdk-go v0.0.0-20261016153141-58913d9825f6
+	go.opentelemetry.io/otel v1.16.0
+	go.opentelemetry.io/otel/trace v1.16.0
+)
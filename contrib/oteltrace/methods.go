@@ -0,0 +1,355 @@
+package oteltrace
+
+import "github.com/breez/breez-sdk-go/breez_sdk"
+
+var _ breez_sdk.BlockingBreezServicesInterface = (*Services)(nil)
+
+func (s *Services) Disconnect() error {
+	span := s.start("Disconnect")
+	err := s.inner.Disconnect()
+	end(span, err)
+	return err
+}
+
+func (s *Services) ConfigureNode(req breez_sdk.ConfigureNodeRequest) error {
+	span := s.start("ConfigureNode")
+	err := s.inner.ConfigureNode(req)
+	end(span, err)
+	return err
+}
+
+func (s *Services) SendPayment(req breez_sdk.SendPaymentRequest) (breez_sdk.SendPaymentResponse, error) {
+	span := s.start("SendPayment")
+	res, err := s.inner.SendPayment(req)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) SendSpontaneousPayment(req breez_sdk.SendSpontaneousPaymentRequest) (breez_sdk.SendPaymentResponse, error) {
+	span := s.start("SendSpontaneousPayment")
+	res, err := s.inner.SendSpontaneousPayment(req)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) ReceivePayment(req breez_sdk.ReceivePaymentRequest) (breez_sdk.ReceivePaymentResponse, error) {
+	span := s.start("ReceivePayment")
+	res, err := s.inner.ReceivePayment(req)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) PayLnurl(req breez_sdk.LnUrlPayRequest) (breez_sdk.LnUrlPayResult, error) {
+	span := s.start("PayLnurl")
+	res, err := s.inner.PayLnurl(req)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) WithdrawLnurl(request breez_sdk.LnUrlWithdrawRequest) (breez_sdk.LnUrlWithdrawResult, error) {
+	span := s.start("WithdrawLnurl")
+	res, err := s.inner.WithdrawLnurl(request)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) LnurlAuth(reqData breez_sdk.LnUrlAuthRequestData) (breez_sdk.LnUrlCallbackStatus, error) {
+	span := s.start("LnurlAuth")
+	res, err := s.inner.LnurlAuth(reqData)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) ReportIssue(req breez_sdk.ReportIssueRequest) error {
+	span := s.start("ReportIssue")
+	err := s.inner.ReportIssue(req)
+	end(span, err)
+	return err
+}
+
+func (s *Services) NodeCredentials() (*breez_sdk.NodeCredentials, error) {
+	span := s.start("NodeCredentials")
+	res, err := s.inner.NodeCredentials()
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) NodeInfo() (breez_sdk.NodeState, error) {
+	span := s.start("NodeInfo")
+	res, err := s.inner.NodeInfo()
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) SignMessage(req breez_sdk.SignMessageRequest) (breez_sdk.SignMessageResponse, error) {
+	span := s.start("SignMessage")
+	res, err := s.inner.SignMessage(req)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) CheckMessage(req breez_sdk.CheckMessageRequest) (breez_sdk.CheckMessageResponse, error) {
+	span := s.start("CheckMessage")
+	res, err := s.inner.CheckMessage(req)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) BackupStatus() (breez_sdk.BackupStatus, error) {
+	span := s.start("BackupStatus")
+	res, err := s.inner.BackupStatus()
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) Backup() error {
+	span := s.start("Backup")
+	err := s.inner.Backup()
+	end(span, err)
+	return err
+}
+
+func (s *Services) ListPayments(req breez_sdk.ListPaymentsRequest) ([]breez_sdk.Payment, error) {
+	span := s.start("ListPayments")
+	res, err := s.inner.ListPayments(req)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) PaymentByHash(hash string) (*breez_sdk.Payment, error) {
+	span := s.start("PaymentByHash")
+	res, err := s.inner.PaymentByHash(hash)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) SetPaymentMetadata(hash string, metadata string) error {
+	span := s.start("SetPaymentMetadata")
+	err := s.inner.SetPaymentMetadata(hash, metadata)
+	end(span, err)
+	return err
+}
+
+func (s *Services) RedeemOnchainFunds(req breez_sdk.RedeemOnchainFundsRequest) (breez_sdk.RedeemOnchainFundsResponse, error) {
+	span := s.start("RedeemOnchainFunds")
+	res, err := s.inner.RedeemOnchainFunds(req)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) FetchFiatRates() ([]breez_sdk.Rate, error) {
+	span := s.start("FetchFiatRates")
+	res, err := s.inner.FetchFiatRates()
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) ListFiatCurrencies() ([]breez_sdk.FiatCurrency, error) {
+	span := s.start("ListFiatCurrencies")
+	res, err := s.inner.ListFiatCurrencies()
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) ListLsps() ([]breez_sdk.LspInformation, error) {
+	span := s.start("ListLsps")
+	res, err := s.inner.ListLsps()
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) ConnectLsp(lspId string) error {
+	span := s.start("ConnectLsp")
+	err := s.inner.ConnectLsp(lspId)
+	end(span, err)
+	return err
+}
+
+func (s *Services) FetchLspInfo(lspId string) (*breez_sdk.LspInformation, error) {
+	span := s.start("FetchLspInfo")
+	res, err := s.inner.FetchLspInfo(lspId)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) OpenChannelFee(req breez_sdk.OpenChannelFeeRequest) (breez_sdk.OpenChannelFeeResponse, error) {
+	span := s.start("OpenChannelFee")
+	res, err := s.inner.OpenChannelFee(req)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) LspId() (*string, error) {
+	span := s.start("LspId")
+	res, err := s.inner.LspId()
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) LspInfo() (breez_sdk.LspInformation, error) {
+	span := s.start("LspInfo")
+	res, err := s.inner.LspInfo()
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) CloseLspChannels() error {
+	span := s.start("CloseLspChannels")
+	err := s.inner.CloseLspChannels()
+	end(span, err)
+	return err
+}
+
+func (s *Services) RegisterWebhook(webhookUrl string) error {
+	span := s.start("RegisterWebhook")
+	err := s.inner.RegisterWebhook(webhookUrl)
+	end(span, err)
+	return err
+}
+
+func (s *Services) UnregisterWebhook(webhookUrl string) error {
+	span := s.start("UnregisterWebhook")
+	err := s.inner.UnregisterWebhook(webhookUrl)
+	end(span, err)
+	return err
+}
+
+func (s *Services) ReceiveOnchain(req breez_sdk.ReceiveOnchainRequest) (breez_sdk.SwapInfo, error) {
+	span := s.start("ReceiveOnchain")
+	res, err := s.inner.ReceiveOnchain(req)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) InProgressSwap() (*breez_sdk.SwapInfo, error) {
+	span := s.start("InProgressSwap")
+	res, err := s.inner.InProgressSwap()
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) RescanSwaps() error {
+	span := s.start("RescanSwaps")
+	err := s.inner.RescanSwaps()
+	end(span, err)
+	return err
+}
+
+func (s *Services) RedeemSwap(swapAddress string) error {
+	span := s.start("RedeemSwap")
+	err := s.inner.RedeemSwap(swapAddress)
+	end(span, err)
+	return err
+}
+
+func (s *Services) ListRefundables() ([]breez_sdk.SwapInfo, error) {
+	span := s.start("ListRefundables")
+	res, err := s.inner.ListRefundables()
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) PrepareRefund(req breez_sdk.PrepareRefundRequest) (breez_sdk.PrepareRefundResponse, error) {
+	span := s.start("PrepareRefund")
+	res, err := s.inner.PrepareRefund(req)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) Refund(req breez_sdk.RefundRequest) (breez_sdk.RefundResponse, error) {
+	span := s.start("Refund")
+	res, err := s.inner.Refund(req)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) ListSwaps(req breez_sdk.ListSwapsRequest) ([]breez_sdk.SwapInfo, error) {
+	span := s.start("ListSwaps")
+	res, err := s.inner.ListSwaps(req)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) FetchReverseSwapFees(req breez_sdk.ReverseSwapFeesRequest) (breez_sdk.ReverseSwapPairInfo, error) {
+	span := s.start("FetchReverseSwapFees")
+	res, err := s.inner.FetchReverseSwapFees(req)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) OnchainPaymentLimits() (breez_sdk.OnchainPaymentLimitsResponse, error) {
+	span := s.start("OnchainPaymentLimits")
+	res, err := s.inner.OnchainPaymentLimits()
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) PrepareOnchainPayment(req breez_sdk.PrepareOnchainPaymentRequest) (breez_sdk.PrepareOnchainPaymentResponse, error) {
+	span := s.start("PrepareOnchainPayment")
+	res, err := s.inner.PrepareOnchainPayment(req)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) InProgressOnchainPayments() ([]breez_sdk.ReverseSwapInfo, error) {
+	span := s.start("InProgressOnchainPayments")
+	res, err := s.inner.InProgressOnchainPayments()
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) ClaimReverseSwap(lockupAddress string) error {
+	span := s.start("ClaimReverseSwap")
+	err := s.inner.ClaimReverseSwap(lockupAddress)
+	end(span, err)
+	return err
+}
+
+func (s *Services) PayOnchain(req breez_sdk.PayOnchainRequest) (breez_sdk.PayOnchainResponse, error) {
+	span := s.start("PayOnchain")
+	res, err := s.inner.PayOnchain(req)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) ExecuteDevCommand(command string) (string, error) {
+	span := s.start("ExecuteDevCommand")
+	res, err := s.inner.ExecuteDevCommand(command)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) GenerateDiagnosticData() (string, error) {
+	span := s.start("GenerateDiagnosticData")
+	res, err := s.inner.GenerateDiagnosticData()
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) Sync() error {
+	span := s.start("Sync")
+	err := s.inner.Sync()
+	end(span, err)
+	return err
+}
+
+func (s *Services) RecommendedFees() (breez_sdk.RecommendedFees, error) {
+	span := s.start("RecommendedFees")
+	res, err := s.inner.RecommendedFees()
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) BuyBitcoin(req breez_sdk.BuyBitcoinRequest) (breez_sdk.BuyBitcoinResponse, error) {
+	span := s.start("BuyBitcoin")
+	res, err := s.inner.BuyBitcoin(req)
+	end(span, err)
+	return res, err
+}
+
+func (s *Services) PrepareRedeemOnchainFunds(req breez_sdk.PrepareRedeemOnchainFundsRequest) (breez_sdk.PrepareRedeemOnchainFundsResponse, error) {
+	span := s.start("PrepareRedeemOnchainFunds")
+	res, err := s.inner.PrepareRedeemOnchainFunds(req)
+	end(span, err)
+	return res, err
+}
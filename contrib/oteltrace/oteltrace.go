@@ -0,0 +1,86 @@
+// Package oteltrace wraps a Breez SDK service with OpenTelemetry spans, one
+// per method call, so that distributed traces include the latency of
+// lightning payments and other node operations:
+//
+//	sdk, err := breez_sdk.ConnectService(req, listener)
+//	svc := oteltrace.Wrap(sdk, otel.GetTracerProvider())
+//
+// It is a separate module so that only its users depend on OpenTelemetry.
+package oteltrace
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer creating the spans.
+const InstrumentationName = "github.com/breez/breez-sdk-go/contrib/oteltrace"
+
+// Services implements breez_sdk.BlockingBreezServicesInterface on top of
+// another implementation, recording a span for every call. The methods take
+// no context, so spans are roots unless WithContext provides a parent.
+type Services struct {
+	inner  breez_sdk.BlockingBreezServicesInterface
+	tracer trace.Tracer
+	ctx    context.Context
+}
+
+// Wrap returns inner traced with a tracer of provider.
+func Wrap(inner breez_sdk.BlockingBreezServicesInterface, provider trace.TracerProvider) *Services {
+	return &Services{
+		inner:  inner,
+		tracer: provider.Tracer(InstrumentationName),
+		ctx:    context.Background(),
+	}
+}
+
+// Connect is breez_sdk.ConnectService recorded as a span, returning the
+// connected service wrapped. Unwrap returns the *breez_sdk.Services.
+func Connect(req breez_sdk.ConnectRequest, listener breez_sdk.EventListener, provider trace.TracerProvider) (*Services, error) {
+	tracer := provider.Tracer(InstrumentationName)
+	_, span := tracer.Start(context.Background(), "breez_sdk.ConnectService", trace.WithSpanKind(trace.SpanKindClient))
+	sdk, err := breez_sdk.ConnectService(req, listener)
+	end(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return &Services{inner: sdk, tracer: tracer, ctx: context.Background()}, nil
+}
+
+// WithContext returns a copy of s whose spans are children of the span in ctx.
+func (s *Services) WithContext(ctx context.Context) *Services {
+	copy := *s
+	copy.ctx = ctx
+	return &copy
+}
+
+// Unwrap returns the wrapped service.
+func (s *Services) Unwrap() breez_sdk.BlockingBreezServicesInterface {
+	return s.inner
+}
+
+func (s *Services) start(method string) trace.Span {
+	_, span := s.tracer.Start(s.ctx, "breez_sdk."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("breez.method", method)))
+	return span
+}
+
+// end records err on span, with the variant of SDK errors as error code, and
+// ends it.
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if variant := errors.Unwrap(err); variant != nil {
+			span.SetAttributes(attribute.String("breez.error.code", reflect.Indirect(reflect.ValueOf(variant)).Type().Name()))
+		}
+	}
+	span.End()
+}
@@ -0,0 +1,156 @@
+package paymentcache
+
+import (
+	"strings"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+)
+
+// Payment is a cached payment. The Lightning fields are nil for payments of
+// closed channels.
+type Payment struct {
+	Id              string
+	PaymentType     string
+	PaymentTime     int64
+	AmountMsat      int64
+	FeeMsat         int64
+	Status          string
+	Error           *string
+	Description     *string
+	Metadata        *string
+	PaymentHash     *string
+	Bolt11          *string
+	Label           *string
+	LnAddress       *string
+	LnurlPayComment *string
+	LnurlMetadata   *string
+}
+
+// paymentColumns are the columns of fields, followed by search_text.
+var paymentColumns = []string{
+	"id", "payment_type", "payment_time", "amount_msat", "fee_msat", "status", "error",
+	"description", "metadata", "payment_hash", "bolt11", "label", "ln_address",
+	"lnurl_pay_comment", "lnurl_metadata", "search_text",
+}
+
+func (p *Payment) fields() []interface{} {
+	return []interface{}{
+		&p.Id, &p.PaymentType, &p.PaymentTime, &p.AmountMsat, &p.FeeMsat, &p.Status, &p.Error,
+		&p.Description, &p.Metadata, &p.PaymentHash, &p.Bolt11, &p.Label, &p.LnAddress,
+		&p.LnurlPayComment, &p.LnurlMetadata,
+	}
+}
+
+func (p Payment) searchText() string {
+	var parts []string
+	for _, field := range []*string{p.Description, p.Metadata, p.PaymentHash, p.Bolt11, p.Label, p.LnAddress, p.LnurlPayComment, p.LnurlMetadata} {
+		if field != nil && *field != "" {
+			parts = append(parts, *field)
+		}
+	}
+	return strings.ToLower(strings.Join(parts, "\n"))
+}
+
+var paymentTypes = map[breez_sdk.PaymentType]string{
+	breez_sdk.PaymentTypeSent:          "sent",
+	breez_sdk.PaymentTypeReceived:      "received",
+	breez_sdk.PaymentTypeClosedChannel: "closed_channel",
+}
+
+var paymentStatuses = map[breez_sdk.PaymentStatus]string{
+	breez_sdk.PaymentStatusPending:  "pending",
+	breez_sdk.PaymentStatusComplete: "complete",
+	breez_sdk.PaymentStatusFailed:   "failed",
+}
+
+func newPayment(p breez_sdk.Payment) Payment {
+	payment := Payment{
+		Id:          p.Id,
+		PaymentType: paymentTypes[p.PaymentType],
+		PaymentTime: p.PaymentTime,
+		AmountMsat:  int64(p.AmountMsat),
+		FeeMsat:     int64(p.FeeMsat),
+		Status:      paymentStatuses[p.Status],
+		Error:       p.Error,
+		Description: p.Description,
+		Metadata:    p.Metadata,
+	}
+	if details, ok := p.Details.(breez_sdk.PaymentDetailsLn); ok {
+		payment.PaymentHash = nonEmpty(details.Data.PaymentHash)
+		payment.Bolt11 = nonEmpty(details.Data.Bolt11)
+		payment.Label = nonEmpty(details.Data.Label)
+		payment.LnAddress = details.Data.LnAddress
+		payment.LnurlPayComment = details.Data.LnurlPayComment
+		payment.LnurlMetadata = details.Data.LnurlMetadata
+	}
+	return payment
+}
+
+// Swap is a cached swap.
+type Swap struct {
+	BitcoinAddress  string
+	CreatedAt       int64
+	Status          string
+	PaidMsat        int64
+	UnconfirmedSats int64
+	ConfirmedSats   int64
+	Bolt11          *string
+	LastRedeemError *string
+}
+
+var swapColumns = []string{
+	"bitcoin_address", "created_at", "status", "paid_msat", "unconfirmed_sats",
+	"confirmed_sats", "bolt11", "last_redeem_error",
+}
+
+func (s *Swap) fields() []interface{} {
+	return []interface{}{
+		&s.BitcoinAddress, &s.CreatedAt, &s.Status, &s.PaidMsat, &s.UnconfirmedSats,
+		&s.ConfirmedSats, &s.Bolt11, &s.LastRedeemError,
+	}
+}
+
+var swapStatuses = map[breez_sdk.SwapStatus]string{
+	breez_sdk.SwapStatusInitial:             "initial",
+	breez_sdk.SwapStatusWaitingConfirmation: "waiting_confirmation",
+	breez_sdk.SwapStatusRedeemable:          "redeemable",
+	breez_sdk.SwapStatusRedeemed:            "redeemed",
+	breez_sdk.SwapStatusRefundable:          "refundable",
+	breez_sdk.SwapStatusCompleted:           "completed",
+}
+
+func newSwap(s breez_sdk.SwapInfo) Swap {
+	return Swap{
+		BitcoinAddress:  s.BitcoinAddress,
+		CreatedAt:       s.CreatedAt,
+		Status:          swapStatuses[s.Status],
+		PaidMsat:        int64(s.PaidMsat),
+		UnconfirmedSats: int64(s.UnconfirmedSats),
+		ConfirmedSats:   int64(s.ConfirmedSats),
+		Bolt11:          s.Bolt11,
+		LastRedeemError: s.LastRedeemError,
+	}
+}
+
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// derefField turns a pointer of fields into the value to insert.
+func derefField(field interface{}) interface{} {
+	switch field := field.(type) {
+	case *string:
+		return *field
+	case **string:
+		if *field == nil {
+			return nil
+		}
+		return **field
+	case *int64:
+		return *field
+	}
+	panic("paymentcache: unexpected field type")
+}
@@ -0,0 +1,316 @@
+// Package paymentcache mirrors the payments and swaps of a node into a SQL
+// database, so that they can be queried and searched offline without going
+// through the SDK.
+//
+//	db, _ := sql.Open("sqlite3", "payments.db")
+//	cache, err := paymentcache.New(ctx, db)
+//	...
+//	stop := paymentcache.Attach(sdk, cache, func(err error) { log.Print(err) })
+//	defer stop()
+//	payments, err := cache.Search(ctx, "coffee", 20)
+//
+// The database is provided by the caller, so the package pulls in no driver.
+// Statements use ? placeholders and plain SQL, as understood by SQLite and
+// MySQL drivers.
+package paymentcache
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+)
+
+// cursorKey is the key of the ListPaymentsChanges cursor in the state table.
+const cursorKey = "payments_cursor"
+
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS paymentcache_payments (
+		id TEXT PRIMARY KEY,
+		payment_type TEXT NOT NULL,
+		payment_time INTEGER NOT NULL,
+		amount_msat INTEGER NOT NULL,
+		fee_msat INTEGER NOT NULL,
+		status TEXT NOT NULL,
+		error TEXT,
+		description TEXT,
+		metadata TEXT,
+		payment_hash TEXT,
+		bolt11 TEXT,
+		label TEXT,
+		ln_address TEXT,
+		lnurl_pay_comment TEXT,
+		lnurl_metadata TEXT,
+		search_text TEXT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS paymentcache_payments_time ON paymentcache_payments (payment_time)`,
+	`CREATE TABLE IF NOT EXISTS paymentcache_swaps (
+		bitcoin_address TEXT PRIMARY KEY,
+		created_at INTEGER NOT NULL,
+		status TEXT NOT NULL,
+		paid_msat INTEGER NOT NULL,
+		unconfirmed_sats INTEGER NOT NULL,
+		confirmed_sats INTEGER NOT NULL,
+		bolt11 TEXT,
+		last_redeem_error TEXT
+	)`,
+	`CREATE TABLE IF NOT EXISTS paymentcache_state (
+		key TEXT PRIMARY KEY,
+		value TEXT NOT NULL
+	)`,
+}
+
+// Source is where Sync reads from, implemented by
+// *breez_sdk.BlockingBreezServices.
+type Source interface {
+	ListPaymentsChanges(ctx context.Context, sinceCursor string) (breez_sdk.PaymentChanges, error)
+	ListSwaps(req breez_sdk.ListSwapsRequest) ([]breez_sdk.SwapInfo, error)
+}
+
+var _ Source = (*breez_sdk.BlockingBreezServices)(nil)
+
+// Cache is the mirror in one database. Its methods are safe for concurrent
+// use; Syncs are serialized.
+type Cache struct {
+	db       *sql.DB
+	syncLock sync.Mutex
+}
+
+// New creates the tables of the cache in db unless they exist.
+func New(ctx context.Context, db *sql.DB) (*Cache, error) {
+	for _, statement := range schema {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return nil, err
+		}
+	}
+	return &Cache{db: db}, nil
+}
+
+// Sync brings the cache up to date with src: the payments changed since the
+// last Sync and all swaps.
+func (c *Cache) Sync(ctx context.Context, src Source) error {
+	c.syncLock.Lock()
+	defer c.syncLock.Unlock()
+
+	var cursor string
+	err := c.db.QueryRowContext(ctx, `SELECT value FROM paymentcache_state WHERE key = ?`, cursorKey).Scan(&cursor)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	changes, err := src.ListPaymentsChanges(ctx, cursor)
+	if err != nil {
+		return err
+	}
+	swaps, err := src.ListSwaps(breez_sdk.ListSwapsRequest{})
+	if err != nil {
+		return err
+	}
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, payment := range changes.Payments {
+		if err := putPayment(ctx, tx, newPayment(payment)); err != nil {
+			return err
+		}
+	}
+	for _, swap := range swaps {
+		if err := putSwap(ctx, tx, newSwap(swap)); err != nil {
+			return err
+		}
+	}
+	if err := put(ctx, tx, "paymentcache_state", "key", cursorKey, []string{"key", "value"}, cursorKey, changes.Cursor); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Attach syncs cache with svc now and whenever the node syncs or a payment or
+// swap changes, until the returned function is called or svc is closed.
+// Events arriving during a sync cause one more sync. Errors of the syncs are
+// passed to onError, which may be nil.
+func Attach(svc *breez_sdk.BlockingBreezServices, cache *Cache, onError func(error)) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	trigger := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if err := cache.Sync(ctx, svc); err != nil && ctx.Err() == nil && onError != nil {
+				onError(err)
+			}
+			select {
+			case <-trigger:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	unsubscribe := svc.OnEvent(func(e breez_sdk.BreezEvent) {
+		switch e.(type) {
+		case breez_sdk.BreezEventSynced, breez_sdk.BreezEventInvoicePaid, breez_sdk.BreezEventPaymentSucceed,
+			breez_sdk.BreezEventPaymentFailed, breez_sdk.BreezEventSwapUpdated:
+			select {
+			case trigger <- struct{}{}:
+			default:
+			}
+		}
+	})
+
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			unsubscribe()
+			cancel()
+			<-done
+		})
+	}
+	unregister := svc.RegisterShutdownHook(stop)
+	return func() {
+		unregister()
+		stop()
+	}
+}
+
+// Query selects cached payments. Zero fields do not filter.
+type Query struct {
+	// Types are "sent", "received" or "closed_channel".
+	Types []string
+	// Statuses are "pending", "complete" or "failed".
+	Statuses []string
+	// FromTime and ToTime bound the payment time, in Unix seconds, inclusive.
+	FromTime int64
+	ToTime   int64
+	Offset   int
+	Limit    int
+}
+
+// Payments returns the cached payments matching q, newest first.
+func (c *Cache) Payments(ctx context.Context, q Query) ([]Payment, error) {
+	var where []string
+	var args []interface{}
+	if len(q.Types) > 0 {
+		where = append(where, "payment_type IN ("+placeholders(len(q.Types))+")")
+		for _, t := range q.Types {
+			args = append(args, t)
+		}
+	}
+	if len(q.Statuses) > 0 {
+		where = append(where, "status IN ("+placeholders(len(q.Statuses))+")")
+		for _, s := range q.Statuses {
+			args = append(args, s)
+		}
+	}
+	if q.FromTime != 0 {
+		where = append(where, "payment_time >= ?")
+		args = append(args, q.FromTime)
+	}
+	if q.ToTime != 0 {
+		where = append(where, "payment_time <= ?")
+		args = append(args, q.ToTime)
+	}
+	return c.queryPayments(ctx, where, args, q.Offset, q.Limit)
+}
+
+// Search returns the cached payments whose description, label, LNURL-pay
+// data, lightning address, metadata, hash or invoice contain every word of
+// text, ignoring case, newest first. A limit of zero returns all matches.
+func (c *Cache) Search(ctx context.Context, text string, limit int) ([]Payment, error) {
+	var where []string
+	var args []interface{}
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		where = append(where, `search_text LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+escapeLike(word)+"%")
+	}
+	return c.queryPayments(ctx, where, args, 0, limit)
+}
+
+// Swaps returns the cached swaps, newest first.
+func (c *Cache) Swaps(ctx context.Context) ([]Swap, error) {
+	rows, err := c.db.QueryContext(ctx, `SELECT `+strings.Join(swapColumns, ", ")+` FROM paymentcache_swaps ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	swaps := []Swap{}
+	for rows.Next() {
+		var swap Swap
+		if err := rows.Scan(swap.fields()...); err != nil {
+			return nil, err
+		}
+		swaps = append(swaps, swap)
+	}
+	return swaps, rows.Err()
+}
+
+func (c *Cache) queryPayments(ctx context.Context, where []string, args []interface{}, offset, limit int) ([]Payment, error) {
+	query := `SELECT ` + strings.Join(paymentColumns[:len(paymentColumns)-1], ", ") + ` FROM paymentcache_payments`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY payment_time DESC, id"
+	if limit > 0 || offset > 0 {
+		if limit <= 0 {
+			limit = math.MaxInt32
+		}
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, limit, offset)
+	}
+
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	payments := []Payment{}
+	for rows.Next() {
+		var payment Payment
+		if err := rows.Scan(payment.fields()...); err != nil {
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+	return payments, rows.Err()
+}
+
+func putPayment(ctx context.Context, tx *sql.Tx, payment Payment) error {
+	values := make([]interface{}, 0, len(paymentColumns))
+	for _, field := range payment.fields() {
+		values = append(values, derefField(field))
+	}
+	values = append(values, payment.searchText())
+	return put(ctx, tx, "paymentcache_payments", "id", payment.Id, paymentColumns, values...)
+}
+
+func putSwap(ctx context.Context, tx *sql.Tx, swap Swap) error {
+	values := make([]interface{}, 0, len(swapColumns))
+	for _, field := range swap.fields() {
+		values = append(values, derefField(field))
+	}
+	return put(ctx, tx, "paymentcache_swaps", "bitcoin_address", swap.BitcoinAddress, swapColumns, values...)
+}
+
+// put replaces the row whose key column has the value key. Deleting and
+// inserting works with every SQL dialect, unlike upserts.
+func put(ctx context.Context, tx *sql.Tx, table, keyColumn string, key interface{}, columns []string, values ...interface{}) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE `+keyColumn+` = ?`, key); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `INSERT INTO `+table+` (`+strings.Join(columns, ", ")+`) VALUES (`+placeholders(len(columns))+`)`, values...)
+	return err
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,251 @@
+// Package paymenthooks routes settled invoices to HTTP callbacks according to
+// declarative rules on their metadata:
+//
+//	router := paymenthooks.NewRouter(paymenthooks.Options{BaseURL: "http://orders.internal"})
+//	router.AddRule(paymenthooks.Rule{
+//		Name:        "orders",
+//		MetadataKey: "order_id",
+//		URL:         "/internal/orders/settle",
+//		Template:    `{"order": {{json .Metadata.order_id}}, "amount_msat": {{.Invoice.Payment.AmountMsat}}}`,
+//	})
+//	stop := paymenthooks.Attach(sdk, router)
+//
+// Invoices get their metadata from breez_sdk.ReceivePaymentOptions.Metadata
+// or SetPaymentMetadata; it must be a JSON object for rules to match.
+package paymenthooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+)
+
+// Rule sends a request to URL for every settled invoice whose metadata
+// contains MetadataKey.
+type Rule struct {
+	// Name identifies the rule for RemoveRule and in errors.
+	Name string
+	// MetadataKey is a dot separated path into the metadata object, such as
+	// "order.id". An empty key matches every invoice.
+	MetadataKey string
+	// URL is resolved against Options.BaseURL.
+	URL string
+	// Method defaults to POST.
+	Method  string
+	Headers map[string]string
+	// Template is a text/template rendering the request body from a
+	// TemplateData, with a json function encoding its argument. The default
+	// body is the JSON encoding of the TemplateData.
+	Template string
+}
+
+// TemplateData is what rule templates are executed with.
+type TemplateData struct {
+	Rule     string                   `json:"rule"`
+	Invoice  breez_sdk.SettledInvoice `json:"invoice"`
+	Metadata map[string]interface{}   `json:"metadata"`
+}
+
+// Options configure a Router.
+type Options struct {
+	BaseURL string
+	// Client defaults to an http.Client with a 10 second timeout.
+	Client *http.Client
+	// OnError is called when a rule fails to render or deliver.
+	OnError func(rule string, err error)
+}
+
+type compiledRule struct {
+	Rule
+	path     []string
+	url      string
+	template *template.Template
+}
+
+// Router evaluates its rules against settled invoices.
+type Router struct {
+	options Options
+	lock    sync.RWMutex
+	rules   []compiledRule
+	// routes tracks the invoices being routed for Attach.
+	routes sync.WaitGroup
+}
+
+// NewRouter returns a Router without rules.
+func NewRouter(options Options) *Router {
+	if options.Client == nil {
+		options.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Router{options: options}
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		encoded, err := json.Marshal(v)
+		return string(encoded), err
+	},
+}
+
+// AddRule adds rule, replacing the rule with the same name.
+func (r *Router) AddRule(rule Rule) error {
+	compiled := compiledRule{Rule: rule}
+	if rule.MetadataKey != "" {
+		compiled.path = strings.Split(rule.MetadataKey, ".")
+	}
+	target, err := url.Parse(rule.URL)
+	if err != nil {
+		return fmt.Errorf("rule %s: %w", rule.Name, err)
+	}
+	if r.options.BaseURL != "" {
+		base, err := url.Parse(r.options.BaseURL)
+		if err != nil {
+			return fmt.Errorf("base url: %w", err)
+		}
+		target = base.ResolveReference(target)
+	}
+	compiled.url = target.String()
+	if rule.Template != "" {
+		compiled.template, err = template.New(rule.Name).Funcs(templateFuncs).Option("missingkey=zero").Parse(rule.Template)
+		if err != nil {
+			return fmt.Errorf("rule %s: %w", rule.Name, err)
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for i := range r.rules {
+		if r.rules[i].Name == rule.Name {
+			r.rules[i] = compiled
+			return nil
+		}
+	}
+	r.rules = append(r.rules, compiled)
+	return nil
+}
+
+// RemoveRule removes the rule with the given name.
+func (r *Router) RemoveRule(name string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for i := range r.rules {
+		if r.rules[i].Name == name {
+			r.rules = append(r.rules[:i], r.rules[i+1:]...)
+			return
+		}
+	}
+}
+
+// Attach routes the invoices settled on svc until the returned function is
+// called or svc is closed. stop cancels the requests still in flight and waits
+// for them to return.
+func Attach(svc *breez_sdk.BlockingBreezServices, r *Router) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var lock sync.Mutex
+	stopped := false
+	unsubscribe := svc.OnInvoiceSettled(func(invoice breez_sdk.SettledInvoice) {
+		lock.Lock()
+		defer lock.Unlock()
+		if stopped {
+			return
+		}
+		r.routes.Add(1)
+		go func() {
+			defer r.routes.Done()
+			r.Route(ctx, invoice)
+		}()
+	})
+	return func() {
+		unsubscribe()
+		lock.Lock()
+		stopped = true
+		lock.Unlock()
+		cancel()
+		r.routes.Wait()
+	}
+}
+
+// Route sends the requests of every rule matching invoice and waits for them.
+func (r *Router) Route(ctx context.Context, invoice breez_sdk.SettledInvoice) {
+	metadata := map[string]interface{}{}
+	if invoice.Metadata != nil && *invoice.Metadata != "" {
+		if err := json.Unmarshal([]byte(*invoice.Metadata), &metadata); err != nil {
+			metadata = map[string]interface{}{}
+		}
+	}
+
+	r.lock.RLock()
+	rules := append([]compiledRule(nil), r.rules...)
+	r.lock.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, rule := range rules {
+		if !hasPath(metadata, rule.path) {
+			continue
+		}
+		wg.Add(1)
+		go func(rule compiledRule) {
+			defer wg.Done()
+			data := TemplateData{Rule: rule.Name, Invoice: invoice, Metadata: metadata}
+			if err := r.deliver(ctx, rule, data); err != nil && r.options.OnError != nil {
+				r.options.OnError(rule.Name, err)
+			}
+		}(rule)
+	}
+	wg.Wait()
+}
+
+func (r *Router) deliver(ctx context.Context, rule compiledRule, data TemplateData) error {
+	var body bytes.Buffer
+	if rule.template != nil {
+		if err := rule.template.Execute(&body, data); err != nil {
+			return err
+		}
+	} else if err := json.NewEncoder(&body).Encode(data); err != nil {
+		return err
+	}
+
+	method := rule.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rule.url, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range rule.Headers {
+		req.Header.Set(key, value)
+	}
+	res, err := r.options.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("%s %s: %s", method, rule.url, res.Status)
+	}
+	return nil
+}
+
+func hasPath(metadata map[string]interface{}, path []string) bool {
+	var value interface{} = metadata
+	for _, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if value, ok = object[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
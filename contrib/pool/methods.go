@@ -0,0 +1,305 @@
+package pool
+
+import "github.com/breez/breez-sdk-go/breez_sdk"
+
+var _ breez_sdk.BlockingBreezServicesInterface = (*Services)(nil)
+
+func (s *Services) Disconnect() error {
+	s.acquire()
+	defer s.release()
+	return s.inner.Disconnect()
+}
+
+func (s *Services) ConfigureNode(req breez_sdk.ConfigureNodeRequest) error {
+	s.acquire()
+	defer s.release()
+	return s.inner.ConfigureNode(req)
+}
+
+func (s *Services) SendPayment(req breez_sdk.SendPaymentRequest) (breez_sdk.SendPaymentResponse, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.SendPayment(req)
+}
+
+func (s *Services) SendSpontaneousPayment(req breez_sdk.SendSpontaneousPaymentRequest) (breez_sdk.SendPaymentResponse, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.SendSpontaneousPayment(req)
+}
+
+func (s *Services) ReceivePayment(req breez_sdk.ReceivePaymentRequest) (breez_sdk.ReceivePaymentResponse, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.ReceivePayment(req)
+}
+
+func (s *Services) PayLnurl(req breez_sdk.LnUrlPayRequest) (breez_sdk.LnUrlPayResult, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.PayLnurl(req)
+}
+
+func (s *Services) WithdrawLnurl(request breez_sdk.LnUrlWithdrawRequest) (breez_sdk.LnUrlWithdrawResult, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.WithdrawLnurl(request)
+}
+
+func (s *Services) LnurlAuth(reqData breez_sdk.LnUrlAuthRequestData) (breez_sdk.LnUrlCallbackStatus, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.LnurlAuth(reqData)
+}
+
+func (s *Services) ReportIssue(req breez_sdk.ReportIssueRequest) error {
+	s.acquire()
+	defer s.release()
+	return s.inner.ReportIssue(req)
+}
+
+func (s *Services) NodeCredentials() (*breez_sdk.NodeCredentials, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.NodeCredentials()
+}
+
+func (s *Services) NodeInfo() (breez_sdk.NodeState, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.NodeInfo()
+}
+
+func (s *Services) SignMessage(req breez_sdk.SignMessageRequest) (breez_sdk.SignMessageResponse, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.SignMessage(req)
+}
+
+func (s *Services) CheckMessage(req breez_sdk.CheckMessageRequest) (breez_sdk.CheckMessageResponse, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.CheckMessage(req)
+}
+
+func (s *Services) BackupStatus() (breez_sdk.BackupStatus, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.BackupStatus()
+}
+
+func (s *Services) Backup() error {
+	s.acquire()
+	defer s.release()
+	return s.inner.Backup()
+}
+
+func (s *Services) ListPayments(req breez_sdk.ListPaymentsRequest) ([]breez_sdk.Payment, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.ListPayments(req)
+}
+
+func (s *Services) PaymentByHash(hash string) (*breez_sdk.Payment, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.PaymentByHash(hash)
+}
+
+func (s *Services) SetPaymentMetadata(hash string, metadata string) error {
+	s.acquire()
+	defer s.release()
+	return s.inner.SetPaymentMetadata(hash, metadata)
+}
+
+func (s *Services) RedeemOnchainFunds(req breez_sdk.RedeemOnchainFundsRequest) (breez_sdk.RedeemOnchainFundsResponse, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.RedeemOnchainFunds(req)
+}
+
+func (s *Services) FetchFiatRates() ([]breez_sdk.Rate, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.FetchFiatRates()
+}
+
+func (s *Services) ListFiatCurrencies() ([]breez_sdk.FiatCurrency, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.ListFiatCurrencies()
+}
+
+func (s *Services) ListLsps() ([]breez_sdk.LspInformation, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.ListLsps()
+}
+
+func (s *Services) ConnectLsp(lspId string) error {
+	s.acquire()
+	defer s.release()
+	return s.inner.ConnectLsp(lspId)
+}
+
+func (s *Services) FetchLspInfo(lspId string) (*breez_sdk.LspInformation, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.FetchLspInfo(lspId)
+}
+
+func (s *Services) OpenChannelFee(req breez_sdk.OpenChannelFeeRequest) (breez_sdk.OpenChannelFeeResponse, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.OpenChannelFee(req)
+}
+
+func (s *Services) LspId() (*string, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.LspId()
+}
+
+func (s *Services) LspInfo() (breez_sdk.LspInformation, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.LspInfo()
+}
+
+func (s *Services) CloseLspChannels() error {
+	s.acquire()
+	defer s.release()
+	return s.inner.CloseLspChannels()
+}
+
+func (s *Services) RegisterWebhook(webhookUrl string) error {
+	s.acquire()
+	defer s.release()
+	return s.inner.RegisterWebhook(webhookUrl)
+}
+
+func (s *Services) UnregisterWebhook(webhookUrl string) error {
+	s.acquire()
+	defer s.release()
+	return s.inner.UnregisterWebhook(webhookUrl)
+}
+
+func (s *Services) ReceiveOnchain(req breez_sdk.ReceiveOnchainRequest) (breez_sdk.SwapInfo, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.ReceiveOnchain(req)
+}
+
+func (s *Services) InProgressSwap() (*breez_sdk.SwapInfo, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.InProgressSwap()
+}
+
+func (s *Services) RescanSwaps() error {
+	s.acquire()
+	defer s.release()
+	return s.inner.RescanSwaps()
+}
+
+func (s *Services) RedeemSwap(swapAddress string) error {
+	s.acquire()
+	defer s.release()
+	return s.inner.RedeemSwap(swapAddress)
+}
+
+func (s *Services) ListRefundables() ([]breez_sdk.SwapInfo, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.ListRefundables()
+}
+
+func (s *Services) PrepareRefund(req breez_sdk.PrepareRefundRequest) (breez_sdk.PrepareRefundResponse, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.PrepareRefund(req)
+}
+
+func (s *Services) Refund(req breez_sdk.RefundRequest) (breez_sdk.RefundResponse, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.Refund(req)
+}
+
+func (s *Services) ListSwaps(req breez_sdk.ListSwapsRequest) ([]breez_sdk.SwapInfo, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.ListSwaps(req)
+}
+
+func (s *Services) FetchReverseSwapFees(req breez_sdk.ReverseSwapFeesRequest) (breez_sdk.ReverseSwapPairInfo, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.FetchReverseSwapFees(req)
+}
+
+func (s *Services) OnchainPaymentLimits() (breez_sdk.OnchainPaymentLimitsResponse, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.OnchainPaymentLimits()
+}
+
+func (s *Services) PrepareOnchainPayment(req breez_sdk.PrepareOnchainPaymentRequest) (breez_sdk.PrepareOnchainPaymentResponse, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.PrepareOnchainPayment(req)
+}
+
+func (s *Services) InProgressOnchainPayments() ([]breez_sdk.ReverseSwapInfo, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.InProgressOnchainPayments()
+}
+
+func (s *Services) ClaimReverseSwap(lockupAddress string) error {
+	s.acquire()
+	defer s.release()
+	return s.inner.ClaimReverseSwap(lockupAddress)
+}
+
+func (s *Services) PayOnchain(req breez_sdk.PayOnchainRequest) (breez_sdk.PayOnchainResponse, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.PayOnchain(req)
+}
+
+func (s *Services) ExecuteDevCommand(command string) (string, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.ExecuteDevCommand(command)
+}
+
+func (s *Services) GenerateDiagnosticData() (string, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.GenerateDiagnosticData()
+}
+
+func (s *Services) Sync() error {
+	s.acquire()
+	defer s.release()
+	return s.inner.Sync()
+}
+
+func (s *Services) RecommendedFees() (breez_sdk.RecommendedFees, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.RecommendedFees()
+}
+
+func (s *Services) BuyBitcoin(req breez_sdk.BuyBitcoinRequest) (breez_sdk.BuyBitcoinResponse, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.BuyBitcoin(req)
+}
+
+func (s *Services) PrepareRedeemOnchainFunds(req breez_sdk.PrepareRedeemOnchainFundsRequest) (breez_sdk.PrepareRedeemOnchainFundsResponse, error) {
+	s.acquire()
+	defer s.release()
+	return s.inner.PrepareRedeemOnchainFunds(req)
+}
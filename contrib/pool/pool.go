@@ -0,0 +1,78 @@
+// Package pool bounds the number of concurrent calls into the Breez SDK
+// library.
+//
+// Concurrency contract: every method of breez_sdk.BlockingBreezServices may be
+// called from any number of goroutines. Each call blocks its goroutine, and
+// the OS thread it runs on, until the library returns, and the library
+// serializes access to the node internally where needed. Event listener and
+// log stream callbacks run on library threads and must not block for long.
+// Close, and Disconnect followed by Destroy, must only be called once no other
+// call is in flight.
+//
+// Under heavy load the number of threads blocked in the library grows with the
+// number of calling goroutines. Wrapping the service limits it:
+//
+//	svc := pool.Wrap(sdk, 4)
+//
+// A limit of 1 serializes all calls.
+package pool
+
+import (
+	"sync/atomic"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+)
+
+// Services implements breez_sdk.BlockingBreezServicesInterface on top of
+// another implementation, allowing at most a fixed number of calls in flight.
+// Further calls block until a slot is released.
+type Services struct {
+	inner    breez_sdk.BlockingBreezServicesInterface
+	slots    chan struct{}
+	inFlight int32
+	waiting  int32
+}
+
+// Wrap returns inner limited to maxInFlight concurrent calls. A maxInFlight
+// below 1 is treated as 1.
+func Wrap(inner breez_sdk.BlockingBreezServicesInterface, maxInFlight int) *Services {
+	if maxInFlight < 1 {
+		maxInFlight = 1
+	}
+	return &Services{
+		inner: inner,
+		slots: make(chan struct{}, maxInFlight),
+	}
+}
+
+// Unwrap returns the wrapped service.
+func (s *Services) Unwrap() breez_sdk.BlockingBreezServicesInterface {
+	return s.inner
+}
+
+// MaxInFlight returns the maximum number of concurrent calls.
+func (s *Services) MaxInFlight() int {
+	return cap(s.slots)
+}
+
+// InFlight returns the number of calls currently running.
+func (s *Services) InFlight() int {
+	return int(atomic.LoadInt32(&s.inFlight))
+}
+
+// Waiting returns the number of calls waiting for a slot.
+func (s *Services) Waiting() int {
+	return int(atomic.LoadInt32(&s.waiting))
+}
+
+func (s *Services) acquire() {
+	atomic.AddInt32(&s.waiting, 1)
+	s.slots <- struct{}{}
+	atomic.AddInt32(&s.waiting, -1)
+	atomic.AddInt32(&s.inFlight, 1)
+}
+
+func (s *Services) release() {
+	atomic.AddInt32(&s.inFlight, -1)
+	<-s.slots
+}
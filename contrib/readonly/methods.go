@@ -0,0 +1,392 @@
+package readonly
+
+import "github.com/breez/breez-sdk-go/breez_sdk"
+
+var _ breez_sdk.BlockingBreezServicesInterface = (*Services)(nil)
+
+func (s *Services) Disconnect() error {
+	if err := s.check("Disconnect"); err != nil {
+		return err
+	}
+	return s.inner.Disconnect()
+}
+
+func (s *Services) ConfigureNode(req breez_sdk.ConfigureNodeRequest) error {
+	if err := s.check("ConfigureNode"); err != nil {
+		return err
+	}
+	return s.inner.ConfigureNode(req)
+}
+
+func (s *Services) SendPayment(req breez_sdk.SendPaymentRequest) (breez_sdk.SendPaymentResponse, error) {
+	if err := s.check("SendPayment"); err != nil {
+		var zero breez_sdk.SendPaymentResponse
+		return zero, err
+	}
+	return s.inner.SendPayment(req)
+}
+
+func (s *Services) SendSpontaneousPayment(req breez_sdk.SendSpontaneousPaymentRequest) (breez_sdk.SendPaymentResponse, error) {
+	if err := s.check("SendSpontaneousPayment"); err != nil {
+		var zero breez_sdk.SendPaymentResponse
+		return zero, err
+	}
+	return s.inner.SendSpontaneousPayment(req)
+}
+
+func (s *Services) ReceivePayment(req breez_sdk.ReceivePaymentRequest) (breez_sdk.ReceivePaymentResponse, error) {
+	if err := s.check("ReceivePayment"); err != nil {
+		var zero breez_sdk.ReceivePaymentResponse
+		return zero, err
+	}
+	return s.inner.ReceivePayment(req)
+}
+
+func (s *Services) PayLnurl(req breez_sdk.LnUrlPayRequest) (breez_sdk.LnUrlPayResult, error) {
+	if err := s.check("PayLnurl"); err != nil {
+		var zero breez_sdk.LnUrlPayResult
+		return zero, err
+	}
+	return s.inner.PayLnurl(req)
+}
+
+func (s *Services) WithdrawLnurl(request breez_sdk.LnUrlWithdrawRequest) (breez_sdk.LnUrlWithdrawResult, error) {
+	if err := s.check("WithdrawLnurl"); err != nil {
+		var zero breez_sdk.LnUrlWithdrawResult
+		return zero, err
+	}
+	return s.inner.WithdrawLnurl(request)
+}
+
+func (s *Services) LnurlAuth(reqData breez_sdk.LnUrlAuthRequestData) (breez_sdk.LnUrlCallbackStatus, error) {
+	if err := s.check("LnurlAuth"); err != nil {
+		var zero breez_sdk.LnUrlCallbackStatus
+		return zero, err
+	}
+	return s.inner.LnurlAuth(reqData)
+}
+
+func (s *Services) ReportIssue(req breez_sdk.ReportIssueRequest) error {
+	if err := s.check("ReportIssue"); err != nil {
+		return err
+	}
+	return s.inner.ReportIssue(req)
+}
+
+func (s *Services) NodeCredentials() (*breez_sdk.NodeCredentials, error) {
+	if err := s.check("NodeCredentials"); err != nil {
+		var zero *breez_sdk.NodeCredentials
+		return zero, err
+	}
+	return s.inner.NodeCredentials()
+}
+
+func (s *Services) NodeInfo() (breez_sdk.NodeState, error) {
+	if err := s.check("NodeInfo"); err != nil {
+		var zero breez_sdk.NodeState
+		return zero, err
+	}
+	return s.inner.NodeInfo()
+}
+
+func (s *Services) SignMessage(req breez_sdk.SignMessageRequest) (breez_sdk.SignMessageResponse, error) {
+	if err := s.check("SignMessage"); err != nil {
+		var zero breez_sdk.SignMessageResponse
+		return zero, err
+	}
+	return s.inner.SignMessage(req)
+}
+
+func (s *Services) CheckMessage(req breez_sdk.CheckMessageRequest) (breez_sdk.CheckMessageResponse, error) {
+	if err := s.check("CheckMessage"); err != nil {
+		var zero breez_sdk.CheckMessageResponse
+		return zero, err
+	}
+	return s.inner.CheckMessage(req)
+}
+
+func (s *Services) BackupStatus() (breez_sdk.BackupStatus, error) {
+	if err := s.check("BackupStatus"); err != nil {
+		var zero breez_sdk.BackupStatus
+		return zero, err
+	}
+	return s.inner.BackupStatus()
+}
+
+func (s *Services) Backup() error {
+	if err := s.check("Backup"); err != nil {
+		return err
+	}
+	return s.inner.Backup()
+}
+
+func (s *Services) ListPayments(req breez_sdk.ListPaymentsRequest) ([]breez_sdk.Payment, error) {
+	if err := s.check("ListPayments"); err != nil {
+		var zero []breez_sdk.Payment
+		return zero, err
+	}
+	return s.inner.ListPayments(req)
+}
+
+func (s *Services) PaymentByHash(hash string) (*breez_sdk.Payment, error) {
+	if err := s.check("PaymentByHash"); err != nil {
+		var zero *breez_sdk.Payment
+		return zero, err
+	}
+	return s.inner.PaymentByHash(hash)
+}
+
+func (s *Services) SetPaymentMetadata(hash string, metadata string) error {
+	if err := s.check("SetPaymentMetadata"); err != nil {
+		return err
+	}
+	return s.inner.SetPaymentMetadata(hash, metadata)
+}
+
+func (s *Services) RedeemOnchainFunds(req breez_sdk.RedeemOnchainFundsRequest) (breez_sdk.RedeemOnchainFundsResponse, error) {
+	if err := s.check("RedeemOnchainFunds"); err != nil {
+		var zero breez_sdk.RedeemOnchainFundsResponse
+		return zero, err
+	}
+	return s.inner.RedeemOnchainFunds(req)
+}
+
+func (s *Services) FetchFiatRates() ([]breez_sdk.Rate, error) {
+	if err := s.check("FetchFiatRates"); err != nil {
+		var zero []breez_sdk.Rate
+		return zero, err
+	}
+	return s.inner.FetchFiatRates()
+}
+
+func (s *Services) ListFiatCurrencies() ([]breez_sdk.FiatCurrency, error) {
+	if err := s.check("ListFiatCurrencies"); err != nil {
+		var zero []breez_sdk.FiatCurrency
+		return zero, err
+	}
+	return s.inner.ListFiatCurrencies()
+}
+
+func (s *Services) ListLsps() ([]breez_sdk.LspInformation, error) {
+	if err := s.check("ListLsps"); err != nil {
+		var zero []breez_sdk.LspInformation
+		return zero, err
+	}
+	return s.inner.ListLsps()
+}
+
+func (s *Services) ConnectLsp(lspId string) error {
+	if err := s.check("ConnectLsp"); err != nil {
+		return err
+	}
+	return s.inner.ConnectLsp(lspId)
+}
+
+func (s *Services) FetchLspInfo(lspId string) (*breez_sdk.LspInformation, error) {
+	if err := s.check("FetchLspInfo"); err != nil {
+		var zero *breez_sdk.LspInformation
+		return zero, err
+	}
+	return s.inner.FetchLspInfo(lspId)
+}
+
+func (s *Services) OpenChannelFee(req breez_sdk.OpenChannelFeeRequest) (breez_sdk.OpenChannelFeeResponse, error) {
+	if err := s.check("OpenChannelFee"); err != nil {
+		var zero breez_sdk.OpenChannelFeeResponse
+		return zero, err
+	}
+	return s.inner.OpenChannelFee(req)
+}
+
+func (s *Services) LspId() (*string, error) {
+	if err := s.check("LspId"); err != nil {
+		var zero *string
+		return zero, err
+	}
+	return s.inner.LspId()
+}
+
+func (s *Services) LspInfo() (breez_sdk.LspInformation, error) {
+	if err := s.check("LspInfo"); err != nil {
+		var zero breez_sdk.LspInformation
+		return zero, err
+	}
+	return s.inner.LspInfo()
+}
+
+func (s *Services) CloseLspChannels() error {
+	if err := s.check("CloseLspChannels"); err != nil {
+		return err
+	}
+	return s.inner.CloseLspChannels()
+}
+
+func (s *Services) RegisterWebhook(webhookUrl string) error {
+	if err := s.check("RegisterWebhook"); err != nil {
+		return err
+	}
+	return s.inner.RegisterWebhook(webhookUrl)
+}
+
+func (s *Services) UnregisterWebhook(webhookUrl string) error {
+	if err := s.check("UnregisterWebhook"); err != nil {
+		return err
+	}
+	return s.inner.UnregisterWebhook(webhookUrl)
+}
+
+func (s *Services) ReceiveOnchain(req breez_sdk.ReceiveOnchainRequest) (breez_sdk.SwapInfo, error) {
+	if err := s.check("ReceiveOnchain"); err != nil {
+		var zero breez_sdk.SwapInfo
+		return zero, err
+	}
+	return s.inner.ReceiveOnchain(req)
+}
+
+func (s *Services) InProgressSwap() (*breez_sdk.SwapInfo, error) {
+	if err := s.check("InProgressSwap"); err != nil {
+		var zero *breez_sdk.SwapInfo
+		return zero, err
+	}
+	return s.inner.InProgressSwap()
+}
+
+func (s *Services) RescanSwaps() error {
+	if err := s.check("RescanSwaps"); err != nil {
+		return err
+	}
+	return s.inner.RescanSwaps()
+}
+
+func (s *Services) RedeemSwap(swapAddress string) error {
+	if err := s.check("RedeemSwap"); err != nil {
+		return err
+	}
+	return s.inner.RedeemSwap(swapAddress)
+}
+
+func (s *Services) ListRefundables() ([]breez_sdk.SwapInfo, error) {
+	if err := s.check("ListRefundables"); err != nil {
+		var zero []breez_sdk.SwapInfo
+		return zero, err
+	}
+	return s.inner.ListRefundables()
+}
+
+func (s *Services) PrepareRefund(req breez_sdk.PrepareRefundRequest) (breez_sdk.PrepareRefundResponse, error) {
+	if err := s.check("PrepareRefund"); err != nil {
+		var zero breez_sdk.PrepareRefundResponse
+		return zero, err
+	}
+	return s.inner.PrepareRefund(req)
+}
+
+func (s *Services) Refund(req breez_sdk.RefundRequest) (breez_sdk.RefundResponse, error) {
+	if err := s.check("Refund"); err != nil {
+		var zero breez_sdk.RefundResponse
+		return zero, err
+	}
+	return s.inner.Refund(req)
+}
+
+func (s *Services) ListSwaps(req breez_sdk.ListSwapsRequest) ([]breez_sdk.SwapInfo, error) {
+	if err := s.check("ListSwaps"); err != nil {
+		var zero []breez_sdk.SwapInfo
+		return zero, err
+	}
+	return s.inner.ListSwaps(req)
+}
+
+func (s *Services) FetchReverseSwapFees(req breez_sdk.ReverseSwapFeesRequest) (breez_sdk.ReverseSwapPairInfo, error) {
+	if err := s.check("FetchReverseSwapFees"); err != nil {
+		var zero breez_sdk.ReverseSwapPairInfo
+		return zero, err
+	}
+	return s.inner.FetchReverseSwapFees(req)
+}
+
+func (s *Services) OnchainPaymentLimits() (breez_sdk.OnchainPaymentLimitsResponse, error) {
+	if err := s.check("OnchainPaymentLimits"); err != nil {
+		var zero breez_sdk.OnchainPaymentLimitsResponse
+		return zero, err
+	}
+	return s.inner.OnchainPaymentLimits()
+}
+
+func (s *Services) PrepareOnchainPayment(req breez_sdk.PrepareOnchainPaymentRequest) (breez_sdk.PrepareOnchainPaymentResponse, error) {
+	if err := s.check("PrepareOnchainPayment"); err != nil {
+		var zero breez_sdk.PrepareOnchainPaymentResponse
+		return zero, err
+	}
+	return s.inner.PrepareOnchainPayment(req)
+}
+
+func (s *Services) InProgressOnchainPayments() ([]breez_sdk.ReverseSwapInfo, error) {
+	if err := s.check("InProgressOnchainPayments"); err != nil {
+		var zero []breez_sdk.ReverseSwapInfo
+		return zero, err
+	}
+	return s.inner.InProgressOnchainPayments()
+}
+
+func (s *Services) ClaimReverseSwap(lockupAddress string) error {
+	if err := s.check("ClaimReverseSwap"); err != nil {
+		return err
+	}
+	return s.inner.ClaimReverseSwap(lockupAddress)
+}
+
+func (s *Services) PayOnchain(req breez_sdk.PayOnchainRequest) (breez_sdk.PayOnchainResponse, error) {
+	if err := s.check("PayOnchain"); err != nil {
+		var zero breez_sdk.PayOnchainResponse
+		return zero, err
+	}
+	return s.inner.PayOnchain(req)
+}
+
+func (s *Services) ExecuteDevCommand(command string) (string, error) {
+	if err := s.check("ExecuteDevCommand"); err != nil {
+		var zero string
+		return zero, err
+	}
+	return s.inner.ExecuteDevCommand(command)
+}
+
+func (s *Services) GenerateDiagnosticData() (string, error) {
+	if err := s.check("GenerateDiagnosticData"); err != nil {
+		var zero string
+		return zero, err
+	}
+	return s.inner.GenerateDiagnosticData()
+}
+
+func (s *Services) Sync() error {
+	if err := s.check("Sync"); err != nil {
+		return err
+	}
+	return s.inner.Sync()
+}
+
+func (s *Services) RecommendedFees() (breez_sdk.RecommendedFees, error) {
+	if err := s.check("RecommendedFees"); err != nil {
+		var zero breez_sdk.RecommendedFees
+		return zero, err
+	}
+	return s.inner.RecommendedFees()
+}
+
+func (s *Services) BuyBitcoin(req breez_sdk.BuyBitcoinRequest) (breez_sdk.BuyBitcoinResponse, error) {
+	if err := s.check("BuyBitcoin"); err != nil {
+		var zero breez_sdk.BuyBitcoinResponse
+		return zero, err
+	}
+	return s.inner.BuyBitcoin(req)
+}
+
+func (s *Services) PrepareRedeemOnchainFunds(req breez_sdk.PrepareRedeemOnchainFundsRequest) (breez_sdk.PrepareRedeemOnchainFundsResponse, error) {
+	if err := s.check("PrepareRedeemOnchainFunds"); err != nil {
+		var zero breez_sdk.PrepareRedeemOnchainFundsResponse
+		return zero, err
+	}
+	return s.inner.PrepareRedeemOnchainFunds(req)
+}
@@ -0,0 +1,86 @@
+// Package readonly opens read-only sessions on a node: listing payments,
+// reading the node state and balances, and creating invoices keep working,
+// while every method that spends funds or signs with the node's keys fails
+// with a *NotPermittedError:
+//
+//	svc, err := readonly.Connect(req, listener)
+//
+// The restriction is enforced on the Go side. The library still holds the
+// seed from the ConnectRequest, so code with access to the wrapped service
+// can spend; hand out only the readonly.Services.
+package readonly
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+)
+
+// ErrNotPermitted is matched by every *NotPermittedError with errors.Is.
+var ErrNotPermitted = errors.New("not permitted in a read-only session")
+
+// NotPermittedError is returned by the methods blocked in a read-only
+// session.
+type NotPermittedError struct {
+	Method string
+}
+
+func (err *NotPermittedError) Error() string {
+	return fmt.Sprintf("%s: %v", err.Method, ErrNotPermitted)
+}
+
+func (err *NotPermittedError) Is(target error) bool {
+	return target == ErrNotPermitted
+}
+
+// Blocked lists the methods a read-only session refuses: those moving funds
+// out of the node, signing with its keys or exporting them, changing where
+// closed channels pay to, and dev commands.
+var Blocked = map[string]bool{
+	"ConfigureNode":          true,
+	"SendPayment":            true,
+	"SendSpontaneousPayment": true,
+	"PayLnurl":               true,
+	"LnurlAuth":              true,
+	"NodeCredentials":        true,
+	"SignMessage":            true,
+	"RedeemOnchainFunds":     true,
+	"CloseLspChannels":       true,
+	"RedeemSwap":             true,
+	"Refund":                 true,
+	"ClaimReverseSwap":       true,
+	"PayOnchain":             true,
+	"ExecuteDevCommand":      true,
+}
+
+// Services implements breez_sdk.BlockingBreezServicesInterface on top of
+// another implementation, refusing the Blocked methods.
+type Services struct {
+	inner breez_sdk.BlockingBreezServicesInterface
+}
+
+// Wrap returns a read-only view of inner.
+func Wrap(inner breez_sdk.BlockingBreezServicesInterface) *Services {
+	return &Services{inner: inner}
+}
+
+// Connect connects like breez_sdk.ConnectService with req.RestoreOnly set, so that
+// no new node is registered for an unknown seed, and returns a read-only view
+// of the node.
+func Connect(req breez_sdk.ConnectRequest, listener breez_sdk.EventListener) (*Services, error) {
+	restoreOnly := true
+	req.RestoreOnly = &restoreOnly
+	sdk, err := breez_sdk.ConnectService(req, listener)
+	if err != nil {
+		return nil, err
+	}
+	return Wrap(sdk), nil
+}
+
+func (s *Services) check(method string) error {
+	if Blocked[method] {
+		return &NotPermittedError{Method: method}
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+// Package regtest connects a node to a regtest deployment of the Breez
+// services for integration tests, and drives the test network through a
+// faucet service.
+//
+// The bindings have no regtest environment, so the endpoints of the
+// deployment are part of the Config. ConfigFromEnv reads them from the
+// environment, which lets CI jobs opt in:
+//
+//	cfg, ok := regtest.ConfigFromEnv()
+//	if !ok {
+//		t.Skip("regtest environment not configured")
+//	}
+//	h, err := regtest.Start(cfg, nil)
+//	...
+//	defer h.Close()
+//	if _, err := h.Fund(ctx, 50_000); err != nil {
+//		t.Fatal(err)
+//	}
+//
+// The faucet is a small HTTP service run next to the regtest network, which
+// has a funded bitcoind wallet and a Lightning test peer. It accepts JSON POST
+// requests on three paths:
+//
+//	/mine  {"blocks": 6}                           -> {"block_hashes": ["..."]}
+//	/pay   {"bolt11": "lnbcrt..."}                 -> {"payment_preimage": "..."}
+//	/send  {"address": "bcrt1...", "amount_sat": 1} -> {"txid": "..."}
+//
+// Any status other than 200 is an error, whose body is its message.
+package regtest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+)
+
+// Environment variables read by ConfigFromEnv.
+const (
+	EnvFaucetURL        = "BREEZ_REGTEST_FAUCET_URL"
+	EnvBreezserver      = "BREEZ_REGTEST_BREEZSERVER"
+	EnvChainnotifierUrl = "BREEZ_REGTEST_CHAINNOTIFIER_URL"
+	EnvMempoolspaceUrl  = "BREEZ_REGTEST_MEMPOOLSPACE_URL"
+	EnvApiKey           = "BREEZ_REGTEST_API_KEY"
+	EnvInviteCode       = "BREEZ_REGTEST_INVITE_CODE"
+)
+
+// Config describes the regtest deployment.
+type Config struct {
+	FaucetURL        string
+	Breezserver      string
+	ChainnotifierUrl string
+	MempoolspaceUrl  string
+	ApiKey           string
+	InviteCode       string
+	// WorkingDir defaults to a temporary directory, removed by Close.
+	WorkingDir string
+	// Seed defaults to a random seed, so that every Start gets a new node.
+	Seed []uint8
+	// Client defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// ConfigFromEnv reads the Config from the Env variables. It returns false if
+// the faucet or the Breez server is not set.
+func ConfigFromEnv() (Config, bool) {
+	cfg := Config{
+		FaucetURL:        os.Getenv(EnvFaucetURL),
+		Breezserver:      os.Getenv(EnvBreezserver),
+		ChainnotifierUrl: os.Getenv(EnvChainnotifierUrl),
+		MempoolspaceUrl:  os.Getenv(EnvMempoolspaceUrl),
+		ApiKey:           os.Getenv(EnvApiKey),
+		InviteCode:       os.Getenv(EnvInviteCode),
+	}
+	return cfg, cfg.FaucetURL != "" && cfg.Breezserver != ""
+}
+
+// Harness is a node connected to the regtest deployment.
+type Harness struct {
+	Services *breez_sdk.Services
+	cfg      Config
+	tempDir  string
+}
+
+// Start connects a node to the deployment described by cfg. Events go to
+// listener, which may be nil.
+func Start(cfg Config, listener breez_sdk.EventListener) (*Harness, error) {
+	h := &Harness{cfg: cfg}
+	if h.cfg.Client == nil {
+		h.cfg.Client = http.DefaultClient
+	}
+	if h.cfg.WorkingDir == "" {
+		dir, err := os.MkdirTemp("", "breez-regtest-")
+		if err != nil {
+			return nil, err
+		}
+		h.cfg.WorkingDir = dir
+		h.tempDir = dir
+	}
+	if len(h.cfg.Seed) == 0 {
+		h.cfg.Seed = make([]uint8, 64)
+		if _, err := rand.Read(h.cfg.Seed); err != nil {
+			h.removeTempDir()
+			return nil, err
+		}
+	}
+
+	nodeConfig := breez_sdk.GreenlightNodeConfig{}
+	if h.cfg.InviteCode != "" {
+		nodeConfig.InviteCode = &h.cfg.InviteCode
+	}
+	config := breez_sdk.DefaultConfig(breez_sdk.EnvironmentTypeStaging, h.cfg.ApiKey, breez_sdk.NodeConfigGreenlight{Config: nodeConfig})
+	config.Network = breez_sdk.NetworkRegtest
+	config.WorkingDir = h.cfg.WorkingDir
+	config.Breezserver = h.cfg.Breezserver
+	if h.cfg.ChainnotifierUrl != "" {
+		config.ChainnotifierUrl = h.cfg.ChainnotifierUrl
+	}
+	if h.cfg.MempoolspaceUrl != "" {
+		config.MempoolspaceUrl = &h.cfg.MempoolspaceUrl
+	}
+
+	services, err := breez_sdk.ConnectService(breez_sdk.ConnectRequest{Config: config, Seed: h.cfg.Seed}, listener)
+	if err != nil {
+		h.removeTempDir()
+		return nil, err
+	}
+	h.Services = services
+	return h, nil
+}
+
+// Close closes the node and removes the temporary working directory.
+func (h *Harness) Close() error {
+	err := h.Services.Close()
+	h.removeTempDir()
+	return err
+}
+
+func (h *Harness) removeTempDir() {
+	if h.tempDir != "" {
+		os.RemoveAll(h.tempDir)
+	}
+}
+
+// MineBlocks mines n blocks and returns their hashes.
+func (h *Harness) MineBlocks(ctx context.Context, n int) ([]string, error) {
+	var resp struct {
+		BlockHashes []string `json:"block_hashes"`
+	}
+	err := h.faucet(ctx, "/mine", map[string]interface{}{"blocks": n}, &resp)
+	return resp.BlockHashes, err
+}
+
+// PayInvoiceFromTestPeer has the Lightning test peer pay bolt11 and returns
+// the preimage.
+func (h *Harness) PayInvoiceFromTestPeer(ctx context.Context, bolt11 string) (string, error) {
+	var resp struct {
+		PaymentPreimage string `json:"payment_preimage"`
+	}
+	err := h.faucet(ctx, "/pay", map[string]interface{}{"bolt11": bolt11}, &resp)
+	return resp.PaymentPreimage, err
+}
+
+// SendToAddress sends amountSat from the faucet wallet to address, such as
+// the swap address of ReceiveOnchain, and returns the transaction id. The
+// transaction still has to be mined with MineBlocks.
+func (h *Harness) SendToAddress(ctx context.Context, address string, amountSat uint64) (string, error) {
+	var resp struct {
+		Txid string `json:"txid"`
+	}
+	err := h.faucet(ctx, "/send", map[string]interface{}{"address": address, "amount_sat": amountSat}, &resp)
+	return resp.Txid, err
+}
+
+// Fund receives amountSat over Lightning from the test peer and waits until
+// the payment is complete. The first payment opens a channel with the LSP,
+// whose fee is deducted from the amount.
+func (h *Harness) Fund(ctx context.Context, amountSat uint64) (breez_sdk.Payment, error) {
+	received, err := h.Services.ReceivePayment(breez_sdk.ReceivePaymentRequest{
+		AmountMsat:  amountSat * 1000,
+		Description: "regtest funding",
+	})
+	if err != nil {
+		return breez_sdk.Payment{}, err
+	}
+
+	// The test peer only returns once the payment settled, so the node is
+	// already waiting for it.
+	paid := make(chan error, 1)
+	go func() {
+		_, err := h.PayInvoiceFromTestPeer(ctx, received.LnInvoice.Bolt11)
+		paid <- err
+	}()
+	payment, err := h.Services.WaitForPayment(ctx, received.LnInvoice.PaymentHash)
+	if err != nil {
+		return payment, err
+	}
+	if err := <-paid; err != nil {
+		return payment, err
+	}
+	return payment, nil
+}
+
+func (h *Harness) faucet(ctx context.Context, path string, body, resp interface{}) error {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(h.cfg.FaucetURL, "/")+path, bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := h.cfg.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("faucet %s: %s: %s", path, res.Status, strings.TrimSpace(string(data)))
+	}
+	return json.Unmarshal(data, resp)
+}
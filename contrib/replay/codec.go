@@ -0,0 +1,158 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// DefaultRedactedFields are the struct fields Record replaces with their zero
+// value: secrets that must not end up in a bug report.
+var DefaultRedactedFields = []string{
+	"Device",
+	"DeveloperKey",
+	"DeveloperCert",
+	"PaymentPreimage",
+	"Preimage",
+	"PaymentSecret",
+	"ApiKey",
+	"InviteCode",
+}
+
+// taggedValue is the JSON form of a value stored in an interface, such as
+// PaymentDetails, which needs its concrete type to be decoded again.
+type taggedValue struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+type encoder struct {
+	redacted map[string]bool
+}
+
+// encode converts v into a value encoding/json can marshal, tagging variants
+// of the SDK's interface types and leaving out redacted fields.
+func (e *encoder) encode(v reflect.Value) (interface{}, error) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil, nil
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return e.encode(v.Elem())
+	case reflect.Struct:
+		fields := map[string]interface{}{}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() || e.redacted[field.Name] {
+				continue
+			}
+			value, err := e.encode(v.Field(i))
+			if err != nil {
+				return nil, err
+			}
+			fields[field.Name] = value
+		}
+		if name := v.Type().Name(); variantTypes[name] != nil {
+			value, err := json.Marshal(fields)
+			if err != nil {
+				return nil, err
+			}
+			return taggedValue{Type: name, Value: value}, nil
+		}
+		return fields, nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			item, err := e.encode(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Interface(), nil
+	default:
+		return nil, fmt.Errorf("cannot record values of type %s", v.Type())
+	}
+}
+
+// decode sets v from data written by encode.
+func decode(data json.RawMessage, v reflect.Value) error {
+	if len(data) == 0 || string(data) == "null" {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		var tagged taggedValue
+		if err := json.Unmarshal(data, &tagged); err != nil {
+			return err
+		}
+		variant := variantTypes[tagged.Type]
+		if variant == nil || !variant.Implements(v.Type()) {
+			return fmt.Errorf("%s is not a variant of %s", tagged.Type, v.Type())
+		}
+		value := reflect.New(variant).Elem()
+		if err := decodeStruct(tagged.Value, value); err != nil {
+			return err
+		}
+		v.Set(value)
+		return nil
+	case reflect.Pointer:
+		value := reflect.New(v.Type().Elem())
+		if err := decode(data, value.Elem()); err != nil {
+			return err
+		}
+		v.Set(value)
+		return nil
+	case reflect.Struct:
+		if variantTypes[v.Type().Name()] != nil {
+			var tagged taggedValue
+			if err := json.Unmarshal(data, &tagged); err != nil {
+				return err
+			}
+			data = tagged.Value
+		}
+		return decodeStruct(data, v)
+	case reflect.Slice:
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decode(item, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	default:
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+}
+
+func decodeStruct(data json.RawMessage, v reflect.Value) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if err := decode(fields[field.Name], v.Field(i)); err != nil {
+			return fmt.Errorf("%s.%s: %w", v.Type().Name(), field.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,582 @@
+package replay
+
+import "github.com/breez/breez-sdk-go/breez_sdk"
+
+var (
+	_ breez_sdk.BlockingBreezServicesInterface = (*Recorder)(nil)
+	_ breez_sdk.BlockingBreezServicesInterface = (*Replayer)(nil)
+)
+
+func (r *Recorder) Disconnect() error {
+	err := r.inner.Disconnect()
+	r.record("Disconnect", nil, err)
+	return err
+}
+
+func (r *Recorder) ConfigureNode(req breez_sdk.ConfigureNodeRequest) error {
+	err := r.inner.ConfigureNode(req)
+	r.record("ConfigureNode", nil, err, req)
+	return err
+}
+
+func (r *Recorder) SendPayment(req breez_sdk.SendPaymentRequest) (breez_sdk.SendPaymentResponse, error) {
+	res, err := r.inner.SendPayment(req)
+	r.record("SendPayment", res, err, req)
+	return res, err
+}
+
+func (r *Recorder) SendSpontaneousPayment(req breez_sdk.SendSpontaneousPaymentRequest) (breez_sdk.SendPaymentResponse, error) {
+	res, err := r.inner.SendSpontaneousPayment(req)
+	r.record("SendSpontaneousPayment", res, err, req)
+	return res, err
+}
+
+func (r *Recorder) ReceivePayment(req breez_sdk.ReceivePaymentRequest) (breez_sdk.ReceivePaymentResponse, error) {
+	res, err := r.inner.ReceivePayment(req)
+	r.record("ReceivePayment", res, err, req)
+	return res, err
+}
+
+func (r *Recorder) PayLnurl(req breez_sdk.LnUrlPayRequest) (breez_sdk.LnUrlPayResult, error) {
+	res, err := r.inner.PayLnurl(req)
+	r.record("PayLnurl", res, err, req)
+	return res, err
+}
+
+func (r *Recorder) WithdrawLnurl(request breez_sdk.LnUrlWithdrawRequest) (breez_sdk.LnUrlWithdrawResult, error) {
+	res, err := r.inner.WithdrawLnurl(request)
+	r.record("WithdrawLnurl", res, err, request)
+	return res, err
+}
+
+func (r *Recorder) LnurlAuth(reqData breez_sdk.LnUrlAuthRequestData) (breez_sdk.LnUrlCallbackStatus, error) {
+	res, err := r.inner.LnurlAuth(reqData)
+	r.record("LnurlAuth", res, err, reqData)
+	return res, err
+}
+
+func (r *Recorder) ReportIssue(req breez_sdk.ReportIssueRequest) error {
+	err := r.inner.ReportIssue(req)
+	r.record("ReportIssue", nil, err, req)
+	return err
+}
+
+func (r *Recorder) NodeCredentials() (*breez_sdk.NodeCredentials, error) {
+	res, err := r.inner.NodeCredentials()
+	r.record("NodeCredentials", res, err)
+	return res, err
+}
+
+func (r *Recorder) NodeInfo() (breez_sdk.NodeState, error) {
+	res, err := r.inner.NodeInfo()
+	r.record("NodeInfo", res, err)
+	return res, err
+}
+
+func (r *Recorder) SignMessage(req breez_sdk.SignMessageRequest) (breez_sdk.SignMessageResponse, error) {
+	res, err := r.inner.SignMessage(req)
+	r.record("SignMessage", res, err, req)
+	return res, err
+}
+
+func (r *Recorder) CheckMessage(req breez_sdk.CheckMessageRequest) (breez_sdk.CheckMessageResponse, error) {
+	res, err := r.inner.CheckMessage(req)
+	r.record("CheckMessage", res, err, req)
+	return res, err
+}
+
+func (r *Recorder) BackupStatus() (breez_sdk.BackupStatus, error) {
+	res, err := r.inner.BackupStatus()
+	r.record("BackupStatus", res, err)
+	return res, err
+}
+
+func (r *Recorder) Backup() error {
+	err := r.inner.Backup()
+	r.record("Backup", nil, err)
+	return err
+}
+
+func (r *Recorder) ListPayments(req breez_sdk.ListPaymentsRequest) ([]breez_sdk.Payment, error) {
+	res, err := r.inner.ListPayments(req)
+	r.record("ListPayments", res, err, req)
+	return res, err
+}
+
+func (r *Recorder) PaymentByHash(hash string) (*breez_sdk.Payment, error) {
+	res, err := r.inner.PaymentByHash(hash)
+	r.record("PaymentByHash", res, err, hash)
+	return res, err
+}
+
+func (r *Recorder) SetPaymentMetadata(hash string, metadata string) error {
+	err := r.inner.SetPaymentMetadata(hash, metadata)
+	r.record("SetPaymentMetadata", nil, err, hash, metadata)
+	return err
+}
+
+func (r *Recorder) RedeemOnchainFunds(req breez_sdk.RedeemOnchainFundsRequest) (breez_sdk.RedeemOnchainFundsResponse, error) {
+	res, err := r.inner.RedeemOnchainFunds(req)
+	r.record("RedeemOnchainFunds", res, err, req)
+	return res, err
+}
+
+func (r *Recorder) FetchFiatRates() ([]breez_sdk.Rate, error) {
+	res, err := r.inner.FetchFiatRates()
+	r.record("FetchFiatRates", res, err)
+	return res, err
+}
+
+func (r *Recorder) ListFiatCurrencies() ([]breez_sdk.FiatCurrency, error) {
+	res, err := r.inner.ListFiatCurrencies()
+	r.record("ListFiatCurrencies", res, err)
+	return res, err
+}
+
+func (r *Recorder) ListLsps() ([]breez_sdk.LspInformation, error) {
+	res, err := r.inner.ListLsps()
+	r.record("ListLsps", res, err)
+	return res, err
+}
+
+func (r *Recorder) ConnectLsp(lspId string) error {
+	err := r.inner.ConnectLsp(lspId)
+	r.record("ConnectLsp", nil, err, lspId)
+	return err
+}
+
+func (r *Recorder) FetchLspInfo(lspId string) (*breez_sdk.LspInformation, error) {
+	res, err := r.inner.FetchLspInfo(lspId)
+	r.record("FetchLspInfo", res, err, lspId)
+	return res, err
+}
+
+func (r *Recorder) OpenChannelFee(req breez_sdk.OpenChannelFeeRequest) (breez_sdk.OpenChannelFeeResponse, error) {
+	res, err := r.inner.OpenChannelFee(req)
+	r.record("OpenChannelFee", res, err, req)
+	return res, err
+}
+
+func (r *Recorder) LspId() (*string, error) {
+	res, err := r.inner.LspId()
+	r.record("LspId", res, err)
+	return res, err
+}
+
+func (r *Recorder) LspInfo() (breez_sdk.LspInformation, error) {
+	res, err := r.inner.LspInfo()
+	r.record("LspInfo", res, err)
+	return res, err
+}
+
+func (r *Recorder) CloseLspChannels() error {
+	err := r.inner.CloseLspChannels()
+	r.record("CloseLspChannels", nil, err)
+	return err
+}
+
+func (r *Recorder) RegisterWebhook(webhookUrl string) error {
+	err := r.inner.RegisterWebhook(webhookUrl)
+	r.record("RegisterWebhook", nil, err, webhookUrl)
+	return err
+}
+
+func (r *Recorder) UnregisterWebhook(webhookUrl string) error {
+	err := r.inner.UnregisterWebhook(webhookUrl)
+	r.record("UnregisterWebhook", nil, err, webhookUrl)
+	return err
+}
+
+func (r *Recorder) ReceiveOnchain(req breez_sdk.ReceiveOnchainRequest) (breez_sdk.SwapInfo, error) {
+	res, err := r.inner.ReceiveOnchain(req)
+	r.record("ReceiveOnchain", res, err, req)
+	return res, err
+}
+
+func (r *Recorder) InProgressSwap() (*breez_sdk.SwapInfo, error) {
+	res, err := r.inner.InProgressSwap()
+	r.record("InProgressSwap", res, err)
+	return res, err
+}
+
+func (r *Recorder) RescanSwaps() error {
+	err := r.inner.RescanSwaps()
+	r.record("RescanSwaps", nil, err)
+	return err
+}
+
+func (r *Recorder) RedeemSwap(swapAddress string) error {
+	err := r.inner.RedeemSwap(swapAddress)
+	r.record("RedeemSwap", nil, err, swapAddress)
+	return err
+}
+
+func (r *Recorder) ListRefundables() ([]breez_sdk.SwapInfo, error) {
+	res, err := r.inner.ListRefundables()
+	r.record("ListRefundables", res, err)
+	return res, err
+}
+
+func (r *Recorder) PrepareRefund(req breez_sdk.PrepareRefundRequest) (breez_sdk.PrepareRefundResponse, error) {
+	res, err := r.inner.PrepareRefund(req)
+	r.record("PrepareRefund", res, err, req)
+	return res, err
+}
+
+func (r *Recorder) Refund(req breez_sdk.RefundRequest) (breez_sdk.RefundResponse, error) {
+	res, err := r.inner.Refund(req)
+	r.record("Refund", res, err, req)
+	return res, err
+}
+
+func (r *Recorder) ListSwaps(req breez_sdk.ListSwapsRequest) ([]breez_sdk.SwapInfo, error) {
+	res, err := r.inner.ListSwaps(req)
+	r.record("ListSwaps", res, err, req)
+	return res, err
+}
+
+func (r *Recorder) FetchReverseSwapFees(req breez_sdk.ReverseSwapFeesRequest) (breez_sdk.ReverseSwapPairInfo, error) {
+	res, err := r.inner.FetchReverseSwapFees(req)
+	r.record("FetchReverseSwapFees", res, err, req)
+	return res, err
+}
+
+func (r *Recorder) OnchainPaymentLimits() (breez_sdk.OnchainPaymentLimitsResponse, error) {
+	res, err := r.inner.OnchainPaymentLimits()
+	r.record("OnchainPaymentLimits", res, err)
+	return res, err
+}
+
+func (r *Recorder) PrepareOnchainPayment(req breez_sdk.PrepareOnchainPaymentRequest) (breez_sdk.PrepareOnchainPaymentResponse, error) {
+	res, err := r.inner.PrepareOnchainPayment(req)
+	r.record("PrepareOnchainPayment", res, err, req)
+	return res, err
+}
+
+func (r *Recorder) InProgressOnchainPayments() ([]breez_sdk.ReverseSwapInfo, error) {
+	res, err := r.inner.InProgressOnchainPayments()
+	r.record("InProgressOnchainPayments", res, err)
+	return res, err
+}
+
+func (r *Recorder) ClaimReverseSwap(lockupAddress string) error {
+	err := r.inner.ClaimReverseSwap(lockupAddress)
+	r.record("ClaimReverseSwap", nil, err, lockupAddress)
+	return err
+}
+
+func (r *Recorder) PayOnchain(req breez_sdk.PayOnchainRequest) (breez_sdk.PayOnchainResponse, error) {
+	res, err := r.inner.PayOnchain(req)
+	r.record("PayOnchain", res, err, req)
+	return res, err
+}
+
+func (r *Recorder) ExecuteDevCommand(command string) (string, error) {
+	res, err := r.inner.ExecuteDevCommand(command)
+	r.record("ExecuteDevCommand", res, err, command)
+	return res, err
+}
+
+func (r *Recorder) GenerateDiagnosticData() (string, error) {
+	res, err := r.inner.GenerateDiagnosticData()
+	r.record("GenerateDiagnosticData", res, err)
+	return res, err
+}
+
+func (r *Recorder) Sync() error {
+	err := r.inner.Sync()
+	r.record("Sync", nil, err)
+	return err
+}
+
+func (r *Recorder) RecommendedFees() (breez_sdk.RecommendedFees, error) {
+	res, err := r.inner.RecommendedFees()
+	r.record("RecommendedFees", res, err)
+	return res, err
+}
+
+func (r *Recorder) BuyBitcoin(req breez_sdk.BuyBitcoinRequest) (breez_sdk.BuyBitcoinResponse, error) {
+	res, err := r.inner.BuyBitcoin(req)
+	r.record("BuyBitcoin", res, err, req)
+	return res, err
+}
+
+func (r *Recorder) PrepareRedeemOnchainFunds(req breez_sdk.PrepareRedeemOnchainFundsRequest) (breez_sdk.PrepareRedeemOnchainFundsResponse, error) {
+	res, err := r.inner.PrepareRedeemOnchainFunds(req)
+	r.record("PrepareRedeemOnchainFunds", res, err, req)
+	return res, err
+}
+
+func (r *Replayer) Disconnect() error {
+	return r.replay("Disconnect", nil)
+}
+
+func (r *Replayer) ConfigureNode(req breez_sdk.ConfigureNodeRequest) error {
+	return r.replay("ConfigureNode", nil)
+}
+
+func (r *Replayer) SendPayment(req breez_sdk.SendPaymentRequest) (breez_sdk.SendPaymentResponse, error) {
+	var res breez_sdk.SendPaymentResponse
+	err := r.replay("SendPayment", &res)
+	return res, err
+}
+
+func (r *Replayer) SendSpontaneousPayment(req breez_sdk.SendSpontaneousPaymentRequest) (breez_sdk.SendPaymentResponse, error) {
+	var res breez_sdk.SendPaymentResponse
+	err := r.replay("SendSpontaneousPayment", &res)
+	return res, err
+}
+
+func (r *Replayer) ReceivePayment(req breez_sdk.ReceivePaymentRequest) (breez_sdk.ReceivePaymentResponse, error) {
+	var res breez_sdk.ReceivePaymentResponse
+	err := r.replay("ReceivePayment", &res)
+	return res, err
+}
+
+func (r *Replayer) PayLnurl(req breez_sdk.LnUrlPayRequest) (breez_sdk.LnUrlPayResult, error) {
+	var res breez_sdk.LnUrlPayResult
+	err := r.replay("PayLnurl", &res)
+	return res, err
+}
+
+func (r *Replayer) WithdrawLnurl(request breez_sdk.LnUrlWithdrawRequest) (breez_sdk.LnUrlWithdrawResult, error) {
+	var res breez_sdk.LnUrlWithdrawResult
+	err := r.replay("WithdrawLnurl", &res)
+	return res, err
+}
+
+func (r *Replayer) LnurlAuth(reqData breez_sdk.LnUrlAuthRequestData) (breez_sdk.LnUrlCallbackStatus, error) {
+	var res breez_sdk.LnUrlCallbackStatus
+	err := r.replay("LnurlAuth", &res)
+	return res, err
+}
+
+func (r *Replayer) ReportIssue(req breez_sdk.ReportIssueRequest) error {
+	return r.replay("ReportIssue", nil)
+}
+
+func (r *Replayer) NodeCredentials() (*breez_sdk.NodeCredentials, error) {
+	var res *breez_sdk.NodeCredentials
+	err := r.replay("NodeCredentials", &res)
+	return res, err
+}
+
+func (r *Replayer) NodeInfo() (breez_sdk.NodeState, error) {
+	var res breez_sdk.NodeState
+	err := r.replay("NodeInfo", &res)
+	return res, err
+}
+
+func (r *Replayer) SignMessage(req breez_sdk.SignMessageRequest) (breez_sdk.SignMessageResponse, error) {
+	var res breez_sdk.SignMessageResponse
+	err := r.replay("SignMessage", &res)
+	return res, err
+}
+
+func (r *Replayer) CheckMessage(req breez_sdk.CheckMessageRequest) (breez_sdk.CheckMessageResponse, error) {
+	var res breez_sdk.CheckMessageResponse
+	err := r.replay("CheckMessage", &res)
+	return res, err
+}
+
+func (r *Replayer) BackupStatus() (breez_sdk.BackupStatus, error) {
+	var res breez_sdk.BackupStatus
+	err := r.replay("BackupStatus", &res)
+	return res, err
+}
+
+func (r *Replayer) Backup() error {
+	return r.replay("Backup", nil)
+}
+
+func (r *Replayer) ListPayments(req breez_sdk.ListPaymentsRequest) ([]breez_sdk.Payment, error) {
+	var res []breez_sdk.Payment
+	err := r.replay("ListPayments", &res)
+	return res, err
+}
+
+func (r *Replayer) PaymentByHash(hash string) (*breez_sdk.Payment, error) {
+	var res *breez_sdk.Payment
+	err := r.replay("PaymentByHash", &res)
+	return res, err
+}
+
+func (r *Replayer) SetPaymentMetadata(hash string, metadata string) error {
+	return r.replay("SetPaymentMetadata", nil)
+}
+
+func (r *Replayer) RedeemOnchainFunds(req breez_sdk.RedeemOnchainFundsRequest) (breez_sdk.RedeemOnchainFundsResponse, error) {
+	var res breez_sdk.RedeemOnchainFundsResponse
+	err := r.replay("RedeemOnchainFunds", &res)
+	return res, err
+}
+
+func (r *Replayer) FetchFiatRates() ([]breez_sdk.Rate, error) {
+	var res []breez_sdk.Rate
+	err := r.replay("FetchFiatRates", &res)
+	return res, err
+}
+
+func (r *Replayer) ListFiatCurrencies() ([]breez_sdk.FiatCurrency, error) {
+	var res []breez_sdk.FiatCurrency
+	err := r.replay("ListFiatCurrencies", &res)
+	return res, err
+}
+
+func (r *Replayer) ListLsps() ([]breez_sdk.LspInformation, error) {
+	var res []breez_sdk.LspInformation
+	err := r.replay("ListLsps", &res)
+	return res, err
+}
+
+func (r *Replayer) ConnectLsp(lspId string) error {
+	return r.replay("ConnectLsp", nil)
+}
+
+func (r *Replayer) FetchLspInfo(lspId string) (*breez_sdk.LspInformation, error) {
+	var res *breez_sdk.LspInformation
+	err := r.replay("FetchLspInfo", &res)
+	return res, err
+}
+
+func (r *Replayer) OpenChannelFee(req breez_sdk.OpenChannelFeeRequest) (breez_sdk.OpenChannelFeeResponse, error) {
+	var res breez_sdk.OpenChannelFeeResponse
+	err := r.replay("OpenChannelFee", &res)
+	return res, err
+}
+
+func (r *Replayer) LspId() (*string, error) {
+	var res *string
+	err := r.replay("LspId", &res)
+	return res, err
+}
+
+func (r *Replayer) LspInfo() (breez_sdk.LspInformation, error) {
+	var res breez_sdk.LspInformation
+	err := r.replay("LspInfo", &res)
+	return res, err
+}
+
+func (r *Replayer) CloseLspChannels() error {
+	return r.replay("CloseLspChannels", nil)
+}
+
+func (r *Replayer) RegisterWebhook(webhookUrl string) error {
+	return r.replay("RegisterWebhook", nil)
+}
+
+func (r *Replayer) UnregisterWebhook(webhookUrl string) error {
+	return r.replay("UnregisterWebhook", nil)
+}
+
+func (r *Replayer) ReceiveOnchain(req breez_sdk.ReceiveOnchainRequest) (breez_sdk.SwapInfo, error) {
+	var res breez_sdk.SwapInfo
+	err := r.replay("ReceiveOnchain", &res)
+	return res, err
+}
+
+func (r *Replayer) InProgressSwap() (*breez_sdk.SwapInfo, error) {
+	var res *breez_sdk.SwapInfo
+	err := r.replay("InProgressSwap", &res)
+	return res, err
+}
+
+func (r *Replayer) RescanSwaps() error {
+	return r.replay("RescanSwaps", nil)
+}
+
+func (r *Replayer) RedeemSwap(swapAddress string) error {
+	return r.replay("RedeemSwap", nil)
+}
+
+func (r *Replayer) ListRefundables() ([]breez_sdk.SwapInfo, error) {
+	var res []breez_sdk.SwapInfo
+	err := r.replay("ListRefundables", &res)
+	return res, err
+}
+
+func (r *Replayer) PrepareRefund(req breez_sdk.PrepareRefundRequest) (breez_sdk.PrepareRefundResponse, error) {
+	var res breez_sdk.PrepareRefundResponse
+	err := r.replay("PrepareRefund", &res)
+	return res, err
+}
+
+func (r *Replayer) Refund(req breez_sdk.RefundRequest) (breez_sdk.RefundResponse, error) {
+	var res breez_sdk.RefundResponse
+	err := r.replay("Refund", &res)
+	return res, err
+}
+
+func (r *Replayer) ListSwaps(req breez_sdk.ListSwapsRequest) ([]breez_sdk.SwapInfo, error) {
+	var res []breez_sdk.SwapInfo
+	err := r.replay("ListSwaps", &res)
+	return res, err
+}
+
+func (r *Replayer) FetchReverseSwapFees(req breez_sdk.ReverseSwapFeesRequest) (breez_sdk.ReverseSwapPairInfo, error) {
+	var res breez_sdk.ReverseSwapPairInfo
+	err := r.replay("FetchReverseSwapFees", &res)
+	return res, err
+}
+
+func (r *Replayer) OnchainPaymentLimits() (breez_sdk.OnchainPaymentLimitsResponse, error) {
+	var res breez_sdk.OnchainPaymentLimitsResponse
+	err := r.replay("OnchainPaymentLimits", &res)
+	return res, err
+}
+
+func (r *Replayer) PrepareOnchainPayment(req breez_sdk.PrepareOnchainPaymentRequest) (breez_sdk.PrepareOnchainPaymentResponse, error) {
+	var res breez_sdk.PrepareOnchainPaymentResponse
+	err := r.replay("PrepareOnchainPayment", &res)
+	return res, err
+}
+
+func (r *Replayer) InProgressOnchainPayments() ([]breez_sdk.ReverseSwapInfo, error) {
+	var res []breez_sdk.ReverseSwapInfo
+	err := r.replay("InProgressOnchainPayments", &res)
+	return res, err
+}
+
+func (r *Replayer) ClaimReverseSwap(lockupAddress string) error {
+	return r.replay("ClaimReverseSwap", nil)
+}
+
+func (r *Replayer) PayOnchain(req breez_sdk.PayOnchainRequest) (breez_sdk.PayOnchainResponse, error) {
+	var res breez_sdk.PayOnchainResponse
+	err := r.replay("PayOnchain", &res)
+	return res, err
+}
+
+func (r *Replayer) ExecuteDevCommand(command string) (string, error) {
+	var res string
+	err := r.replay("ExecuteDevCommand", &res)
+	return res, err
+}
+
+func (r *Replayer) GenerateDiagnosticData() (string, error) {
+	var res string
+	err := r.replay("GenerateDiagnosticData", &res)
+	return res, err
+}
+
+func (r *Replayer) Sync() error {
+	return r.replay("Sync", nil)
+}
+
+func (r *Replayer) RecommendedFees() (breez_sdk.RecommendedFees, error) {
+	var res breez_sdk.RecommendedFees
+	err := r.replay("RecommendedFees", &res)
+	return res, err
+}
+
+func (r *Replayer) BuyBitcoin(req breez_sdk.BuyBitcoinRequest) (breez_sdk.BuyBitcoinResponse, error) {
+	var res breez_sdk.BuyBitcoinResponse
+	err := r.replay("BuyBitcoin", &res)
+	return res, err
+}
+
+func (r *Replayer) PrepareRedeemOnchainFunds(req breez_sdk.PrepareRedeemOnchainFundsRequest) (breez_sdk.PrepareRedeemOnchainFundsResponse, error) {
+	var res breez_sdk.PrepareRedeemOnchainFundsResponse
+	err := r.replay("PrepareRedeemOnchainFunds", &res)
+	return res, err
+}
@@ -0,0 +1,261 @@
+// Package replay records the calls made to a Breez SDK service, with their
+// results, and replays them without a node. A user hitting a bug runs their
+// app with a Recorder and sends the recording; maintainers then drive the same
+// code with a Replayer to reproduce it deterministically.
+//
+//	f, _ := os.Create("breez-calls.jsonl")
+//	svc := replay.Record(sdk, f)
+//	...
+//	svc, err := replay.NewReplayer(f)
+//
+// Recordings are JSON lines, one Call per line. Secrets such as credentials and
+// preimages are left out, see DefaultRedactedFields.
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+)
+
+// Call is a recorded method call.
+type Call struct {
+	Method string            `json:"method"`
+	Args   []json.RawMessage `json:"args,omitempty"`
+	Result json.RawMessage   `json:"result,omitempty"`
+	Error  *RecordedError    `json:"error,omitempty"`
+}
+
+// RecordedError is an error returned by a recorded call. Type is the name of
+// the concrete type of the error, such as "SdkError" or "RustPanicError", or of
+// the sentinel error it is, such as "ErrServiceClosed". Variant is the name of
+// the variant type of SDK errors, such as "SdkErrorGeneric".
+type RecordedError struct {
+	Type    string `json:"type,omitempty"`
+	Variant string `json:"variant,omitempty"`
+	Message string `json:"message"`
+}
+
+// Recorder implements breez_sdk.BlockingBreezServicesInterface on top of
+// another implementation, writing every call to a recording.
+type Recorder struct {
+	inner   breez_sdk.BlockingBreezServicesInterface
+	encoder encoder
+	lock    sync.Mutex
+	out     io.Writer
+	err     error
+}
+
+// Record returns inner writing its calls to w, redacting
+// DefaultRedactedFields.
+func Record(inner breez_sdk.BlockingBreezServicesInterface, w io.Writer) *Recorder {
+	return RecordRedacting(inner, w, DefaultRedactedFields)
+}
+
+// RecordRedacting is Record with a custom list of redacted field names.
+func RecordRedacting(inner breez_sdk.BlockingBreezServicesInterface, w io.Writer, redactedFields []string) *Recorder {
+	redacted := map[string]bool{}
+	for _, name := range redactedFields {
+		redacted[name] = true
+	}
+	return &Recorder{
+		inner:   inner,
+		encoder: encoder{redacted: redacted},
+		out:     w,
+	}
+}
+
+// Err returns the first error writing the recording. Calls are still
+// forwarded after recording failed.
+func (r *Recorder) Err() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.err
+}
+
+func (r *Recorder) record(method string, result interface{}, err error, args ...interface{}) {
+	call, encodeErr := r.encodeCall(method, result, err, args)
+	var line []byte
+	if encodeErr == nil {
+		line, encodeErr = json.Marshal(call)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.err != nil {
+		return
+	}
+	if encodeErr != nil {
+		r.err = fmt.Errorf("recording %s: %w", method, encodeErr)
+		return
+	}
+	if _, err := r.out.Write(append(line, '\n')); err != nil {
+		r.err = err
+	}
+}
+
+func (r *Recorder) encodeCall(method string, result interface{}, err error, args []interface{}) (Call, error) {
+	call := Call{Method: method}
+	for _, arg := range args {
+		encoded, err := r.marshal(arg)
+		if err != nil {
+			return call, err
+		}
+		call.Args = append(call.Args, encoded)
+	}
+	if err != nil {
+		call.Error = &RecordedError{Type: errorTypeName(err), Message: err.Error()}
+		if variant := errors.Unwrap(err); variant != nil {
+			call.Error.Variant = reflect.Indirect(reflect.ValueOf(variant)).Type().Name()
+		}
+		return call, nil
+	}
+	if result != nil {
+		encoded, err := r.marshal(result)
+		if err != nil {
+			return call, err
+		}
+		call.Result = encoded
+	}
+	return call, nil
+}
+
+func (r *Recorder) marshal(v interface{}) (json.RawMessage, error) {
+	encoded, err := r.encoder.encode(reflect.ValueOf(v))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(encoded)
+}
+
+// errorTypeName returns the RecordedError.Type of err.
+func errorTypeName(err error) string {
+	for name, sentinel := range sentinelErrors {
+		if err == sentinel {
+			return name
+		}
+	}
+	return reflect.Indirect(reflect.ValueOf(err)).Type().Name()
+}
+
+// ReplayedError is returned by a Replayer for a call that failed when it was
+// recorded, unless the original error could be rebuilt as is, see
+// rebuildError. It keeps the message of the original error and unwraps to an
+// error of its type, such as a *breez_sdk.SdkError for the recorded variant,
+// so errors.Is and errors.As match as they did for the original error.
+type ReplayedError struct {
+	Message string
+	Err     error
+}
+
+func (err *ReplayedError) Error() string {
+	return err.Message
+}
+
+func (err *ReplayedError) Unwrap() error {
+	return err.Err
+}
+
+// ErrUnexpectedCall is returned by a Replayer for a call that does not match
+// the next one of the recording.
+var ErrUnexpectedCall = fmt.Errorf("call does not match the recording")
+
+// Replayer implements breez_sdk.BlockingBreezServicesInterface by returning
+// the results of a recording, in order. The arguments of the calls are not
+// compared, only the methods.
+type Replayer struct {
+	lock  sync.Mutex
+	calls []Call
+	next  int
+}
+
+// NewReplayer reads a recording written by a Recorder.
+func NewReplayer(r io.Reader) (*Replayer, error) {
+	var calls []Call
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var call Call
+		if err := json.Unmarshal(scanner.Bytes(), &call); err != nil {
+			return nil, fmt.Errorf("reading call %d: %w", len(calls)+1, err)
+		}
+		calls = append(calls, call)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &Replayer{calls: calls}, nil
+}
+
+// Remaining returns the number of recorded calls not replayed yet.
+func (r *Replayer) Remaining() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.calls) - r.next
+}
+
+// replay takes the next call, which must be a call to method, and decodes its
+// result into result, a pointer.
+func (r *Replayer) replay(method string, result interface{}) error {
+	r.lock.Lock()
+	if r.next >= len(r.calls) {
+		r.lock.Unlock()
+		return fmt.Errorf("%w: %s after the end of the recording", ErrUnexpectedCall, method)
+	}
+	call := r.calls[r.next]
+	if call.Method != method {
+		r.lock.Unlock()
+		return fmt.Errorf("%w: %s instead of %s", ErrUnexpectedCall, method, call.Method)
+	}
+	r.next++
+	r.lock.Unlock()
+
+	if call.Error != nil {
+		return rebuildError(*call.Error)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := decode(call.Result, reflect.ValueOf(result).Elem()); err != nil {
+		return fmt.Errorf("replaying %s: %w", method, err)
+	}
+	return nil
+}
+
+// rebuildError returns the error recorded as recorded. The sentinel errors and
+// the errors whose message holds all their fields are returned as they were;
+// the others, such as the variants of SDK errors whose message cannot be set
+// outside the bindings, are wrapped in a *ReplayedError.
+func rebuildError(recorded RecordedError) error {
+	if sentinel, ok := sentinelErrors[recorded.Type]; ok {
+		return sentinel
+	}
+	replayed := &ReplayedError{Message: recorded.Message}
+	switch recorded.Type {
+	case "RustPanicError":
+		if message := strings.TrimPrefix(recorded.Message, rustPanicPrefix); message != recorded.Message {
+			return &breez_sdk.RustPanicError{Message: message}
+		}
+		replayed.Err = &breez_sdk.RustPanicError{Message: recorded.Message}
+	case "DecodeError":
+		replayed.Err = &breez_sdk.DecodeError{Err: errors.New(recorded.Message)}
+	default:
+		if newErr, ok := errorVariants[recorded.Variant]; ok {
+			replayed.Err = newErr()
+		}
+	}
+	return replayed
+}
+
+// rustPanicPrefix starts the message of a *breez_sdk.RustPanicError.
+var rustPanicPrefix = strings.TrimSuffix((&breez_sdk.RustPanicError{Message: "-"}).Error(), "-")
@@ -0,0 +1,115 @@
+package replay
+
+import (
+	"reflect"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+)
+
+// variantTypes are the concrete types of the values stored in the interfaces of
+// the SDK, by name.
+var variantTypes = map[string]reflect.Type{
+	"AesSuccessActionDataResultDecrypted":   reflect.TypeOf(breez_sdk.AesSuccessActionDataResultDecrypted{}),
+	"AesSuccessActionDataResultErrorStatus": reflect.TypeOf(breez_sdk.AesSuccessActionDataResultErrorStatus{}),
+	"BreezEventNewBlock":                    reflect.TypeOf(breez_sdk.BreezEventNewBlock{}),
+	"BreezEventInvoicePaid":                 reflect.TypeOf(breez_sdk.BreezEventInvoicePaid{}),
+	"BreezEventSynced":                      reflect.TypeOf(breez_sdk.BreezEventSynced{}),
+	"BreezEventPaymentSucceed":              reflect.TypeOf(breez_sdk.BreezEventPaymentSucceed{}),
+	"BreezEventPaymentFailed":               reflect.TypeOf(breez_sdk.BreezEventPaymentFailed{}),
+	"BreezEventBackupStarted":               reflect.TypeOf(breez_sdk.BreezEventBackupStarted{}),
+	"BreezEventBackupSucceeded":             reflect.TypeOf(breez_sdk.BreezEventBackupSucceeded{}),
+	"BreezEventBackupFailed":                reflect.TypeOf(breez_sdk.BreezEventBackupFailed{}),
+	"BreezEventReverseSwapUpdated":          reflect.TypeOf(breez_sdk.BreezEventReverseSwapUpdated{}),
+	"BreezEventSwapUpdated":                 reflect.TypeOf(breez_sdk.BreezEventSwapUpdated{}),
+	"InputTypeBitcoinAddress":               reflect.TypeOf(breez_sdk.InputTypeBitcoinAddress{}),
+	"InputTypeBolt11":                       reflect.TypeOf(breez_sdk.InputTypeBolt11{}),
+	"InputTypeNodeId":                       reflect.TypeOf(breez_sdk.InputTypeNodeId{}),
+	"InputTypeUrl":                          reflect.TypeOf(breez_sdk.InputTypeUrl{}),
+	"InputTypeLnUrlPay":                     reflect.TypeOf(breez_sdk.InputTypeLnUrlPay{}),
+	"InputTypeLnUrlWithdraw":                reflect.TypeOf(breez_sdk.InputTypeLnUrlWithdraw{}),
+	"InputTypeLnUrlAuth":                    reflect.TypeOf(breez_sdk.InputTypeLnUrlAuth{}),
+	"InputTypeLnUrlError":                   reflect.TypeOf(breez_sdk.InputTypeLnUrlError{}),
+	"LnUrlCallbackStatusOk":                 reflect.TypeOf(breez_sdk.LnUrlCallbackStatusOk{}),
+	"LnUrlCallbackStatusErrorStatus":        reflect.TypeOf(breez_sdk.LnUrlCallbackStatusErrorStatus{}),
+	"LnUrlPayResultEndpointSuccess":         reflect.TypeOf(breez_sdk.LnUrlPayResultEndpointSuccess{}),
+	"LnUrlPayResultEndpointError":           reflect.TypeOf(breez_sdk.LnUrlPayResultEndpointError{}),
+	"LnUrlPayResultPayError":                reflect.TypeOf(breez_sdk.LnUrlPayResultPayError{}),
+	"LnUrlWithdrawResultOk":                 reflect.TypeOf(breez_sdk.LnUrlWithdrawResultOk{}),
+	"LnUrlWithdrawResultTimeout":            reflect.TypeOf(breez_sdk.LnUrlWithdrawResultTimeout{}),
+	"LnUrlWithdrawResultErrorStatus":        reflect.TypeOf(breez_sdk.LnUrlWithdrawResultErrorStatus{}),
+	"NodeConfigGreenlight":                  reflect.TypeOf(breez_sdk.NodeConfigGreenlight{}),
+	"NodeCredentialsGreenlight":             reflect.TypeOf(breez_sdk.NodeCredentialsGreenlight{}),
+	"PaymentDetailsLn":                      reflect.TypeOf(breez_sdk.PaymentDetailsLn{}),
+	"PaymentDetailsClosedChannel":           reflect.TypeOf(breez_sdk.PaymentDetailsClosedChannel{}),
+	"ReportIssueRequestPaymentFailure":      reflect.TypeOf(breez_sdk.ReportIssueRequestPaymentFailure{}),
+	"SuccessActionProcessedAes":             reflect.TypeOf(breez_sdk.SuccessActionProcessedAes{}),
+	"SuccessActionProcessedMessage":         reflect.TypeOf(breez_sdk.SuccessActionProcessedMessage{}),
+	"SuccessActionProcessedUrl":             reflect.TypeOf(breez_sdk.SuccessActionProcessedUrl{}),
+}
+
+// sentinelErrors are the sentinel errors of the SDK, by name.
+var sentinelErrors = map[string]error{
+	"ErrServiceClosed": breez_sdk.ErrServiceClosed,
+}
+
+// errorVariants construct the variants of the SDK error enums, by the name of
+// the variant type.
+var errorVariants = map[string]func() error{
+	"ConnectErrorGeneric":                             func() error { return breez_sdk.NewConnectErrorGeneric() },
+	"ConnectErrorRestoreOnly":                         func() error { return breez_sdk.NewConnectErrorRestoreOnly() },
+	"ConnectErrorServiceConnectivity":                 func() error { return breez_sdk.NewConnectErrorServiceConnectivity() },
+	"LnUrlAuthErrorGeneric":                           func() error { return breez_sdk.NewLnUrlAuthErrorGeneric() },
+	"LnUrlAuthErrorInvalidUri":                        func() error { return breez_sdk.NewLnUrlAuthErrorInvalidUri() },
+	"LnUrlAuthErrorServiceConnectivity":               func() error { return breez_sdk.NewLnUrlAuthErrorServiceConnectivity() },
+	"LnUrlPayErrorAlreadyPaid":                        func() error { return breez_sdk.NewLnUrlPayErrorAlreadyPaid() },
+	"LnUrlPayErrorGeneric":                            func() error { return breez_sdk.NewLnUrlPayErrorGeneric() },
+	"LnUrlPayErrorInvalidAmount":                      func() error { return breez_sdk.NewLnUrlPayErrorInvalidAmount() },
+	"LnUrlPayErrorInvalidInvoice":                     func() error { return breez_sdk.NewLnUrlPayErrorInvalidInvoice() },
+	"LnUrlPayErrorInvalidNetwork":                     func() error { return breez_sdk.NewLnUrlPayErrorInvalidNetwork() },
+	"LnUrlPayErrorInvalidUri":                         func() error { return breez_sdk.NewLnUrlPayErrorInvalidUri() },
+	"LnUrlPayErrorInvoiceExpired":                     func() error { return breez_sdk.NewLnUrlPayErrorInvoiceExpired() },
+	"LnUrlPayErrorPaymentFailed":                      func() error { return breez_sdk.NewLnUrlPayErrorPaymentFailed() },
+	"LnUrlPayErrorPaymentTimeout":                     func() error { return breez_sdk.NewLnUrlPayErrorPaymentTimeout() },
+	"LnUrlPayErrorRouteNotFound":                      func() error { return breez_sdk.NewLnUrlPayErrorRouteNotFound() },
+	"LnUrlPayErrorRouteTooExpensive":                  func() error { return breez_sdk.NewLnUrlPayErrorRouteTooExpensive() },
+	"LnUrlPayErrorServiceConnectivity":                func() error { return breez_sdk.NewLnUrlPayErrorServiceConnectivity() },
+	"LnUrlWithdrawErrorGeneric":                       func() error { return breez_sdk.NewLnUrlWithdrawErrorGeneric() },
+	"LnUrlWithdrawErrorInvalidAmount":                 func() error { return breez_sdk.NewLnUrlWithdrawErrorInvalidAmount() },
+	"LnUrlWithdrawErrorInvalidInvoice":                func() error { return breez_sdk.NewLnUrlWithdrawErrorInvalidInvoice() },
+	"LnUrlWithdrawErrorInvalidUri":                    func() error { return breez_sdk.NewLnUrlWithdrawErrorInvalidUri() },
+	"LnUrlWithdrawErrorServiceConnectivity":           func() error { return breez_sdk.NewLnUrlWithdrawErrorServiceConnectivity() },
+	"LnUrlWithdrawErrorInvoiceNoRoutingHints":         func() error { return breez_sdk.NewLnUrlWithdrawErrorInvoiceNoRoutingHints() },
+	"ReceiveOnchainErrorGeneric":                      func() error { return breez_sdk.NewReceiveOnchainErrorGeneric() },
+	"ReceiveOnchainErrorServiceConnectivity":          func() error { return breez_sdk.NewReceiveOnchainErrorServiceConnectivity() },
+	"ReceiveOnchainErrorSwapInProgress":               func() error { return breez_sdk.NewReceiveOnchainErrorSwapInProgress() },
+	"ReceivePaymentErrorGeneric":                      func() error { return breez_sdk.NewReceivePaymentErrorGeneric() },
+	"ReceivePaymentErrorInvalidAmount":                func() error { return breez_sdk.NewReceivePaymentErrorInvalidAmount() },
+	"ReceivePaymentErrorInvalidInvoice":               func() error { return breez_sdk.NewReceivePaymentErrorInvalidInvoice() },
+	"ReceivePaymentErrorInvoiceExpired":               func() error { return breez_sdk.NewReceivePaymentErrorInvoiceExpired() },
+	"ReceivePaymentErrorInvoiceNoDescription":         func() error { return breez_sdk.NewReceivePaymentErrorInvoiceNoDescription() },
+	"ReceivePaymentErrorInvoicePreimageAlreadyExists": func() error { return breez_sdk.NewReceivePaymentErrorInvoicePreimageAlreadyExists() },
+	"ReceivePaymentErrorServiceConnectivity":          func() error { return breez_sdk.NewReceivePaymentErrorServiceConnectivity() },
+	"ReceivePaymentErrorInvoiceNoRoutingHints":        func() error { return breez_sdk.NewReceivePaymentErrorInvoiceNoRoutingHints() },
+	"RedeemOnchainErrorGeneric":                       func() error { return breez_sdk.NewRedeemOnchainErrorGeneric() },
+	"RedeemOnchainErrorServiceConnectivity":           func() error { return breez_sdk.NewRedeemOnchainErrorServiceConnectivity() },
+	"RedeemOnchainErrorInsufficientFunds":             func() error { return breez_sdk.NewRedeemOnchainErrorInsufficientFunds() },
+	"SdkErrorGeneric":                                 func() error { return breez_sdk.NewSdkErrorGeneric() },
+	"SdkErrorServiceConnectivity":                     func() error { return breez_sdk.NewSdkErrorServiceConnectivity() },
+	"SendOnchainErrorGeneric":                         func() error { return breez_sdk.NewSendOnchainErrorGeneric() },
+	"SendOnchainErrorInvalidDestinationAddress":       func() error { return breez_sdk.NewSendOnchainErrorInvalidDestinationAddress() },
+	"SendOnchainErrorOutOfRange":                      func() error { return breez_sdk.NewSendOnchainErrorOutOfRange() },
+	"SendOnchainErrorPaymentFailed":                   func() error { return breez_sdk.NewSendOnchainErrorPaymentFailed() },
+	"SendOnchainErrorPaymentTimeout":                  func() error { return breez_sdk.NewSendOnchainErrorPaymentTimeout() },
+	"SendOnchainErrorServiceConnectivity":             func() error { return breez_sdk.NewSendOnchainErrorServiceConnectivity() },
+	"SendPaymentErrorAlreadyPaid":                     func() error { return breez_sdk.NewSendPaymentErrorAlreadyPaid() },
+	"SendPaymentErrorGeneric":                         func() error { return breez_sdk.NewSendPaymentErrorGeneric() },
+	"SendPaymentErrorInvalidAmount":                   func() error { return breez_sdk.NewSendPaymentErrorInvalidAmount() },
+	"SendPaymentErrorInvalidInvoice":                  func() error { return breez_sdk.NewSendPaymentErrorInvalidInvoice() },
+	"SendPaymentErrorInvoiceExpired":                  func() error { return breez_sdk.NewSendPaymentErrorInvoiceExpired() },
+	"SendPaymentErrorInvalidNetwork":                  func() error { return breez_sdk.NewSendPaymentErrorInvalidNetwork() },
+	"SendPaymentErrorPaymentFailed":                   func() error { return breez_sdk.NewSendPaymentErrorPaymentFailed() },
+	"SendPaymentErrorPaymentTimeout":                  func() error { return breez_sdk.NewSendPaymentErrorPaymentTimeout() },
+	"SendPaymentErrorRouteNotFound":                   func() error { return breez_sdk.NewSendPaymentErrorRouteNotFound() },
+	"SendPaymentErrorRouteTooExpensive":               func() error { return breez_sdk.NewSendPaymentErrorRouteTooExpensive() },
+	"SendPaymentErrorServiceConnectivity":             func() error { return breez_sdk.NewSendPaymentErrorServiceConnectivity() },
+}
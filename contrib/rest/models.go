@@ -0,0 +1,124 @@
+package rest
+
+import "github.com/breez/breez-sdk-go/breez_sdk"
+
+// NodeInfo is the body of GET /node_info.
+type NodeInfo struct {
+	Id                         string   `json:"id"`
+	BlockHeight                uint32   `json:"block_height"`
+	ChannelsBalanceMsat        uint64   `json:"channels_balance_msat"`
+	OnchainBalanceMsat         uint64   `json:"onchain_balance_msat"`
+	PendingOnchainBalanceMsat  uint64   `json:"pending_onchain_balance_msat"`
+	MaxPayableMsat             uint64   `json:"max_payable_msat"`
+	MaxReceivableMsat          uint64   `json:"max_receivable_msat"`
+	MaxSinglePaymentAmountMsat uint64   `json:"max_single_payment_amount_msat"`
+	TotalInboundLiquidityMsats uint64   `json:"total_inbound_liquidity_msats"`
+	ConnectedPeers             []string `json:"connected_peers"`
+}
+
+func newNodeInfo(state breez_sdk.NodeState) NodeInfo {
+	peers := state.ConnectedPeers
+	if peers == nil {
+		peers = []string{}
+	}
+	return NodeInfo{
+		Id:                         state.Id,
+		BlockHeight:                state.BlockHeight,
+		ChannelsBalanceMsat:        state.ChannelsBalanceMsat,
+		OnchainBalanceMsat:         state.OnchainBalanceMsat,
+		PendingOnchainBalanceMsat:  state.PendingOnchainBalanceMsat,
+		MaxPayableMsat:             state.MaxPayableMsat,
+		MaxReceivableMsat:          state.MaxReceivableMsat,
+		MaxSinglePaymentAmountMsat: state.MaxSinglePaymentAmountMsat,
+		TotalInboundLiquidityMsats: state.TotalInboundLiquidityMsats,
+		ConnectedPeers:             peers,
+	}
+}
+
+// Payment is a payment in the bodies of GET /list_payments and
+// POST /send_payment. The Lightning fields are empty for payments of closed
+// channels.
+type Payment struct {
+	Id          string  `json:"id"`
+	PaymentType string  `json:"payment_type"`
+	PaymentTime int64   `json:"payment_time"`
+	AmountMsat  uint64  `json:"amount_msat"`
+	FeeMsat     uint64  `json:"fee_msat"`
+	Status      string  `json:"status"`
+	Error       *string `json:"error,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Metadata    *string `json:"metadata,omitempty"`
+	PaymentHash string  `json:"payment_hash,omitempty"`
+	Preimage    string  `json:"preimage,omitempty"`
+	Bolt11      string  `json:"bolt11,omitempty"`
+	Label       string  `json:"label,omitempty"`
+}
+
+var paymentTypes = map[breez_sdk.PaymentType]string{
+	breez_sdk.PaymentTypeSent:          "sent",
+	breez_sdk.PaymentTypeReceived:      "received",
+	breez_sdk.PaymentTypeClosedChannel: "closed_channel",
+}
+
+var paymentTypeFilters = map[string]breez_sdk.PaymentTypeFilter{
+	"sent":           breez_sdk.PaymentTypeFilterSent,
+	"received":       breez_sdk.PaymentTypeFilterReceived,
+	"closed_channel": breez_sdk.PaymentTypeFilterClosedChannel,
+}
+
+var paymentStatuses = map[breez_sdk.PaymentStatus]string{
+	breez_sdk.PaymentStatusPending:  "pending",
+	breez_sdk.PaymentStatusComplete: "complete",
+	breez_sdk.PaymentStatusFailed:   "failed",
+}
+
+func newPayment(p breez_sdk.Payment) Payment {
+	payment := Payment{
+		Id:          p.Id,
+		PaymentType: paymentTypes[p.PaymentType],
+		PaymentTime: p.PaymentTime,
+		AmountMsat:  p.AmountMsat,
+		FeeMsat:     p.FeeMsat,
+		Status:      paymentStatuses[p.Status],
+		Error:       p.Error,
+		Description: p.Description,
+		Metadata:    p.Metadata,
+	}
+	if details, ok := p.Details.(breez_sdk.PaymentDetailsLn); ok {
+		payment.PaymentHash = details.Data.PaymentHash
+		payment.Preimage = details.Data.PaymentPreimage
+		payment.Bolt11 = details.Data.Bolt11
+		payment.Label = details.Data.Label
+	}
+	return payment
+}
+
+// ReceivePaymentRequest is the body of POST /receive_payment.
+type ReceivePaymentRequest struct {
+	AmountMsat  uint64  `json:"amount_msat"`
+	Description string  `json:"description"`
+	Expiry      *uint32 `json:"expiry,omitempty"`
+}
+
+// ReceivePaymentResponse is the response to POST /receive_payment.
+type ReceivePaymentResponse struct {
+	Bolt11         string  `json:"bolt11"`
+	PaymentHash    string  `json:"payment_hash"`
+	Expiry         uint64  `json:"expiry"`
+	OpeningFeeMsat *uint64 `json:"opening_fee_msat,omitempty"`
+}
+
+// SendPaymentRequest is the body of POST /send_payment. AmountMsat is only
+// needed for invoices without an amount.
+type SendPaymentRequest struct {
+	Bolt11     string  `json:"bolt11"`
+	AmountMsat *uint64 `json:"amount_msat,omitempty"`
+	Label      *string `json:"label,omitempty"`
+}
+
+// Error is the body of failed requests. Code is the variant of SDK errors,
+// such as "SendPaymentErrorRouteNotFound".
+type Error struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
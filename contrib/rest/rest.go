@@ -0,0 +1,245 @@
+// Package rest exposes a node as a small JSON API, for wallet backends that
+// talk to the node over HTTP:
+//
+//	GET  /node_info        NodeInfo
+//	POST /receive_payment  ReceivePaymentRequest -> ReceivePaymentResponse
+//	POST /send_payment     SendPaymentRequest -> Payment
+//	GET  /list_payments    ?type=sent&from=...&to=...&offset=...&limit=...&include_failures=true -> []Payment
+//
+// Every request must carry the API key in the X-Api-Key header or as a
+// bearer token. Errors are answered with an Error body.
+package rest
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+)
+
+// ApiKeyHeader is the header carrying the API key.
+const ApiKeyHeader = "X-Api-Key"
+
+// Service is what the handler needs from the SDK, implemented by
+// *breez_sdk.BlockingBreezServices.
+type Service interface {
+	NodeInfo() (breez_sdk.NodeState, error)
+	ReceivePayment(req breez_sdk.ReceivePaymentRequest) (breez_sdk.ReceivePaymentResponse, error)
+	SendPayment(req breez_sdk.SendPaymentRequest) (breez_sdk.SendPaymentResponse, error)
+	ListPayments(req breez_sdk.ListPaymentsRequest) ([]breez_sdk.Payment, error)
+}
+
+var _ Service = (*breez_sdk.BlockingBreezServices)(nil)
+
+// Handler serves the API of svc to requests authenticated with apiKey, which
+// must not be empty.
+func Handler(svc Service, apiKey string) http.Handler {
+	if apiKey == "" {
+		panic("rest: empty API key")
+	}
+	h := &handler{svc: svc, apiKey: []byte(apiKey)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/node_info", h.method(http.MethodGet, h.nodeInfo))
+	mux.HandleFunc("/receive_payment", h.method(http.MethodPost, h.receivePayment))
+	mux.HandleFunc("/send_payment", h.method(http.MethodPost, h.sendPayment))
+	mux.HandleFunc("/list_payments", h.method(http.MethodGet, h.listPayments))
+	return h.authenticate(mux)
+}
+
+type handler struct {
+	svc    Service
+	apiKey []byte
+}
+
+func (h *handler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(ApiKeyHeader)
+		if key == "" {
+			key = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		}
+		if subtle.ConstantTimeCompare([]byte(key), h.apiKey) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("invalid API key"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (h *handler) method(method string, fn func(*http.Request) (interface{}, int, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+			return
+		}
+		body, status, err := fn(r)
+		if err != nil {
+			writeError(w, status, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, body)
+	}
+}
+
+func (h *handler) nodeInfo(r *http.Request) (interface{}, int, error) {
+	state, err := h.svc.NodeInfo()
+	if err != nil {
+		return nil, http.StatusBadGateway, err
+	}
+	return newNodeInfo(state), 0, nil
+}
+
+func (h *handler) receivePayment(r *http.Request) (interface{}, int, error) {
+	var req ReceivePaymentRequest
+	if err := decode(r, &req); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	resp, err := h.svc.ReceivePayment(breez_sdk.ReceivePaymentRequest{
+		AmountMsat:  req.AmountMsat,
+		Description: req.Description,
+		Expiry:      req.Expiry,
+	})
+	if err != nil {
+		return nil, sdkErrorStatus(err), err
+	}
+	return ReceivePaymentResponse{
+		Bolt11:         resp.LnInvoice.Bolt11,
+		PaymentHash:    resp.LnInvoice.PaymentHash,
+		Expiry:         resp.LnInvoice.Expiry,
+		OpeningFeeMsat: resp.OpeningFeeMsat,
+	}, 0, nil
+}
+
+func (h *handler) sendPayment(r *http.Request) (interface{}, int, error) {
+	var req SendPaymentRequest
+	if err := decode(r, &req); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if req.Bolt11 == "" {
+		return nil, http.StatusBadRequest, errors.New("missing bolt11")
+	}
+	resp, err := h.svc.SendPayment(breez_sdk.SendPaymentRequest{
+		Bolt11:     req.Bolt11,
+		AmountMsat: req.AmountMsat,
+		Label:      req.Label,
+	})
+	if err != nil {
+		return nil, sdkErrorStatus(err), err
+	}
+	return newPayment(resp.Payment), 0, nil
+}
+
+func (h *handler) listPayments(r *http.Request) (interface{}, int, error) {
+	query := r.URL.Query()
+	var req breez_sdk.ListPaymentsRequest
+	if types := query["type"]; len(types) > 0 {
+		filters := make([]breez_sdk.PaymentTypeFilter, 0, len(types))
+		for _, name := range types {
+			filter, ok := paymentTypeFilters[name]
+			if !ok {
+				return nil, http.StatusBadRequest, errors.New("invalid type " + strconv.Quote(name))
+			}
+			filters = append(filters, filter)
+		}
+		req.Filters = &filters
+	}
+	var err error
+	if req.FromTimestamp, err = queryInt64(query.Get("from"), "from"); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if req.ToTimestamp, err = queryInt64(query.Get("to"), "to"); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if req.Offset, err = queryUint32(query.Get("offset"), "offset"); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if req.Limit, err = queryUint32(query.Get("limit"), "limit"); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if value := query.Get("include_failures"); value != "" {
+		includeFailures, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, http.StatusBadRequest, errors.New("invalid include_failures")
+		}
+		req.IncludeFailures = &includeFailures
+	}
+
+	payments, err := h.svc.ListPayments(req)
+	if err != nil {
+		return nil, http.StatusBadGateway, err
+	}
+	body := make([]Payment, len(payments))
+	for i, payment := range payments {
+		body[i] = newPayment(payment)
+	}
+	return body, 0, nil
+}
+
+func decode(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, 1<<20))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return errors.New("invalid request body: " + err.Error())
+	}
+	return nil
+}
+
+func queryInt64(value, name string) (*int64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid " + name)
+	}
+	return &parsed, nil
+}
+
+func queryUint32(value, name string) (*uint32, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return nil, errors.New("invalid " + name)
+	}
+	result := uint32(parsed)
+	return &result, nil
+}
+
+// sdkErrorStatus maps the SDK errors caused by the request to 400 and the
+// others to 502.
+func sdkErrorStatus(err error) int {
+	code := errorCode(err)
+	for _, caller := range []string{"InvalidAmount", "InvalidInvoice", "InvoiceExpired", "InvalidNetwork", "AlreadyPaid", "InvoiceNoDescription", "InvoicePreimageAlreadyExists"} {
+		if strings.HasSuffix(code, caller) {
+			return http.StatusBadRequest
+		}
+	}
+	return http.StatusBadGateway
+}
+
+// errorCode returns the variant name of SDK errors, such as
+// "SendPaymentErrorRouteNotFound".
+func errorCode(err error) string {
+	variant := errors.Unwrap(err)
+	if variant == nil {
+		return ""
+	}
+	return reflect.Indirect(reflect.ValueOf(variant)).Type().Name()
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, Error{Error: err.Error(), Code: errorCode(err)})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,416 @@
+package retry
+
+import "github.com/breez/breez-sdk-go/breez_sdk"
+
+var _ breez_sdk.BlockingBreezServicesInterface = (*Services)(nil)
+
+func (s *Services) Disconnect() error {
+	return s.do("Disconnect", func() error {
+		return s.inner.Disconnect()
+	})
+}
+
+func (s *Services) ConfigureNode(req breez_sdk.ConfigureNodeRequest) error {
+	return s.do("ConfigureNode", func() error {
+		return s.inner.ConfigureNode(req)
+	})
+}
+
+func (s *Services) SendPayment(req breez_sdk.SendPaymentRequest) (breez_sdk.SendPaymentResponse, error) {
+	var result breez_sdk.SendPaymentResponse
+	err := s.do("SendPayment", func() (err error) {
+		result, err = s.inner.SendPayment(req)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) SendSpontaneousPayment(req breez_sdk.SendSpontaneousPaymentRequest) (breez_sdk.SendPaymentResponse, error) {
+	var result breez_sdk.SendPaymentResponse
+	err := s.do("SendSpontaneousPayment", func() (err error) {
+		result, err = s.inner.SendSpontaneousPayment(req)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) ReceivePayment(req breez_sdk.ReceivePaymentRequest) (breez_sdk.ReceivePaymentResponse, error) {
+	var result breez_sdk.ReceivePaymentResponse
+	err := s.do("ReceivePayment", func() (err error) {
+		result, err = s.inner.ReceivePayment(req)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) PayLnurl(req breez_sdk.LnUrlPayRequest) (breez_sdk.LnUrlPayResult, error) {
+	var result breez_sdk.LnUrlPayResult
+	err := s.do("PayLnurl", func() (err error) {
+		result, err = s.inner.PayLnurl(req)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) WithdrawLnurl(request breez_sdk.LnUrlWithdrawRequest) (breez_sdk.LnUrlWithdrawResult, error) {
+	var result breez_sdk.LnUrlWithdrawResult
+	err := s.do("WithdrawLnurl", func() (err error) {
+		result, err = s.inner.WithdrawLnurl(request)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) LnurlAuth(reqData breez_sdk.LnUrlAuthRequestData) (breez_sdk.LnUrlCallbackStatus, error) {
+	var result breez_sdk.LnUrlCallbackStatus
+	err := s.do("LnurlAuth", func() (err error) {
+		result, err = s.inner.LnurlAuth(reqData)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) ReportIssue(req breez_sdk.ReportIssueRequest) error {
+	return s.do("ReportIssue", func() error {
+		return s.inner.ReportIssue(req)
+	})
+}
+
+func (s *Services) NodeCredentials() (*breez_sdk.NodeCredentials, error) {
+	var result *breez_sdk.NodeCredentials
+	err := s.do("NodeCredentials", func() (err error) {
+		result, err = s.inner.NodeCredentials()
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) NodeInfo() (breez_sdk.NodeState, error) {
+	var result breez_sdk.NodeState
+	err := s.do("NodeInfo", func() (err error) {
+		result, err = s.inner.NodeInfo()
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) SignMessage(req breez_sdk.SignMessageRequest) (breez_sdk.SignMessageResponse, error) {
+	var result breez_sdk.SignMessageResponse
+	err := s.do("SignMessage", func() (err error) {
+		result, err = s.inner.SignMessage(req)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) CheckMessage(req breez_sdk.CheckMessageRequest) (breez_sdk.CheckMessageResponse, error) {
+	var result breez_sdk.CheckMessageResponse
+	err := s.do("CheckMessage", func() (err error) {
+		result, err = s.inner.CheckMessage(req)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) BackupStatus() (breez_sdk.BackupStatus, error) {
+	var result breez_sdk.BackupStatus
+	err := s.do("BackupStatus", func() (err error) {
+		result, err = s.inner.BackupStatus()
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) Backup() error {
+	return s.do("Backup", func() error {
+		return s.inner.Backup()
+	})
+}
+
+func (s *Services) ListPayments(req breez_sdk.ListPaymentsRequest) ([]breez_sdk.Payment, error) {
+	var result []breez_sdk.Payment
+	err := s.do("ListPayments", func() (err error) {
+		result, err = s.inner.ListPayments(req)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) PaymentByHash(hash string) (*breez_sdk.Payment, error) {
+	var result *breez_sdk.Payment
+	err := s.do("PaymentByHash", func() (err error) {
+		result, err = s.inner.PaymentByHash(hash)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) SetPaymentMetadata(hash string, metadata string) error {
+	return s.do("SetPaymentMetadata", func() error {
+		return s.inner.SetPaymentMetadata(hash, metadata)
+	})
+}
+
+func (s *Services) RedeemOnchainFunds(req breez_sdk.RedeemOnchainFundsRequest) (breez_sdk.RedeemOnchainFundsResponse, error) {
+	var result breez_sdk.RedeemOnchainFundsResponse
+	err := s.do("RedeemOnchainFunds", func() (err error) {
+		result, err = s.inner.RedeemOnchainFunds(req)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) FetchFiatRates() ([]breez_sdk.Rate, error) {
+	var result []breez_sdk.Rate
+	err := s.do("FetchFiatRates", func() (err error) {
+		result, err = s.inner.FetchFiatRates()
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) ListFiatCurrencies() ([]breez_sdk.FiatCurrency, error) {
+	var result []breez_sdk.FiatCurrency
+	err := s.do("ListFiatCurrencies", func() (err error) {
+		result, err = s.inner.ListFiatCurrencies()
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) ListLsps() ([]breez_sdk.LspInformation, error) {
+	var result []breez_sdk.LspInformation
+	err := s.do("ListLsps", func() (err error) {
+		result, err = s.inner.ListLsps()
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) ConnectLsp(lspId string) error {
+	return s.do("ConnectLsp", func() error {
+		return s.inner.ConnectLsp(lspId)
+	})
+}
+
+func (s *Services) FetchLspInfo(lspId string) (*breez_sdk.LspInformation, error) {
+	var result *breez_sdk.LspInformation
+	err := s.do("FetchLspInfo", func() (err error) {
+		result, err = s.inner.FetchLspInfo(lspId)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) OpenChannelFee(req breez_sdk.OpenChannelFeeRequest) (breez_sdk.OpenChannelFeeResponse, error) {
+	var result breez_sdk.OpenChannelFeeResponse
+	err := s.do("OpenChannelFee", func() (err error) {
+		result, err = s.inner.OpenChannelFee(req)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) LspId() (*string, error) {
+	var result *string
+	err := s.do("LspId", func() (err error) {
+		result, err = s.inner.LspId()
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) LspInfo() (breez_sdk.LspInformation, error) {
+	var result breez_sdk.LspInformation
+	err := s.do("LspInfo", func() (err error) {
+		result, err = s.inner.LspInfo()
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) CloseLspChannels() error {
+	return s.do("CloseLspChannels", func() error {
+		return s.inner.CloseLspChannels()
+	})
+}
+
+func (s *Services) RegisterWebhook(webhookUrl string) error {
+	return s.do("RegisterWebhook", func() error {
+		return s.inner.RegisterWebhook(webhookUrl)
+	})
+}
+
+func (s *Services) UnregisterWebhook(webhookUrl string) error {
+	return s.do("UnregisterWebhook", func() error {
+		return s.inner.UnregisterWebhook(webhookUrl)
+	})
+}
+
+func (s *Services) ReceiveOnchain(req breez_sdk.ReceiveOnchainRequest) (breez_sdk.SwapInfo, error) {
+	var result breez_sdk.SwapInfo
+	err := s.do("ReceiveOnchain", func() (err error) {
+		result, err = s.inner.ReceiveOnchain(req)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) InProgressSwap() (*breez_sdk.SwapInfo, error) {
+	var result *breez_sdk.SwapInfo
+	err := s.do("InProgressSwap", func() (err error) {
+		result, err = s.inner.InProgressSwap()
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) RescanSwaps() error {
+	return s.do("RescanSwaps", func() error {
+		return s.inner.RescanSwaps()
+	})
+}
+
+func (s *Services) RedeemSwap(swapAddress string) error {
+	return s.do("RedeemSwap", func() error {
+		return s.inner.RedeemSwap(swapAddress)
+	})
+}
+
+func (s *Services) ListRefundables() ([]breez_sdk.SwapInfo, error) {
+	var result []breez_sdk.SwapInfo
+	err := s.do("ListRefundables", func() (err error) {
+		result, err = s.inner.ListRefundables()
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) PrepareRefund(req breez_sdk.PrepareRefundRequest) (breez_sdk.PrepareRefundResponse, error) {
+	var result breez_sdk.PrepareRefundResponse
+	err := s.do("PrepareRefund", func() (err error) {
+		result, err = s.inner.PrepareRefund(req)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) Refund(req breez_sdk.RefundRequest) (breez_sdk.RefundResponse, error) {
+	var result breez_sdk.RefundResponse
+	err := s.do("Refund", func() (err error) {
+		result, err = s.inner.Refund(req)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) ListSwaps(req breez_sdk.ListSwapsRequest) ([]breez_sdk.SwapInfo, error) {
+	var result []breez_sdk.SwapInfo
+	err := s.do("ListSwaps", func() (err error) {
+		result, err = s.inner.ListSwaps(req)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) FetchReverseSwapFees(req breez_sdk.ReverseSwapFeesRequest) (breez_sdk.ReverseSwapPairInfo, error) {
+	var result breez_sdk.ReverseSwapPairInfo
+	err := s.do("FetchReverseSwapFees", func() (err error) {
+		result, err = s.inner.FetchReverseSwapFees(req)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) OnchainPaymentLimits() (breez_sdk.OnchainPaymentLimitsResponse, error) {
+	var result breez_sdk.OnchainPaymentLimitsResponse
+	err := s.do("OnchainPaymentLimits", func() (err error) {
+		result, err = s.inner.OnchainPaymentLimits()
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) PrepareOnchainPayment(req breez_sdk.PrepareOnchainPaymentRequest) (breez_sdk.PrepareOnchainPaymentResponse, error) {
+	var result breez_sdk.PrepareOnchainPaymentResponse
+	err := s.do("PrepareOnchainPayment", func() (err error) {
+		result, err = s.inner.PrepareOnchainPayment(req)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) InProgressOnchainPayments() ([]breez_sdk.ReverseSwapInfo, error) {
+	var result []breez_sdk.ReverseSwapInfo
+	err := s.do("InProgressOnchainPayments", func() (err error) {
+		result, err = s.inner.InProgressOnchainPayments()
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) ClaimReverseSwap(lockupAddress string) error {
+	return s.do("ClaimReverseSwap", func() error {
+		return s.inner.ClaimReverseSwap(lockupAddress)
+	})
+}
+
+func (s *Services) PayOnchain(req breez_sdk.PayOnchainRequest) (breez_sdk.PayOnchainResponse, error) {
+	var result breez_sdk.PayOnchainResponse
+	err := s.do("PayOnchain", func() (err error) {
+		result, err = s.inner.PayOnchain(req)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) ExecuteDevCommand(command string) (string, error) {
+	var result string
+	err := s.do("ExecuteDevCommand", func() (err error) {
+		result, err = s.inner.ExecuteDevCommand(command)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) GenerateDiagnosticData() (string, error) {
+	var result string
+	err := s.do("GenerateDiagnosticData", func() (err error) {
+		result, err = s.inner.GenerateDiagnosticData()
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) Sync() error {
+	return s.do("Sync", func() error {
+		return s.inner.Sync()
+	})
+}
+
+func (s *Services) RecommendedFees() (breez_sdk.RecommendedFees, error) {
+	var result breez_sdk.RecommendedFees
+	err := s.do("RecommendedFees", func() (err error) {
+		result, err = s.inner.RecommendedFees()
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) BuyBitcoin(req breez_sdk.BuyBitcoinRequest) (breez_sdk.BuyBitcoinResponse, error) {
+	var result breez_sdk.BuyBitcoinResponse
+	err := s.do("BuyBitcoin", func() (err error) {
+		result, err = s.inner.BuyBitcoin(req)
+		return err
+	})
+	return result, err
+}
+
+func (s *Services) PrepareRedeemOnchainFunds(req breez_sdk.PrepareRedeemOnchainFundsRequest) (breez_sdk.PrepareRedeemOnchainFundsResponse, error) {
+	var result breez_sdk.PrepareRedeemOnchainFundsResponse
+	err := s.do("PrepareRedeemOnchainFunds", func() (err error) {
+		result, err = s.inner.PrepareRedeemOnchainFunds(req)
+		return err
+	})
+	return result, err
+}
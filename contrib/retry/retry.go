@@ -0,0 +1,158 @@
+// Package retry retries calls into the Breez SDK that fail transiently, such
+// as with ServiceConnectivity errors during brief outages:
+//
+//	svc := retry.Wrap(sdk, retry.DefaultPolicy)
+//	info, err := svc.NodeInfo()
+//
+// A different policy applies to single calls with WithPolicy:
+//
+//	svc.WithPolicy(retry.Policy{MaxAttempts: 10}).Sync()
+//
+// Calls whose repetition could move funds twice, listed in NonIdempotent, are
+// only retried if the policy allows it with RetryNonIdempotent.
+package retry
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+)
+
+// Policy configures the retries. Zero fields, except Jitter, take the value of
+// DefaultPolicy.
+type Policy struct {
+	// MaxAttempts counts the first call; 1 disables retries.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, multiplied by
+	// Multiplier for every further retry up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter randomizes each wait by up to this fraction, in either
+	// direction.
+	Jitter float64
+	// Retryable reports whether the failed call of method is retried,
+	// IsServiceConnectivity if nil.
+	Retryable func(method string, err error) bool
+	// RetryNonIdempotent allows retrying the methods in NonIdempotent.
+	RetryNonIdempotent bool
+	// Clock times the waits, breez_sdk.SystemClock if nil.
+	Clock breez_sdk.Clock
+}
+
+// DefaultPolicy makes up to three attempts, waiting half a second and then one
+// second between them.
+var DefaultPolicy = Policy{
+	MaxAttempts:    3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// NonIdempotent are the methods that may pay, broadcast or close twice when
+// repeated after a failure whose effect is unknown.
+var NonIdempotent = map[string]bool{
+	"SendSpontaneousPayment": true,
+	"PayLnurl":               true,
+	"WithdrawLnurl":          true,
+	"RedeemOnchainFunds":     true,
+	"Refund":                 true,
+	"PayOnchain":             true,
+	"ClaimReverseSwap":       true,
+	"CloseLspChannels":       true,
+	"ExecuteDevCommand":      true,
+}
+
+// IsServiceConnectivity reports whether err is the ServiceConnectivity
+// variant of an SDK error.
+func IsServiceConnectivity(method string, err error) bool {
+	variant := errors.Unwrap(err)
+	if variant == nil {
+		return false
+	}
+	return strings.HasSuffix(reflect.Indirect(reflect.ValueOf(variant)).Type().Name(), "ServiceConnectivity")
+}
+
+func (p Policy) withDefaults() Policy {
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = DefaultPolicy.MaxAttempts
+	}
+	if p.InitialBackoff == 0 {
+		p.InitialBackoff = DefaultPolicy.InitialBackoff
+	}
+	if p.MaxBackoff == 0 {
+		p.MaxBackoff = DefaultPolicy.MaxBackoff
+	}
+	if p.Multiplier == 0 {
+		p.Multiplier = DefaultPolicy.Multiplier
+	}
+	if p.Retryable == nil {
+		p.Retryable = IsServiceConnectivity
+	}
+	if p.Clock == nil {
+		p.Clock = breez_sdk.SystemClock
+	}
+	return p
+}
+
+// Services implements breez_sdk.BlockingBreezServicesInterface on top of
+// another implementation, retrying failed calls according to a Policy.
+type Services struct {
+	inner  breez_sdk.BlockingBreezServicesInterface
+	policy Policy
+}
+
+// Wrap returns inner retrying according to policy.
+func Wrap(inner breez_sdk.BlockingBreezServicesInterface, policy Policy) *Services {
+	return &Services{inner: inner, policy: policy.withDefaults()}
+}
+
+// WithPolicy returns the service retrying according to policy instead, for
+// the calls made on the returned value.
+func (s *Services) WithPolicy(policy Policy) *Services {
+	return &Services{inner: s.inner, policy: policy.withDefaults()}
+}
+
+// Unwrap returns the wrapped service.
+func (s *Services) Unwrap() breez_sdk.BlockingBreezServicesInterface {
+	return s.inner
+}
+
+// do calls fn until it succeeds, fails with an error the policy does not
+// retry, or the attempts are used up, and returns its last error.
+func (s *Services) do(method string, fn func() error) error {
+	policy := s.policy
+	backoff := policy.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= policy.MaxAttempts || !policy.Retryable(method, err) {
+			return err
+		}
+		if NonIdempotent[method] && !policy.RetryNonIdempotent {
+			return err
+		}
+		sleep(policy.Clock, jitter(backoff, policy.Jitter))
+		backoff = time.Duration(float64(backoff) * policy.Multiplier)
+		if backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+func jitter(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 {
+		return d
+	}
+	return time.Duration(float64(d) * (1 + fraction*(2*rand.Float64()-1)))
+}
+
+func sleep(clock breez_sdk.Clock, d time.Duration) {
+	done := make(chan struct{})
+	clock.AfterFunc(d, func() { close(done) })
+	<-done
+}
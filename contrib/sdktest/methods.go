@@ -0,0 +1,152 @@
+package sdktest
+
+import "github.com/breez/breez-sdk-go/breez_sdk"
+
+var _ breez_sdk.BlockingBreezServicesInterface = (*Mock)(nil)
+
+// The methods below have no behaviour of their own: they return zero values,
+// or the error set with Fail.
+
+func (m *Mock) ConfigureNode(req breez_sdk.ConfigureNodeRequest) error {
+	return m.call("ConfigureNode")
+}
+
+func (m *Mock) PayLnurl(req breez_sdk.LnUrlPayRequest) (breez_sdk.LnUrlPayResult, error) {
+	return nil, m.call("PayLnurl")
+}
+
+func (m *Mock) WithdrawLnurl(request breez_sdk.LnUrlWithdrawRequest) (breez_sdk.LnUrlWithdrawResult, error) {
+	return nil, m.call("WithdrawLnurl")
+}
+
+func (m *Mock) LnurlAuth(reqData breez_sdk.LnUrlAuthRequestData) (breez_sdk.LnUrlCallbackStatus, error) {
+	return nil, m.call("LnurlAuth")
+}
+
+func (m *Mock) ReportIssue(req breez_sdk.ReportIssueRequest) error {
+	return m.call("ReportIssue")
+}
+
+func (m *Mock) NodeCredentials() (*breez_sdk.NodeCredentials, error) {
+	return nil, m.call("NodeCredentials")
+}
+
+func (m *Mock) RedeemOnchainFunds(req breez_sdk.RedeemOnchainFundsRequest) (breez_sdk.RedeemOnchainFundsResponse, error) {
+	return breez_sdk.RedeemOnchainFundsResponse{}, m.call("RedeemOnchainFunds")
+}
+
+func (m *Mock) ListFiatCurrencies() ([]breez_sdk.FiatCurrency, error) {
+	return nil, m.call("ListFiatCurrencies")
+}
+
+func (m *Mock) ListLsps() ([]breez_sdk.LspInformation, error) {
+	return nil, m.call("ListLsps")
+}
+
+func (m *Mock) ConnectLsp(lspId string) error {
+	return m.call("ConnectLsp")
+}
+
+func (m *Mock) FetchLspInfo(lspId string) (*breez_sdk.LspInformation, error) {
+	return nil, m.call("FetchLspInfo")
+}
+
+func (m *Mock) OpenChannelFee(req breez_sdk.OpenChannelFeeRequest) (breez_sdk.OpenChannelFeeResponse, error) {
+	return breez_sdk.OpenChannelFeeResponse{}, m.call("OpenChannelFee")
+}
+
+func (m *Mock) LspId() (*string, error) {
+	return nil, m.call("LspId")
+}
+
+func (m *Mock) LspInfo() (breez_sdk.LspInformation, error) {
+	return breez_sdk.LspInformation{}, m.call("LspInfo")
+}
+
+func (m *Mock) CloseLspChannels() error {
+	return m.call("CloseLspChannels")
+}
+
+func (m *Mock) RegisterWebhook(webhookUrl string) error {
+	return m.call("RegisterWebhook")
+}
+
+func (m *Mock) UnregisterWebhook(webhookUrl string) error {
+	return m.call("UnregisterWebhook")
+}
+
+func (m *Mock) ReceiveOnchain(req breez_sdk.ReceiveOnchainRequest) (breez_sdk.SwapInfo, error) {
+	return breez_sdk.SwapInfo{}, m.call("ReceiveOnchain")
+}
+
+func (m *Mock) InProgressSwap() (*breez_sdk.SwapInfo, error) {
+	return nil, m.call("InProgressSwap")
+}
+
+func (m *Mock) RescanSwaps() error {
+	return m.call("RescanSwaps")
+}
+
+func (m *Mock) RedeemSwap(swapAddress string) error {
+	return m.call("RedeemSwap")
+}
+
+func (m *Mock) ListRefundables() ([]breez_sdk.SwapInfo, error) {
+	return nil, m.call("ListRefundables")
+}
+
+func (m *Mock) PrepareRefund(req breez_sdk.PrepareRefundRequest) (breez_sdk.PrepareRefundResponse, error) {
+	return breez_sdk.PrepareRefundResponse{}, m.call("PrepareRefund")
+}
+
+func (m *Mock) Refund(req breez_sdk.RefundRequest) (breez_sdk.RefundResponse, error) {
+	return breez_sdk.RefundResponse{}, m.call("Refund")
+}
+
+func (m *Mock) ListSwaps(req breez_sdk.ListSwapsRequest) ([]breez_sdk.SwapInfo, error) {
+	return nil, m.call("ListSwaps")
+}
+
+func (m *Mock) FetchReverseSwapFees(req breez_sdk.ReverseSwapFeesRequest) (breez_sdk.ReverseSwapPairInfo, error) {
+	return breez_sdk.ReverseSwapPairInfo{}, m.call("FetchReverseSwapFees")
+}
+
+func (m *Mock) OnchainPaymentLimits() (breez_sdk.OnchainPaymentLimitsResponse, error) {
+	return breez_sdk.OnchainPaymentLimitsResponse{}, m.call("OnchainPaymentLimits")
+}
+
+func (m *Mock) PrepareOnchainPayment(req breez_sdk.PrepareOnchainPaymentRequest) (breez_sdk.PrepareOnchainPaymentResponse, error) {
+	return breez_sdk.PrepareOnchainPaymentResponse{}, m.call("PrepareOnchainPayment")
+}
+
+func (m *Mock) InProgressOnchainPayments() ([]breez_sdk.ReverseSwapInfo, error) {
+	return nil, m.call("InProgressOnchainPayments")
+}
+
+func (m *Mock) ClaimReverseSwap(lockupAddress string) error {
+	return m.call("ClaimReverseSwap")
+}
+
+func (m *Mock) PayOnchain(req breez_sdk.PayOnchainRequest) (breez_sdk.PayOnchainResponse, error) {
+	return breez_sdk.PayOnchainResponse{}, m.call("PayOnchain")
+}
+
+func (m *Mock) ExecuteDevCommand(command string) (string, error) {
+	return "", m.call("ExecuteDevCommand")
+}
+
+func (m *Mock) GenerateDiagnosticData() (string, error) {
+	return "", m.call("GenerateDiagnosticData")
+}
+
+func (m *Mock) RecommendedFees() (breez_sdk.RecommendedFees, error) {
+	return breez_sdk.RecommendedFees{}, m.call("RecommendedFees")
+}
+
+func (m *Mock) BuyBitcoin(req breez_sdk.BuyBitcoinRequest) (breez_sdk.BuyBitcoinResponse, error) {
+	return breez_sdk.BuyBitcoinResponse{}, m.call("BuyBitcoin")
+}
+
+func (m *Mock) PrepareRedeemOnchainFunds(req breez_sdk.PrepareRedeemOnchainFundsRequest) (breez_sdk.PrepareRedeemOnchainFundsResponse, error) {
+	return breez_sdk.PrepareRedeemOnchainFundsResponse{}, m.call("PrepareRedeemOnchainFunds")
+}
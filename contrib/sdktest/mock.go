@@ -0,0 +1,523 @@
+// Package sdktest provides Mock, an in-memory implementation of
+// breez_sdk.BlockingBreezServicesInterface for unit tests of code built on
+// the SDK. It needs no node and no network, although building it still needs
+// cgo as the SDK types live in the bindings package.
+//
+//	mock := sdktest.New()
+//	mock.SetBalance(1_000_000, 0)
+//	mock.ScriptSend(sdktest.SendOutcome{Err: breez_sdk.NewSendPaymentErrorRouteNotFound()})
+//	app := NewApp(mock)
+//
+// Invoices created by the mock are not valid BOLT11 strings; they are only
+// understood by the mock itself.
+package sdktest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/breez/breez-sdk-go/breez_sdk"
+)
+
+// invoicePrefix starts the fake invoices of the mock, followed by the payment
+// hash.
+const invoicePrefix = "lnsdktest"
+
+// SendOutcome scripts the result of a SendPayment or SendSpontaneousPayment
+// call. A nil Err succeeds.
+type SendOutcome struct {
+	Err     error
+	FeeMsat uint64
+}
+
+type invoice struct {
+	invoice  breez_sdk.LnInvoice
+	preimage string
+}
+
+// Mock is an in-memory SDK service. Its zero value is not usable; create it
+// with New.
+type Mock struct {
+	lock       sync.Mutex
+	node       breez_sdk.NodeState
+	payments   []breez_sdk.Payment
+	invoices   map[string]invoice
+	outcomes   []SendOutcome
+	failures   map[string]error
+	rates      []breez_sdk.Rate
+	listener   breez_sdk.EventListener
+	calls      []string
+	counter    int
+	lastBackup *uint64
+	now        func() time.Time
+}
+
+// New returns a Mock of a node without funds.
+func New() *Mock {
+	return &Mock{
+		node: breez_sdk.NodeState{
+			Id:             "02" + strings.Repeat("00", 32),
+			BlockHeight:    800_000,
+			ConnectedPeers: []string{"03" + strings.Repeat("11", 32)},
+		},
+		invoices: map[string]invoice{},
+		failures: map[string]error{},
+		now:      time.Now,
+	}
+}
+
+// SetListener sets the listener that receives the events of the mock.
+func (m *Mock) SetListener(listener breez_sdk.EventListener) {
+	m.lock.Lock()
+	m.listener = listener
+	m.lock.Unlock()
+}
+
+// SetClock replaces the time source of payment times.
+func (m *Mock) SetClock(now func() time.Time) {
+	m.lock.Lock()
+	m.now = now
+	m.lock.Unlock()
+}
+
+// SetNodeState replaces the state NodeInfo returns.
+func (m *Mock) SetNodeState(state breez_sdk.NodeState) {
+	m.lock.Lock()
+	m.node = state
+	m.lock.Unlock()
+}
+
+// SetBalance sets the channel and onchain balances, and the amounts that can
+// be paid and received accordingly.
+func (m *Mock) SetBalance(channelsMsat, onchainMsat uint64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.node.ChannelsBalanceMsat = channelsMsat
+	m.node.OnchainBalanceMsat = onchainMsat
+	m.updateLimitsLocked()
+}
+
+func (m *Mock) updateLimitsLocked() {
+	m.node.MaxPayableMsat = m.node.ChannelsBalanceMsat
+	m.node.MaxSinglePaymentAmountMsat = m.node.ChannelsBalanceMsat
+}
+
+// SetFiatRates sets the rates FetchFiatRates returns.
+func (m *Mock) SetFiatRates(rates []breez_sdk.Rate) {
+	m.lock.Lock()
+	m.rates = rates
+	m.lock.Unlock()
+}
+
+// ScriptSend queues the outcomes of the next payments sent. Payments sent
+// without a scripted outcome succeed without fee.
+func (m *Mock) ScriptSend(outcomes ...SendOutcome) {
+	m.lock.Lock()
+	m.outcomes = append(m.outcomes, outcomes...)
+	m.lock.Unlock()
+}
+
+// Fail makes every call to method, such as "NodeInfo", return err until
+// Fail is called again with a nil err.
+func (m *Mock) Fail(method string, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if err == nil {
+		delete(m.failures, method)
+	} else {
+		m.failures[method] = err
+	}
+}
+
+// AddPayment adds payment to the payment history.
+func (m *Mock) AddPayment(payment breez_sdk.Payment) {
+	m.lock.Lock()
+	m.payments = append(m.payments, payment)
+	m.lock.Unlock()
+}
+
+// Emit sends e to the listener.
+func (m *Mock) Emit(e breez_sdk.BreezEvent) {
+	m.lock.Lock()
+	listener := m.listener
+	m.lock.Unlock()
+	if listener != nil {
+		listener.OnEvent(e)
+	}
+}
+
+// Calls returns the names of the methods called so far, in order.
+func (m *Mock) Calls() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]string(nil), m.calls...)
+}
+
+// call records a call to method and returns the error it was set to fail with.
+func (m *Mock) call(method string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.calls = append(m.calls, method)
+	return m.failures[method]
+}
+
+func (m *Mock) nextHashLocked() (hash string, preimage string) {
+	m.counter++
+	image := sha256.Sum256([]byte(fmt.Sprintf("sdktest-%d", m.counter)))
+	digest := sha256.Sum256(image[:])
+	return hex.EncodeToString(digest[:]), hex.EncodeToString(image[:])
+}
+
+// PayInvoice simulates the payment of an invoice created by ReceivePayment:
+// the received payment is stored, the balance updated and an InvoicePaid
+// event emitted.
+func (m *Mock) PayInvoice(bolt11 string) (breez_sdk.Payment, error) {
+	m.lock.Lock()
+	hash := strings.TrimPrefix(bolt11, invoicePrefix)
+	created, ok := m.invoices[hash]
+	if !ok {
+		m.lock.Unlock()
+		return breez_sdk.Payment{}, fmt.Errorf("unknown invoice %s", bolt11)
+	}
+	delete(m.invoices, hash)
+	amountMsat := uint64(0)
+	if created.invoice.AmountMsat != nil {
+		amountMsat = *created.invoice.AmountMsat
+	}
+	payment := breez_sdk.Payment{
+		Id:          hash,
+		PaymentType: breez_sdk.PaymentTypeReceived,
+		PaymentTime: m.now().Unix(),
+		AmountMsat:  amountMsat,
+		Status:      breez_sdk.PaymentStatusComplete,
+		Description: created.invoice.Description,
+		Details: breez_sdk.PaymentDetailsLn{Data: breez_sdk.LnPaymentDetails{
+			PaymentHash:       hash,
+			PaymentPreimage:   created.preimage,
+			DestinationPubkey: m.node.Id,
+			Bolt11:            created.invoice.Bolt11,
+		}},
+	}
+	m.payments = append(m.payments, payment)
+	m.node.ChannelsBalanceMsat += amountMsat
+	m.updateLimitsLocked()
+	m.lock.Unlock()
+
+	m.Emit(breez_sdk.BreezEventInvoicePaid{Details: breez_sdk.InvoicePaidDetails{
+		PaymentHash: hash,
+		Bolt11:      created.invoice.Bolt11,
+		Payment:     &payment,
+	}})
+	return payment, nil
+}
+
+func (m *Mock) Disconnect() error {
+	return m.call("Disconnect")
+}
+
+func (m *Mock) NodeInfo() (breez_sdk.NodeState, error) {
+	if err := m.call("NodeInfo"); err != nil {
+		return breez_sdk.NodeState{}, err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.node, nil
+}
+
+func (m *Mock) ReceivePayment(req breez_sdk.ReceivePaymentRequest) (breez_sdk.ReceivePaymentResponse, error) {
+	if err := m.call("ReceivePayment"); err != nil {
+		return breez_sdk.ReceivePaymentResponse{}, err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	hash, preimage := m.nextHashLocked()
+	amountMsat := req.AmountMsat
+	description := req.Description
+	created := breez_sdk.LnInvoice{
+		Bolt11:      invoicePrefix + hash,
+		Network:     breez_sdk.NetworkRegtest,
+		PayeePubkey: m.node.Id,
+		PaymentHash: hash,
+		Description: &description,
+		AmountMsat:  &amountMsat,
+		Timestamp:   uint64(m.now().Unix()),
+		Expiry:      3600,
+	}
+	if req.UseDescriptionHash != nil && *req.UseDescriptionHash {
+		descriptionHash := breez_sdk.DescriptionHash(req.Description)
+		created.Description = nil
+		created.DescriptionHash = &descriptionHash
+	}
+	m.invoices[hash] = invoice{invoice: created, preimage: preimage}
+	return breez_sdk.ReceivePaymentResponse{LnInvoice: created}, nil
+}
+
+func (m *Mock) SendPayment(req breez_sdk.SendPaymentRequest) (breez_sdk.SendPaymentResponse, error) {
+	if err := m.call("SendPayment"); err != nil {
+		return breez_sdk.SendPaymentResponse{}, err
+	}
+	hash := strings.TrimPrefix(req.Bolt11, invoicePrefix)
+	m.lock.Lock()
+	amountMsat := uint64(0)
+	if req.AmountMsat != nil {
+		amountMsat = *req.AmountMsat
+	} else if created, ok := m.invoices[hash]; ok && created.invoice.AmountMsat != nil {
+		amountMsat = *created.invoice.AmountMsat
+	}
+	m.lock.Unlock()
+	if hash == req.Bolt11 {
+		digest := sha256.Sum256([]byte(req.Bolt11))
+		hash = hex.EncodeToString(digest[:])
+	}
+	return m.send(hash, req.Bolt11, "", amountMsat, req.Label)
+}
+
+func (m *Mock) SendSpontaneousPayment(req breez_sdk.SendSpontaneousPaymentRequest) (breez_sdk.SendPaymentResponse, error) {
+	if err := m.call("SendSpontaneousPayment"); err != nil {
+		return breez_sdk.SendPaymentResponse{}, err
+	}
+	m.lock.Lock()
+	hash, _ := m.nextHashLocked()
+	m.lock.Unlock()
+	return m.send(hash, "", req.NodeId, req.AmountMsat, req.Label)
+}
+
+func (m *Mock) send(hash, bolt11, destination string, amountMsat uint64, label *string) (breez_sdk.SendPaymentResponse, error) {
+	m.lock.Lock()
+	outcome := SendOutcome{}
+	if len(m.outcomes) > 0 {
+		outcome = m.outcomes[0]
+		m.outcomes = m.outcomes[1:]
+	}
+	if outcome.Err == nil && amountMsat+outcome.FeeMsat > m.node.ChannelsBalanceMsat {
+		outcome.Err = breez_sdk.NewSendPaymentErrorInvalidAmount()
+	}
+
+	payment := breez_sdk.Payment{
+		Id:          hash,
+		PaymentType: breez_sdk.PaymentTypeSent,
+		PaymentTime: m.now().Unix(),
+		AmountMsat:  amountMsat,
+		FeeMsat:     outcome.FeeMsat,
+		Status:      breez_sdk.PaymentStatusComplete,
+		Details: breez_sdk.PaymentDetailsLn{Data: breez_sdk.LnPaymentDetails{
+			PaymentHash:       hash,
+			DestinationPubkey: destination,
+			Bolt11:            bolt11,
+			Keysend:           bolt11 == "",
+		}},
+	}
+	if label != nil {
+		details := payment.Details.(breez_sdk.PaymentDetailsLn)
+		details.Data.Label = *label
+		payment.Details = details
+	}
+	if outcome.Err != nil {
+		message := outcome.Err.Error()
+		payment.Status = breez_sdk.PaymentStatusFailed
+		payment.FeeMsat = 0
+		payment.Error = &message
+	} else {
+		m.node.ChannelsBalanceMsat -= amountMsat + outcome.FeeMsat
+		m.updateLimitsLocked()
+	}
+	m.payments = append(m.payments, payment)
+	m.lock.Unlock()
+
+	if outcome.Err != nil {
+		m.Emit(breez_sdk.BreezEventPaymentFailed{Details: breez_sdk.PaymentFailedData{
+			Error:  *payment.Error,
+			NodeId: destination,
+			Label:  label,
+		}})
+		return breez_sdk.SendPaymentResponse{}, outcome.Err
+	}
+	m.Emit(breez_sdk.BreezEventPaymentSucceed{Details: payment})
+	return breez_sdk.SendPaymentResponse{Payment: payment}, nil
+}
+
+func (m *Mock) ListPayments(req breez_sdk.ListPaymentsRequest) ([]breez_sdk.Payment, error) {
+	if err := m.call("ListPayments"); err != nil {
+		return nil, err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var matching []breez_sdk.Payment
+	// Newest first, like the SDK.
+	for i := len(m.payments) - 1; i >= 0; i-- {
+		if paymentMatches(m.payments[i], req) {
+			matching = append(matching, m.payments[i])
+		}
+	}
+	if req.Offset != nil {
+		if int(*req.Offset) >= len(matching) {
+			return []breez_sdk.Payment{}, nil
+		}
+		matching = matching[*req.Offset:]
+	}
+	if req.Limit != nil && int(*req.Limit) < len(matching) {
+		matching = matching[:*req.Limit]
+	}
+	if matching == nil {
+		matching = []breez_sdk.Payment{}
+	}
+	return matching, nil
+}
+
+func paymentMatches(payment breez_sdk.Payment, req breez_sdk.ListPaymentsRequest) bool {
+	if payment.Status == breez_sdk.PaymentStatusFailed && (req.IncludeFailures == nil || !*req.IncludeFailures) {
+		return false
+	}
+	if req.FromTimestamp != nil && payment.PaymentTime < *req.FromTimestamp {
+		return false
+	}
+	if req.ToTimestamp != nil && payment.PaymentTime > *req.ToTimestamp {
+		return false
+	}
+	if req.Filters != nil && len(*req.Filters) > 0 {
+		found := false
+		for _, filter := range *req.Filters {
+			if uint(filter) == uint(payment.PaymentType) {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if req.MetadataFilters != nil {
+		for _, filter := range *req.MetadataFilters {
+			if !metadataMatches(payment.Metadata, filter) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// metadataMatches supports the "$.a.b" paths of metadata filters.
+func metadataMatches(metadata *string, filter breez_sdk.MetadataFilter) bool {
+	if metadata == nil {
+		return false
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(*metadata), &value); err != nil {
+		return false
+	}
+	for _, key := range strings.Split(strings.TrimPrefix(filter.JsonPath, "$."), ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if value, ok = object[key]; !ok {
+			return false
+		}
+	}
+	var expected interface{}
+	if err := json.Unmarshal([]byte(filter.JsonValue), &expected); err != nil {
+		return false
+	}
+	got, _ := json.Marshal(value)
+	want, _ := json.Marshal(expected)
+	return bytes.Equal(got, want)
+}
+
+func (m *Mock) PaymentByHash(hash string) (*breez_sdk.Payment, error) {
+	if err := m.call("PaymentByHash"); err != nil {
+		return nil, err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for i := len(m.payments) - 1; i >= 0; i-- {
+		if m.payments[i].Id == hash {
+			payment := m.payments[i]
+			return &payment, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *Mock) SetPaymentMetadata(hash string, metadata string) error {
+	if err := m.call("SetPaymentMetadata"); err != nil {
+		return err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for i := range m.payments {
+		if m.payments[i].Id == hash {
+			value := metadata
+			m.payments[i].Metadata = &value
+			return nil
+		}
+	}
+	return breez_sdk.NewSdkErrorGeneric()
+}
+
+func (m *Mock) Sync() error {
+	if err := m.call("Sync"); err != nil {
+		return err
+	}
+	m.Emit(breez_sdk.BreezEventSynced{})
+	return nil
+}
+
+func (m *Mock) Backup() error {
+	if err := m.call("Backup"); err != nil {
+		return err
+	}
+	m.lock.Lock()
+	now := uint64(m.now().Unix())
+	m.lastBackup = &now
+	m.lock.Unlock()
+	m.Emit(breez_sdk.BreezEventBackupStarted{})
+	m.Emit(breez_sdk.BreezEventBackupSucceeded{})
+	return nil
+}
+
+func (m *Mock) BackupStatus() (breez_sdk.BackupStatus, error) {
+	if err := m.call("BackupStatus"); err != nil {
+		return breez_sdk.BackupStatus{}, err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return breez_sdk.BackupStatus{BackedUp: m.lastBackup != nil, LastBackupTime: m.lastBackup}, nil
+}
+
+func (m *Mock) FetchFiatRates() ([]breez_sdk.Rate, error) {
+	if err := m.call("FetchFiatRates"); err != nil {
+		return nil, err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]breez_sdk.Rate(nil), m.rates...), nil
+}
+
+// SignMessage returns a fake signature, only accepted by CheckMessage of a
+// Mock.
+func (m *Mock) SignMessage(req breez_sdk.SignMessageRequest) (breez_sdk.SignMessageResponse, error) {
+	if err := m.call("SignMessage"); err != nil {
+		return breez_sdk.SignMessageResponse{}, err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return breez_sdk.SignMessageResponse{Signature: fakeSignature(m.node.Id, req.Message)}, nil
+}
+
+func (m *Mock) CheckMessage(req breez_sdk.CheckMessageRequest) (breez_sdk.CheckMessageResponse, error) {
+	if err := m.call("CheckMessage"); err != nil {
+		return breez_sdk.CheckMessageResponse{}, err
+	}
+	return breez_sdk.CheckMessageResponse{IsValid: req.Signature == fakeSignature(req.Pubkey, req.Message)}, nil
+}
+
+func fakeSignature(pubkey, message string) string {
+	digest := sha256.Sum256([]byte(pubkey + "\n" + message))
+	return hex.EncodeToString(digest[:])
+}
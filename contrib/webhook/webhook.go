@@ -0,0 +1,148 @@
+// Package webhook decodes the notifications the Breez notification service
+// sends to webhooks registered with RegisterWebhook, and serves them as an
+// http.Handler.
+//
+// The service does not sign its requests. Instead, the registered URL carries a
+// secret token, which the handler checks:
+//
+//	url, _ := webhook.URL("https://example.com/breez", secret)
+//	sdk.RegisterWebhook(url)
+//	http.Handle("/breez", webhook.Handler(secret, func(p webhook.Payload) error { ... }))
+package webhook
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// TokenParam is the query parameter carrying the secret token.
+const TokenParam = "token"
+
+// Templates of the notifications.
+const (
+	TemplatePaymentReceived     = "payment_received"
+	TemplateAddressTxsConfirmed = "address_txs_confirmed"
+	TemplateLnurlPayInfo        = "lnurlpay_info"
+	TemplateLnurlPayInvoice     = "lnurlpay_invoice"
+)
+
+// Payload is a notification. Data is decoded by the accessor of its Template.
+type Payload struct {
+	Template string          `json:"template"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// PaymentReceivedData notifies of an incoming payment.
+type PaymentReceivedData struct {
+	PaymentHash string `json:"payment_hash"`
+}
+
+// AddressTxsConfirmedData notifies of confirmed transactions to a swap
+// address.
+type AddressTxsConfirmedData struct {
+	Address string `json:"address"`
+}
+
+// LnurlPayInfoData requests the LNURL-pay info to be sent to ReplyUrl.
+type LnurlPayInfoData struct {
+	CallbackUrl string `json:"callback_url"`
+	ReplyUrl    string `json:"reply_url"`
+}
+
+// LnurlPayInvoiceData requests an invoice for Amount msat to be sent to
+// ReplyUrl.
+type LnurlPayInvoiceData struct {
+	Amount   uint64 `json:"amount"`
+	ReplyUrl string `json:"reply_url"`
+}
+
+// PaymentReceived decodes a payment_received notification.
+func (p Payload) PaymentReceived() (PaymentReceivedData, error) {
+	var data PaymentReceivedData
+	return data, p.decode(TemplatePaymentReceived, &data)
+}
+
+// AddressTxsConfirmed decodes an address_txs_confirmed notification.
+func (p Payload) AddressTxsConfirmed() (AddressTxsConfirmedData, error) {
+	var data AddressTxsConfirmedData
+	return data, p.decode(TemplateAddressTxsConfirmed, &data)
+}
+
+// LnurlPayInfo decodes an lnurlpay_info notification.
+func (p Payload) LnurlPayInfo() (LnurlPayInfoData, error) {
+	var data LnurlPayInfoData
+	return data, p.decode(TemplateLnurlPayInfo, &data)
+}
+
+// LnurlPayInvoice decodes an lnurlpay_invoice notification.
+func (p Payload) LnurlPayInvoice() (LnurlPayInvoiceData, error) {
+	var data LnurlPayInvoiceData
+	return data, p.decode(TemplateLnurlPayInvoice, &data)
+}
+
+func (p Payload) decode(template string, v interface{}) error {
+	if p.Template != template {
+		return fmt.Errorf("%s notification is not %s", p.Template, template)
+	}
+	return json.Unmarshal(p.Data, v)
+}
+
+// Decode reads a notification.
+func Decode(r io.Reader) (Payload, error) {
+	var payload Payload
+	if err := json.NewDecoder(r).Decode(&payload); err != nil {
+		return Payload{}, err
+	}
+	if payload.Template == "" {
+		return Payload{}, fmt.Errorf("notification without template")
+	}
+	return payload, nil
+}
+
+// URL adds secret to the webhook URL base, to be passed to RegisterWebhook.
+func URL(base string, secret string) (string, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set(TokenParam, secret)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// Verify reports whether r carries secret.
+func Verify(r *http.Request, secret string) bool {
+	token := r.URL.Query().Get(TokenParam)
+	return secret != "" && subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+}
+
+// Handler verifies and decodes notifications and passes them to fn. It replies
+// 401 to requests without the secret, 400 to undecodable ones and 500 when fn
+// fails, so that the notification service retries.
+func Handler(secret string, fn func(Payload) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if !Verify(r, secret) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		payload, err := Decode(http.MaxBytesReader(w, r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := fn(payload); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}